@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"crypto/tls"
 	"flag"
 	"fmt"
 	"net"
@@ -12,10 +13,21 @@ import (
 func main() {
 	host := flag.String("host", "localhost", "server host")
 	port := flag.String("port", "4000", "server port")
+	useTLS := flag.Bool("tls", false, "connect using TLS (use the server's tls_port)")
+	insecure := flag.Bool("insecure", false, "skip TLS certificate verification (self-signed certs)")
 	flag.Parse()
 
 	addr := net.JoinHostPort(*host, *port)
-	conn, err := net.Dial("tcp", addr)
+	var conn net.Conn
+	var err error
+	if *useTLS {
+		conn, err = tls.Dial("tcp", addr, &tls.Config{
+			ServerName:         *host,
+			InsecureSkipVerify: *insecure,
+		})
+	} else {
+		conn, err = net.Dial("tcp", addr)
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Connection failed: %v\n", err)
 		os.Exit(1)
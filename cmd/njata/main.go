@@ -9,12 +9,15 @@ import (
 	"time"
 
 	"njata/internal/area"
+	"njata/internal/bans"
 	"njata/internal/commands"
 	"njata/internal/config"
 	"njata/internal/game"
 	"njata/internal/netserver"
+	"njata/internal/quests"
 	"njata/internal/races"
 	"njata/internal/skills"
+	"njata/internal/socials"
 )
 
 func main() {
@@ -51,8 +54,28 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err := quests.Load("quests/quests.json"); err != nil {
+		fmt.Printf("Quests load error: %v\n", err)
+	}
+
+	if err := bans.Load("system/bans.json"); err != nil {
+		fmt.Printf("Ban list load error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := socials.Load("system/socials.json"); err != nil {
+		fmt.Printf("Socials load error: %v\n", err)
+	}
+
 	world := game.CreateWorldFromRooms(rooms, start)
 	world.SetPrototypes(mobiles, objects)
+	world.SetDeathPolicy(game.DeathPolicy{
+		RecallVnum:      cfg.RecallRoomVnum,
+		GoldLossPercent: cfg.DeathGoldLossPercent,
+		ProficiencyLoss: cfg.DeathProficiencyLoss,
+		CorpseDecay:     time.Duration(cfg.CorpseDecayMinutes) * time.Minute,
+		MobCorpseDecay:  time.Duration(cfg.MobCorpseDecayMinutes) * time.Minute,
+	})
 	registry := commands.NewRegistry()
 	commands.RegisterBuiltins(registry)
 
@@ -60,7 +83,7 @@ func main() {
 		fmt.Println(message)
 	}
 
-	server := netserver.NewServer(world, registry, *port, logger)
+	server := netserver.NewServer(world, registry, cfg, *port, logger)
 
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
 	defer stop()
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"njata/internal/game"
+	"njata/internal/script"
 )
 
 func LoadRoomsFromDir(path string) (map[int]*game.Room, map[int]*game.Mobile, map[int]*game.Object, int, error) {
@@ -51,9 +52,8 @@ func LoadRoomsFromDir(path string) (map[int]*game.Room, map[int]*game.Mobile, ma
 		for _, reset := range room.MobileResets {
 			if proto, ok := mobiles[reset.MobVnum]; ok {
 				for i := 0; i < reset.Count; i++ {
-					// Create a copy of the prototype
-					mobCopy := *proto
-					room.Mobiles = append(room.Mobiles, &mobCopy)
+					// Create a copy of the prototype, carrying the reset's items
+					room.Mobiles = append(room.Mobiles, game.ResetMobile(proto, reset, objects))
 				}
 			}
 		}
@@ -95,7 +95,12 @@ func parseAreasFromJSON(path string) (map[int]*game.Room, map[int]*game.Mobile,
 			MobileResets []struct {
 				Vnum  int `json:"vnum"`
 				Count int `json:"count"`
+				Items []struct {
+					Vnum  int `json:"vnum"`
+					Count int `json:"count"`
+				} `json:"items"`
 			} `json:"mobile_resets"`
+			Scripts      []script.Script   `json:"scripts"`
 			ObjectResets []struct {
 				Vnum  int `json:"vnum"`
 				Count int `json:"count"`
@@ -120,6 +125,17 @@ func parseAreasFromJSON(path string) (map[int]*game.Room, map[int]*game.Mobile,
 				Vnum  int `json:"vnum"`
 				Count int `json:"count"`
 			} `json:"loot"`
+			GoldMin   int              `json:"gold_min"`
+			GoldMax   int              `json:"gold_max"`
+			Behaviors map[string]bool  `json:"behaviors"`
+			Skills    []game.MobSkill `json:"skills"`
+			Scripts   []script.Script `json:"scripts"`
+			Shop      *game.Shop      `json:"shop"`
+			IsBanker  bool            `json:"is_banker"`
+			// Damage defenses
+			Resistances     []string `json:"resistances"`
+			Immunities      []string `json:"immunities"`
+			Vulnerabilities []string `json:"vulnerabilities"`
 			// Trainer fields
 			IsTrainer         bool   `json:"is_trainer"`
 			TeachesSpellID    int    `json:"teaches_spell_id"`
@@ -135,12 +151,14 @@ func parseAreasFromJSON(path string) (map[int]*game.Room, map[int]*game.Mobile,
 			Long      string          `json:"long"`
 			Weight    int             `json:"weight"`
 			Value     interface{}     `json:"value"` // Can be int or [4]int
+			Cost      int             `json:"cost"`
 			Flags     map[string]bool `json:"flags"`
 			EquipSlot string          `json:"equip_slot"`
 			ArmorVal  int             `json:"armor_value"`
 			TeachesSpellID int         `json:"teaches_spell_id"`
 			TeachesAmount  int         `json:"teaches_amount"`
 			Consumable     bool        `json:"consumable"`
+			Scripts        []script.Script `json:"scripts"`
 		} `json:"objects"`
 	}
 
@@ -150,12 +168,24 @@ func parseAreasFromJSON(path string) (map[int]*game.Room, map[int]*game.Mobile,
 
 	rooms := make(map[int]*game.Room)
 	for _, roomJSON := range areaJSON.Rooms {
+		if err := checkScripts(roomJSON.Scripts); err != nil {
+			return nil, nil, nil, fmt.Errorf("room %d: %w", roomJSON.Vnum, err)
+		}
+
 		// Parse resets
 		mobResets := make([]game.Reset, len(roomJSON.MobileResets))
 		for i, mr := range roomJSON.MobileResets {
+			items := make([]game.LootEntry, 0, len(mr.Items))
+			for _, item := range mr.Items {
+				items = append(items, game.LootEntry{
+					Vnum:  item.Vnum,
+					Count: item.Count,
+				})
+			}
 			mobResets[i] = game.Reset{
 				MobVnum: mr.Vnum,
 				Count:   mr.Count,
+				Items:   items,
 			}
 		}
 		objResets := make([]game.Reset, len(roomJSON.ObjectResets))
@@ -181,6 +211,7 @@ func parseAreasFromJSON(path string) (map[int]*game.Room, map[int]*game.Mobile,
 			Objects:          make([]*game.Object, 0),
 			MobileResets:     mobResets,
 			ObjectResets:     objResets,
+			Scripts:          roomJSON.Scripts,
 		}
 		if room.Vnum > 0 {
 			rooms[room.Vnum] = room
@@ -189,6 +220,10 @@ func parseAreasFromJSON(path string) (map[int]*game.Room, map[int]*game.Mobile,
 
 	mobiles := make(map[int]*game.Mobile)
 	for _, mobJSON := range areaJSON.Mobiles {
+		if err := checkScripts(mobJSON.Scripts); err != nil {
+			return nil, nil, nil, fmt.Errorf("mobile %d: %w", mobJSON.Vnum, err)
+		}
+
 		loot := make([]game.LootEntry, 0, len(mobJSON.Loot))
 		for _, entry := range mobJSON.Loot {
 			loot = append(loot, game.LootEntry{
@@ -212,7 +247,19 @@ func parseAreasFromJSON(path string) (map[int]*game.Room, map[int]*game.Mobile,
 			Mana:       mobJSON.Mana,
 			MaxMana:    mobJSON.MaxMana,
 			Attributes: mobJSON.Attributes,
+			Behaviors:  mobJSON.Behaviors,
+			AreaName:   areaJSON.Name,
+			Skills:     mobJSON.Skills,
+			Scripts:    mobJSON.Scripts,
+			Shop:       mobJSON.Shop,
+			IsBanker:   mobJSON.IsBanker,
 			Loot:       loot,
+			GoldMin:    mobJSON.GoldMin,
+			GoldMax:    mobJSON.GoldMax,
+			// Damage defenses
+			Resistances:     mobJSON.Resistances,
+			Immunities:      mobJSON.Immunities,
+			Vulnerabilities: mobJSON.Vulnerabilities,
 			// Trainer fields
 			IsTrainer:         mobJSON.IsTrainer,
 			TeachesSpellID:    mobJSON.TeachesSpellID,
@@ -227,6 +274,10 @@ func parseAreasFromJSON(path string) (map[int]*game.Room, map[int]*game.Mobile,
 
 	objects := make(map[int]*game.Object)
 	for _, objJSON := range areaJSON.Objects {
+		if err := checkScripts(objJSON.Scripts); err != nil {
+			return nil, nil, nil, fmt.Errorf("object %d: %w", objJSON.Vnum, err)
+		}
+
 		// Handle both int and [4]int value formats
 		var objValue [4]int
 		switch v := objJSON.Value.(type) {
@@ -250,12 +301,14 @@ func parseAreasFromJSON(path string) (map[int]*game.Room, map[int]*game.Mobile,
 			Long:      objJSON.Long,
 			Weight:    objJSON.Weight,
 			Value:     objValue,
+			Cost:      objJSON.Cost,
 			Flags:     objJSON.Flags,
 			EquipSlot: objJSON.EquipSlot,
 			ArmorVal:  objJSON.ArmorVal,
 			TeachesSpellID: objJSON.TeachesSpellID,
 			TeachesAmount:  objJSON.TeachesAmount,
 			Consumable:     objJSON.Consumable,
+			Scripts:        objJSON.Scripts,
 		}
 		if obj.Vnum > 0 {
 			objects[obj.Vnum] = obj
@@ -265,6 +318,28 @@ func parseAreasFromJSON(path string) (map[int]*game.Room, map[int]*game.Mobile,
 	return rooms, mobiles, objects, nil
 }
 
+// checkScripts rejects scripts with unknown triggers, bad timer intervals or
+// code that doesn't parse, so mistakes surface when the area loads rather
+// than every time the script would run. Each script keeps its parsed code.
+func checkScripts(scripts []script.Script) error {
+	for i := range scripts {
+		s := &scripts[i]
+		trigger := script.NormalizeTrigger(s.Trigger)
+		if trigger == "" {
+			return fmt.Errorf("unknown script trigger %q", s.Trigger)
+		}
+		if trigger == script.TriggerTimer {
+			if _, err := script.Interval(s.Arg); err != nil {
+				return fmt.Errorf("%s script: %w", s.Trigger, err)
+			}
+		}
+		if err := s.Prepare(); err != nil {
+			return fmt.Errorf("%s script: %w", s.Trigger, err)
+		}
+	}
+	return nil
+}
+
 func findLowestVnum(rooms map[int]*game.Room) int {
 	if len(rooms) == 0 {
 		return 0
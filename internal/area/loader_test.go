@@ -4,6 +4,8 @@ import (
     "os"
     "path/filepath"
     "testing"
+
+    "njata/internal/script"
 )
 
 func TestLoadRoomsFromDir(t *testing.T) {
@@ -90,3 +92,21 @@ func TestLoadRoomsFromDir(t *testing.T) {
         t.Fatalf("expected exdesc for sign")
     }
 }
+
+func TestCheckScriptsRejectsBadCode(t *testing.T) {
+    good := []script.Script{{Trigger: "timer", Arg: "4", Code: []string{"echo tick"}}}
+    if err := checkScripts(good); err != nil {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    bad := map[string]script.Script{
+        "unknown trigger": {Trigger: "sometimes", Code: []string{"echo hi"}},
+        "bad interval":    {Trigger: "timer", Arg: "often", Code: []string{"echo tick"}},
+        "bad code":        {Trigger: "random", Arg: "10", Code: []string{"if ispc", "echo hi"}},
+    }
+    for name, s := range bad {
+        if err := checkScripts([]script.Script{s}); err == nil {
+            t.Errorf("%s: expected an error", name)
+        }
+    }
+}
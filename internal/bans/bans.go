@@ -0,0 +1,239 @@
+package bans
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// lookupTimeout bounds the reverse DNS lookup made when a hostname ban has to
+// be checked against a connecting IP.
+const lookupTimeout = 3 * time.Second
+
+// Ban levels, following legacy/system/ban.lst semantics.
+const (
+	LevelAll    = "all"    // nobody from the site may connect
+	LevelNewbie = "newbie" // existing characters may log in, new ones may not be created
+)
+
+// Ban describes a single site ban. Sites may start and/or end with '*' to
+// match any suffix or prefix, e.g. "*.example.com" or "192.168.*".
+type Ban struct {
+	Site     string    `json:"site"`
+	Level    string    `json:"level"`
+	BannedBy string    `json:"banned_by"`
+	BannedAt time.Time `json:"banned_at"`
+	Note     string    `json:"note"`
+}
+
+var (
+	banList []*Ban
+	banPath string
+	mu      sync.RWMutex
+
+	// lookupAddr resolves an IP to its host names; tests replace it.
+	lookupAddr = func(ip string) []string {
+		ctx, cancel := context.WithTimeout(context.Background(), lookupTimeout)
+		defer cancel()
+		names, _ := net.DefaultResolver.LookupAddr(ctx, ip)
+		return names
+	}
+)
+
+// Load reads the ban list from path. A missing file yields an empty list.
+func Load(path string) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	banPath = path
+	banList = nil
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to read ban list: %w", err)
+	}
+
+	var loaded []*Ban
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return fmt.Errorf("failed to parse ban list: %w", err)
+	}
+
+	banList = loaded
+	return nil
+}
+
+// save writes the ban list back to disk. Callers must hold mu.
+func save() error {
+	if banPath == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(banPath), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(banList, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(banPath, data, 0644)
+}
+
+// Add bans a site at the given level, replacing any existing ban on the same site.
+func Add(site string, level string, by string, note string) (*Ban, error) {
+	site = strings.ToLower(strings.TrimSpace(site))
+	if site == "" || strings.Trim(site, "*") == "" {
+		return nil, fmt.Errorf("invalid site")
+	}
+
+	switch level {
+	case "":
+		level = LevelAll
+	case LevelAll, LevelNewbie:
+	default:
+		return nil, fmt.Errorf("unknown ban level %q", level)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	ban := &Ban{
+		Site:     site,
+		Level:    level,
+		BannedBy: by,
+		BannedAt: time.Now(),
+		Note:     note,
+	}
+
+	replaced := false
+	for i, existing := range banList {
+		if existing.Site == site {
+			banList[i] = ban
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		banList = append(banList, ban)
+	}
+
+	return ban, save()
+}
+
+// Remove lifts the ban on site. It returns false if no such ban exists.
+func Remove(site string) (bool, error) {
+	site = strings.ToLower(strings.TrimSpace(site))
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	for i, existing := range banList {
+		if existing.Site == site {
+			banList = append(banList[:i], banList[i+1:]...)
+			return true, save()
+		}
+	}
+
+	return false, nil
+}
+
+// List returns all bans sorted by site.
+func List() []Ban {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	result := make([]Ban, 0, len(banList))
+	for _, ban := range banList {
+		result = append(result, *ban)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Site < result[j].Site
+	})
+	return result
+}
+
+// Check returns the ban that applies to host. Newbie-only bans apply only when newbie is true.
+// When host is an IP and a hostname ban is in force, the IP is resolved by reverse DNS so
+// patterns like "*.example.com" can match it.
+func Check(host string, newbie bool) (*Ban, bool) {
+	host = strings.ToLower(strings.TrimSpace(host))
+
+	mu.RLock()
+	candidates := make([]Ban, 0, len(banList))
+	for _, ban := range banList {
+		if ban.Level == LevelNewbie && !newbie {
+			continue
+		}
+		candidates = append(candidates, *ban)
+	}
+	mu.RUnlock()
+
+	var names []string
+	resolved := net.ParseIP(host) == nil
+	for i := range candidates {
+		ban := &candidates[i]
+		if matchSite(ban.Site, host) {
+			return ban, true
+		}
+		if !isHostnamePattern(ban.Site) {
+			continue
+		}
+		if !resolved {
+			names = resolveHost(host)
+			resolved = true
+		}
+		for _, name := range names {
+			if matchSite(ban.Site, name) {
+				return ban, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// resolveHost returns the lower-cased host names for ip, without the
+// trailing dot DNS gives them.
+func resolveHost(ip string) []string {
+	var names []string
+	for _, name := range lookupAddr(ip) {
+		names = append(names, strings.TrimSuffix(strings.ToLower(name), "."))
+	}
+	return names
+}
+
+// isHostnamePattern reports whether a ban names hosts rather than IPs.
+func isHostnamePattern(site string) bool {
+	return !strings.Contains(site, ":") && strings.IndexFunc(site, unicode.IsLetter) >= 0
+}
+
+func matchSite(pattern string, host string) bool {
+	prefix := strings.HasPrefix(pattern, "*")
+	suffix := strings.HasSuffix(pattern, "*")
+	name := strings.Trim(pattern, "*")
+
+	switch {
+	case prefix && suffix:
+		return strings.Contains(host, name)
+	case prefix:
+		return strings.HasSuffix(host, name)
+	case suffix:
+		return strings.HasPrefix(host, name)
+	default:
+		return host == name
+	}
+}
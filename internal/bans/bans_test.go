@@ -0,0 +1,94 @@
+package bans
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestMatchSite(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		host     string
+		expected bool
+	}{
+		{"10.0.0.1", "10.0.0.1", true},
+		{"10.0.0.1", "10.0.0.12", false},
+		{"10.0.*", "10.0.4.5", true},
+		{"*.example.com", "dialup.example.com", true},
+		{"*.example.com", "example.org", false},
+		{"*bad*", "very.bad.host", true},
+	}
+
+	for _, tt := range tests {
+		if got := matchSite(tt.pattern, tt.host); got != tt.expected {
+			t.Errorf("matchSite(%q, %q) = %v, want %v", tt.pattern, tt.host, got, tt.expected)
+		}
+	}
+}
+
+func TestAddCheckRemovePersist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bans.json")
+	if err := Load(path); err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	if _, err := Add("10.0.*", LevelNewbie, "Keeper", "alts"); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+
+	if _, banned := Check("10.0.0.5", false); banned {
+		t.Fatalf("newbie ban should not block existing characters")
+	}
+	if _, banned := Check("10.0.0.5", true); !banned {
+		t.Fatalf("newbie ban should block new characters")
+	}
+
+	// Reload from disk to verify persistence
+	if err := Load(path); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if len(List()) != 1 {
+		t.Fatalf("expected 1 persisted ban, got %d", len(List()))
+	}
+
+	removed, err := Remove("10.0.*")
+	if err != nil || !removed {
+		t.Fatalf("remove: %v %v", removed, err)
+	}
+	if _, banned := Check("10.0.0.5", true); banned {
+		t.Fatalf("expected ban to be lifted")
+	}
+}
+
+func TestCheckResolvesHostnameBans(t *testing.T) {
+	saved := lookupAddr
+	t.Cleanup(func() { lookupAddr = saved })
+	lookups := 0
+	lookupAddr = func(ip string) []string {
+		lookups++
+		if ip == "192.0.2.7" {
+			return []string{"Dialup7.Example.com."}
+		}
+		return nil
+	}
+
+	if err := Load(filepath.Join(t.TempDir(), "bans.json")); err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if _, err := Add("10.0.*", LevelAll, "Keeper", ""); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if _, banned := Check("192.0.2.7", false); banned || lookups != 0 {
+		t.Fatalf("expected no lookup without a hostname ban, got %d", lookups)
+	}
+
+	if _, err := Add("*.example.com", LevelAll, "Keeper", ""); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if ban, banned := Check("192.0.2.7", false); !banned || ban.Site != "*.example.com" {
+		t.Fatalf("expected the resolved host to be banned, got %v", ban)
+	}
+	if _, banned := Check("192.0.2.8", false); banned {
+		t.Fatalf("expected other hosts to be let in")
+	}
+}
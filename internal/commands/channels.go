@@ -0,0 +1,245 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"njata/internal/game"
+)
+
+// registerChannels adds one command per channel plus the private and room-level
+// communication commands.
+func registerChannels(registry *Registry) {
+	registry.Register("ooc", channelCommand("ooc"))
+	registry.Register("newbie", channelCommand("newbie"))
+	registry.Register("ktalk", channelCommand("keeper"))
+	registry.Register("channels", cmdChannels)
+	registry.Register("history", cmdHistory)
+	registry.Register("tell", cmdTell)
+	registry.Register("reply", cmdReply)
+	registry.Register("whisper", cmdWhisper)
+	registry.Register("yell", cmdYell)
+	registry.Register("emote", cmdEmote)
+	registry.Register("ignore", cmdIgnore)
+}
+
+// channelCommand returns a handler that speaks on the named channel.
+func channelCommand(name string) Handler {
+	return func(ctx Context, args string) {
+		if ctx.Player == nil {
+			ctx.Output.WriteLine("You must be logged in.")
+			return
+		}
+
+		args = strings.TrimSpace(args)
+		if args == "" {
+			ctx.Output.WriteLine(fmt.Sprintf("%s what?", strings.ToUpper(name[:1])+name[1:]))
+			return
+		}
+
+		if err := ctx.World.BroadcastChannel(ctx.Player, name, args); err != nil {
+			ctx.Output.WriteLine(err.Error())
+		}
+	}
+}
+
+func cmdChannels(ctx Context, args string) {
+	if ctx.Player == nil {
+		ctx.Output.WriteLine("You must be logged in.")
+		return
+	}
+
+	args = strings.ToLower(strings.TrimSpace(args))
+	if args == "" {
+		ctx.Output.WriteLine("Channels:")
+		for _, name := range ctx.World.ChannelNames(ctx.Player) {
+			state := "&Gon&w"
+			if !game.ChannelEnabled(ctx.Player, name) {
+				state = "&Roff&w"
+			}
+			ctx.Output.WriteLine(fmt.Sprintf("  %-10s %s", name, state))
+		}
+		ctx.Output.WriteLine("Usage: channels +<name> | -<name>")
+		return
+	}
+
+	on := true
+	switch args[0] {
+	case '+':
+		args = args[1:]
+	case '-':
+		on = false
+		args = args[1:]
+	default:
+		on = !game.ChannelEnabled(ctx.Player, args)
+	}
+
+	if err := ctx.World.SetChannel(ctx.Player, args, on); err != nil {
+		ctx.Output.WriteLine(err.Error())
+		return
+	}
+
+	if on {
+		ctx.Output.WriteLine(fmt.Sprintf("You are now listening to %s.", args))
+	} else {
+		ctx.Output.WriteLine(fmt.Sprintf("You are no longer listening to %s.", args))
+	}
+}
+
+func cmdHistory(ctx Context, args string) {
+	if ctx.Player == nil {
+		ctx.Output.WriteLine("You must be logged in.")
+		return
+	}
+
+	name := strings.ToLower(strings.TrimSpace(args))
+	if name == "" {
+		ctx.Output.WriteLine("History of which channel? (syntax: history <channel>)")
+		return
+	}
+
+	history, err := ctx.World.ChannelHistory(ctx.Player, name)
+	if err != nil {
+		ctx.Output.WriteLine(err.Error())
+		return
+	}
+
+	if len(history) == 0 {
+		ctx.Output.WriteLine(fmt.Sprintf("Nothing has been said on %s recently.", name))
+		return
+	}
+
+	ctx.Output.WriteLine(fmt.Sprintf("Recent messages on %s:", name))
+	for _, line := range history {
+		ctx.Output.WriteLine(line)
+	}
+}
+
+func cmdTell(ctx Context, args string) {
+	if ctx.Player == nil {
+		ctx.Output.WriteLine("You must be logged in.")
+		return
+	}
+
+	name, message := game.FirstArg(args)
+	if name == "" || message == "" {
+		ctx.Output.WriteLine("Tell whom what? (syntax: tell <player> <message>)")
+		return
+	}
+
+	if _, err := ctx.World.SendTell(ctx.Player, name, message); err != nil {
+		ctx.Output.WriteLine(err.Error())
+	}
+}
+
+func cmdReply(ctx Context, args string) {
+	if ctx.Player == nil {
+		ctx.Output.WriteLine("You must be logged in.")
+		return
+	}
+
+	message := strings.TrimSpace(args)
+	if message == "" {
+		ctx.Output.WriteLine("Reply what?")
+		return
+	}
+
+	if ctx.Player.ReplyTo == "" {
+		ctx.Output.WriteLine("You have nobody to reply to.")
+		return
+	}
+
+	if _, err := ctx.World.SendTell(ctx.Player, ctx.Player.ReplyTo, message); err != nil {
+		ctx.Output.WriteLine(err.Error())
+	}
+}
+
+func cmdWhisper(ctx Context, args string) {
+	if ctx.Player == nil {
+		ctx.Output.WriteLine("You must be logged in.")
+		return
+	}
+
+	name, message := game.FirstArg(args)
+	if name == "" || message == "" {
+		ctx.Output.WriteLine("Whisper what to whom? (syntax: whisper <player> <message>)")
+		return
+	}
+
+	target, ok := ctx.World.FindPlayerInRoom(ctx.Player, name)
+	if !ok {
+		ctx.Output.WriteLine("They aren't here.")
+		return
+	}
+	if target == ctx.Player {
+		ctx.Output.WriteLine("You whisper quietly to yourself.")
+		return
+	}
+
+	if err := ctx.World.Whisper(ctx.Player, target, message); err != nil {
+		ctx.Output.WriteLine(err.Error())
+	}
+}
+
+func cmdYell(ctx Context, args string) {
+	if ctx.Player == nil {
+		ctx.Output.WriteLine("You must be logged in.")
+		return
+	}
+
+	message := strings.TrimSpace(args)
+	if message == "" {
+		ctx.Output.WriteLine("Yell what?")
+		return
+	}
+
+	if err := ctx.World.Yell(ctx.Player, message); err != nil {
+		ctx.Output.WriteLine(err.Error())
+	}
+}
+
+func cmdEmote(ctx Context, args string) {
+	if ctx.Player == nil {
+		ctx.Output.WriteLine("You must be logged in.")
+		return
+	}
+
+	action := strings.TrimSpace(args)
+	if action == "" {
+		ctx.Output.WriteLine("Emote what?")
+		return
+	}
+
+	if err := ctx.World.Emote(ctx.Player, action); err != nil {
+		ctx.Output.WriteLine(err.Error())
+	}
+}
+
+func cmdIgnore(ctx Context, args string) {
+	if ctx.Player == nil {
+		ctx.Output.WriteLine("You must be logged in.")
+		return
+	}
+
+	name := strings.TrimSpace(args)
+	if name == "" {
+		names := ctx.World.IgnoredNames(ctx.Player)
+		if len(names) == 0 {
+			ctx.Output.WriteLine("You are not ignoring anyone.")
+			return
+		}
+		ctx.Output.WriteLine(fmt.Sprintf("You are ignoring: %s", strings.Join(names, ", ")))
+		return
+	}
+
+	if strings.EqualFold(name, ctx.Player.Name) {
+		ctx.Output.WriteLine("You can't ignore yourself.")
+		return
+	}
+
+	if ctx.World.ToggleIgnore(ctx.Player, name) {
+		ctx.Output.WriteLine(fmt.Sprintf("You now ignore %s.", game.CapitalizeName(name)))
+	} else {
+		ctx.Output.WriteLine(fmt.Sprintf("You stop ignoring %s.", game.CapitalizeName(name)))
+	}
+}
@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"njata/internal/game"
+)
+
+// parseAmount reads a gold amount, where "all" means everything available.
+func parseAmount(args string, all int) (int, bool) {
+	arg, _ := game.FirstArg(args)
+	if strings.EqualFold(arg, "all") {
+		return all, all > 0
+	}
+	amount, err := strconv.Atoi(arg)
+	return amount, err == nil && amount > 0
+}
+
+func cmdDeposit(ctx Context, args string) {
+	if ctx.Player == nil {
+		ctx.Output.WriteLine("You must be logged in.")
+		return
+	}
+
+	amount, ok := parseAmount(args, ctx.Player.Gold)
+	if !ok {
+		ctx.Output.WriteLine("Deposit how much gold?")
+		return
+	}
+
+	if err := ctx.World.Deposit(ctx.Player, amount); err != nil {
+		ctx.Output.WriteLine(err.Error())
+	}
+}
+
+func cmdWithdraw(ctx Context, args string) {
+	if ctx.Player == nil {
+		ctx.Output.WriteLine("You must be logged in.")
+		return
+	}
+
+	amount, ok := parseAmount(args, ctx.Player.Bank)
+	if !ok {
+		ctx.Output.WriteLine("Withdraw how much gold?")
+		return
+	}
+
+	if err := ctx.World.Withdraw(ctx.Player, amount); err != nil {
+		ctx.Output.WriteLine(err.Error())
+	}
+}
+
+func cmdBalance(ctx Context, args string) {
+	if ctx.Player == nil {
+		ctx.Output.WriteLine("You must be logged in.")
+		return
+	}
+
+	balance, err := ctx.World.Balance(ctx.Player)
+	if err != nil {
+		ctx.Output.WriteLine(err.Error())
+		return
+	}
+	ctx.Output.WriteLine(fmt.Sprintf("You have %d gold in the bank and %d on hand.", balance, ctx.Player.Gold))
+}
+
+// cmdEconomy shows keepers the gold ledger: where gold has come from and
+// gone to, and how the money supply has moved day by day.
+func cmdEconomy(ctx Context, args string) {
+	if ctx.Player == nil {
+		ctx.Output.WriteLine("You must be logged in.")
+		return
+	}
+
+	if !ctx.Player.IsKeeper {
+		ctx.Output.WriteLine("You do not have the authority to do that.")
+		return
+	}
+
+	ledger := ctx.World.Ledger()
+	created, destroyed := ledger.TotalCreated(), ledger.TotalDestroyed()
+
+	ctx.Output.WriteLine("&YEconomy ledger&w")
+	ctx.Output.WriteLine(fmt.Sprintf("Gold created:   %8d", created))
+	writeLedgerSources(ctx, ledger.Created)
+	ctx.Output.WriteLine(fmt.Sprintf("Gold destroyed: %8d", destroyed))
+	writeLedgerSources(ctx, ledger.Destroyed)
+	ctx.Output.WriteLine(fmt.Sprintf("Net change:     %+8d", created-destroyed))
+
+	carried, banked := ctx.World.GoldHeld()
+	ctx.Output.WriteLine(fmt.Sprintf("Players online hold %d gold and have %d banked.", carried, banked))
+
+	if len(ledger.Days) == 0 {
+		return
+	}
+	ctx.Output.WriteLine("")
+	ctx.Output.WriteLine("Recent days      Created Destroyed       Net")
+	for _, day := range ledger.Days {
+		date := fmt.Sprintf("%d/%d/%d", day.Year, day.Month+1, day.Day+1)
+		ctx.Output.WriteLine(fmt.Sprintf("%-12s %11d %9d %+9d", date, day.Created, day.Destroyed, day.Created-day.Destroyed))
+	}
+}
+
+// writeLedgerSources lists a ledger column's sources, largest first.
+func writeLedgerSources(ctx Context, amounts map[string]int) {
+	sources := make([]string, 0, len(amounts))
+	for source := range amounts {
+		sources = append(sources, source)
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		if amounts[sources[i]] != amounts[sources[j]] {
+			return amounts[sources[i]] > amounts[sources[j]]
+		}
+		return sources[i] < sources[j]
+	})
+	for _, source := range sources {
+		ctx.Output.WriteLine(fmt.Sprintf("  %-14s %8d", source, amounts[source]))
+	}
+}
@@ -0,0 +1,220 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"njata/internal/game"
+	"njata/internal/skills"
+)
+
+// detectRadius is how many rooms away a detect spell senses creatures when
+// the spell doesn't give a radius of its own.
+const detectRadius = 2
+
+// spellCast is one casting of a non-damage spell, handed to its effect.
+type spellCast struct {
+	spell       *skills.Spell
+	targets     spellTargets
+	proficiency int
+}
+
+// spellEffect carries out what a non-damage spell does once it has been
+// cast and announced.
+type spellEffect func(ctx Context, cast spellCast)
+
+// spellEffects maps the effect types spells declare in skills.json
+// ("effects": {"type": "heal"}) to their handlers, so a new spell only
+// needs an entry there.
+var spellEffects = map[string]spellEffect{
+	"heal":     effectHeal,
+	"teleport": effectTeleport,
+	"blink":    effectBlink,
+	"identify": effectIdentify,
+	"detect":   effectDetect,
+	"summon":   effectSummon,
+}
+
+// effectHeal restores hit points to the target, rolled from the spell's
+// healing formula.
+func effectHeal(ctx Context, cast spellCast) {
+	p, target := ctx.Player, cast.targets.player
+	if target == nil {
+		return
+	}
+
+	amount := game.RollFormula(cast.spell.Effects.Healing, p) + cast.proficiency/20
+	healed := ctx.World.HealPlayer(target, amount)
+
+	actor, subject := game.PlayerSubject(p), game.PlayerSubject(target)
+	vars := spellVars(cast.spell, healed)
+	ctx.World.Act(cast.spell.Messages.Hit, actor, subject, vars, game.ToActor)
+	ctx.World.Act(cast.spell.Messages.Hit, actor, subject, vars, game.ToRoom)
+}
+
+// effectTeleport sends the target to the spell's room, or to the recall
+// room if it names none.
+func effectTeleport(ctx Context, cast spellCast) {
+	dest := cast.spell.Effects.Room
+	if dest == 0 {
+		dest = ctx.World.RecallRoom()
+	}
+	whisk(ctx, cast, dest)
+}
+
+// effectBlink sends the target a short hop to a random room in the area.
+func effectBlink(ctx Context, cast spellCast) {
+	if cast.targets.player == nil {
+		return
+	}
+	dest, ok := ctx.World.BlinkDestination(cast.targets.player)
+	if !ok {
+		ctx.Output.WriteLine("The magic finds nowhere to take you.")
+		return
+	}
+	whisk(ctx, cast, dest)
+}
+
+// whisk moves the spell's target, the caster or one of their group, to the
+// room at dest and shows them where they have arrived.
+func whisk(ctx Context, cast spellCast, dest int) {
+	target := cast.targets.player
+	if target == nil {
+		return
+	}
+
+	if err := ctx.World.CanTeleport(ctx.Player, target); err != nil {
+		ctx.Output.WriteLine(err.Error())
+		return
+	}
+	if err := ctx.World.CanRecall(target); err != nil {
+		ctx.Output.WriteLine(err.Error())
+		return
+	}
+	if err := ctx.World.CanEnter(target, dest); err != nil {
+		ctx.Output.WriteLine(err.Error())
+		return
+	}
+
+	actor, subject := game.PlayerSubject(ctx.Player), game.PlayerSubject(target)
+	ctx.World.Act("$n vanishes!", subject, game.Subject{}, nil, game.ToRoom)
+	if err := ctx.World.TeleportPlayer(target, dest); err != nil {
+		ctx.Output.WriteLine(err.Error())
+		return
+	}
+	ctx.World.Act(cast.spell.Messages.Hit, actor, subject, spellVars(cast.spell, 0), game.ToTarget)
+	ctx.World.Act("$n appears in a flash of light.", subject, game.Subject{}, nil, game.ToRoom)
+	arrive(ctx, target)
+}
+
+// arrive shows a player who has been moved by magic the room they are now
+// in, and lets the room react to them.
+func arrive(ctx Context, p *game.Player) {
+	if p != ctx.Player {
+		ctx = Context{World: ctx.World, Player: p, Output: p.Output}
+	}
+	if view, err := ctx.World.DescribeRoom(p); err == nil {
+		enterRoom(ctx, view)
+	}
+}
+
+// effectIdentify reveals an object's true nature.
+func effectIdentify(ctx Context, cast spellCast) {
+	obj := cast.targets.object
+	if obj == nil {
+		return
+	}
+
+	ctx.Output.WriteLine(strings.ReplaceAll(cast.spell.Messages.Hit, "$target", obj.Short))
+	kind := obj.Type
+	if kind == "" {
+		kind = "item"
+	}
+	ctx.Output.WriteLine(fmt.Sprintf("%s is %s %s, weighing %d pounds and worth %d gold.",
+		capitalize(obj.Short), article(kind), kind, obj.Weight, obj.Cost))
+	if obj.EquipSlot != "" {
+		ctx.Output.WriteLine(fmt.Sprintf("It is worn on the %s and gives %d armor.", obj.EquipSlot, obj.ArmorVal))
+	}
+	if spell := skills.GetSpell(obj.TeachesSpellID); spell != nil {
+		ctx.Output.WriteLine(fmt.Sprintf("It can teach the spell %s.", spell.Name))
+	}
+
+	var flags []string
+	for flag, on := range obj.Flags {
+		if on {
+			flags = append(flags, flag)
+		}
+	}
+	if len(flags) > 0 {
+		sort.Strings(flags)
+		ctx.Output.WriteLine("It is marked: " + strings.Join(flags, ", ") + ".")
+	}
+	if len(obj.Scripts) > 0 {
+		ctx.Output.WriteLine("You sense magic at work within it.")
+	}
+}
+
+// effectDetect senses the creatures in the rooms around the caster.
+func effectDetect(ctx Context, cast spellCast) {
+	radius := cast.spell.Targeting.Radius
+	if radius <= 0 {
+		radius = detectRadius
+	}
+
+	sensed := ctx.World.SenseCreatures(ctx.Player, radius)
+	if len(sensed) == 0 {
+		ctx.Output.WriteLine("You sense no one nearby.")
+		return
+	}
+
+	ctx.Output.WriteLine("You sense:")
+	for _, creature := range sensed {
+		where := "here"
+		if creature.Distance == 1 {
+			where = "close by to the " + creature.Direction
+		} else if creature.Distance > 1 {
+			where = "far off to the " + creature.Direction
+		}
+		ctx.Output.WriteLine(fmt.Sprintf("  %-30s %s", creature.Name, where))
+	}
+}
+
+// effectSummon calls a group member from wherever they are to the caster.
+func effectSummon(ctx Context, cast spellCast) {
+	target := cast.targets.player
+	if target == nil {
+		return
+	}
+	if err := ctx.World.CanSummon(ctx.Player, target); err != nil {
+		ctx.Output.WriteLine(err.Error())
+		return
+	}
+
+	if err := ctx.World.CanRecall(target); err != nil {
+		ctx.Output.WriteLine("The magic can't reach them there.")
+		return
+	}
+	if err := ctx.World.CanEnter(target, ctx.Player.Location); err != nil {
+		ctx.Output.WriteLine(err.Error())
+		return
+	}
+
+	subject := game.PlayerSubject(target)
+	ctx.World.Act("$n vanishes!", subject, game.Subject{}, nil, game.ToRoom)
+	if err := ctx.World.TeleportPlayer(target, ctx.Player.Location); err != nil {
+		ctx.Output.WriteLine(err.Error())
+		return
+	}
+	ctx.World.Act(cast.spell.Messages.Hit, game.PlayerSubject(ctx.Player), subject, spellVars(cast.spell, 0), game.ToTarget)
+	ctx.World.Act("$n arrives suddenly.", subject, game.Subject{}, nil, game.ToRoom)
+	arrive(ctx, target)
+}
+
+// article returns "a" or "an" for word.
+func article(word string) string {
+	if word != "" && strings.ContainsRune("aeiou", rune(word[0])) {
+		return "an"
+	}
+	return "a"
+}
@@ -0,0 +1,194 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"njata/internal/game"
+	"njata/internal/skills"
+)
+
+func TestSpellEffectsDeclared(t *testing.T) {
+	t.Cleanup(skills.Preserve())
+	if err := skills.Load("../../skills/skills.json"); err != nil {
+		t.Fatalf("load skills: %v", err)
+	}
+
+	for _, spell := range skills.AllSpells() {
+		kind := spell.Effects.Type
+		if kind == "" {
+			continue
+		}
+		if _, ok := spellEffects[kind]; !ok {
+			t.Fatalf("%s declares effect %q, which has no handler", spell.Name, kind)
+		}
+	}
+}
+
+func TestArticle(t *testing.T) {
+	if got := article("armor"); got != "an" {
+		t.Fatalf("expected an, got %q", got)
+	}
+	if got := article("weapon"); got != "a" {
+		t.Fatalf("expected a, got %q", got)
+	}
+}
+
+// newEffectWorld builds a small keep for spell effects: a temple, a hall
+// south of it, a solitary sanctum and, below the keep, a cell that binds
+// magic. Alice and Bob stand in the hall, each knowing every spell.
+func newEffectWorld(t *testing.T) (*game.World, *game.Player, *game.Player) {
+	t.Helper()
+	t.Cleanup(skills.Preserve())
+	if err := skills.Load("../../skills/skills.json"); err != nil {
+		t.Fatalf("load skills: %v", err)
+	}
+
+	room := func(vnum int, name, area string, flags map[string]bool, exits map[string]int) *game.Room {
+		return &game.Room{Vnum: vnum, Name: name, AreaName: area, Sector: "inside", Flags: flags, Exits: exits}
+	}
+	rooms := map[int]*game.Room{
+		1: room(1, "Temple", "keep", map[string]bool{}, map[string]int{"south": 2}),
+		2: room(2, "Hall", "keep", map[string]bool{}, map[string]int{"north": 1, "down": 3}),
+		3: room(3, "Cell", "dungeon", map[string]bool{game.RoomFlagNoRecall: true}, map[string]int{"up": 2}),
+		4: room(4, "Sanctum", "keep", map[string]bool{game.RoomFlagSolitary: true}, map[string]int{}),
+	}
+	world := game.CreateWorldFromRooms(rooms, 1)
+
+	alice := newCaster(t, world, "alice", 2)
+	bob := newCaster(t, world, "bob", 2)
+	return world, alice, bob
+}
+
+func newCaster(t *testing.T, world *game.World, name string, room int) *game.Player {
+	t.Helper()
+	p := &game.Player{
+		Name: name, Output: &lineOutput{}, Location: room,
+		HP: 100, MaxHP: 100, Mana: 500, MaxMana: 500,
+		Skills: map[int]*skills.PlayerSkillProgress{},
+	}
+	for _, spell := range skills.AllSpells() {
+		p.Skills[spell.ID] = &skills.PlayerSkillProgress{SpellID: spell.ID, Proficiency: 50, Learned: true}
+	}
+	if err := world.AddPlayer(p); err != nil {
+		t.Fatalf("add player: %v", err)
+	}
+	return p
+}
+
+func groupWith(t *testing.T, world *game.World, leader, member *game.Player) {
+	t.Helper()
+	if err := world.Follow(member, leader); err != nil {
+		t.Fatalf("follow: %v", err)
+	}
+	if err := world.GroupToggle(leader, member); err != nil {
+		t.Fatalf("group: %v", err)
+	}
+}
+
+// cast has p cast args and returns everything p was shown.
+func cast(world *game.World, p *game.Player, args string) string {
+	out := p.Output.(*lineOutput)
+	out.lines = nil
+	cmdCast(Context{World: world, Player: p, Output: out}, args)
+	return strings.Join(out.lines, "\n")
+}
+
+func TestHealSpellRestoresHP(t *testing.T) {
+	world, alice, bob := newEffectWorld(t)
+	bob.HP = 10
+
+	cast(world, alice, "mend bob")
+
+	if bob.HP <= 10 || bob.HP > bob.MaxHP {
+		t.Fatalf("expected mend to restore some of bob's HP, got %d", bob.HP)
+	}
+}
+
+func TestTeleportSpellsMoveTheirTarget(t *testing.T) {
+	world, alice, bob := newEffectWorld(t)
+	groupWith(t, world, alice, bob)
+
+	cast(world, bob, "ephemeral step")
+	if bob.Location != 1 {
+		t.Fatalf("expected blink to hop bob to the only open room in the keep, got room %d", bob.Location)
+	}
+
+	out := cast(world, alice, "kinship call bob")
+	if bob.Location != 2 {
+		t.Fatalf("expected bob summoned back to the hall, got room %d: %s", bob.Location, out)
+	}
+
+	cast(world, alice, "path shift")
+	if alice.Location != 1 {
+		t.Fatalf("expected path shift to take alice to the temple, got room %d", alice.Location)
+	}
+}
+
+func TestTeleportSpellsRefuseStrangers(t *testing.T) {
+	world, alice, bob := newEffectWorld(t)
+
+	if out := cast(world, alice, "ephemeral step bob"); bob.Location != 2 || !strings.Contains(out, game.ErrNoTeleport.Error()) {
+		t.Fatalf("expected blink on a stranger to be refused, bob in %d: %s", bob.Location, out)
+	}
+
+	carol := newCaster(t, world, "carol", 1)
+	if out := cast(world, alice, "kinship call carol"); carol.Location != 1 || !strings.Contains(out, game.ErrNoSummon.Error()) {
+		t.Fatalf("expected summoning a stranger to be refused, carol in %d: %s", carol.Location, out)
+	}
+}
+
+func TestTeleportSpellsRefuseNoRecallRooms(t *testing.T) {
+	world, alice, bob := newEffectWorld(t)
+	groupWith(t, world, alice, bob)
+	alice.Location = 3
+
+	if out := cast(world, alice, "path shift"); alice.Location != 3 || !strings.Contains(out, game.ErrNoRecall.Error()) {
+		t.Fatalf("expected the cell to hold alice, got room %d: %s", alice.Location, out)
+	}
+
+	alice.Location, bob.Location = 2, 3
+	if out := cast(world, alice, "kinship call bob"); bob.Location != 3 || !strings.Contains(out, "can't reach them") {
+		t.Fatalf("expected the cell to hold bob, got room %d: %s", bob.Location, out)
+	}
+}
+
+func TestTeleportSpellsRefuseFullRooms(t *testing.T) {
+	world, alice, bob := newEffectWorld(t)
+	groupWith(t, world, alice, bob)
+	world.SetDeathPolicy(game.DeathPolicy{RecallVnum: 4})
+	newCaster(t, world, "carol", 4)
+	bob.Output.(*lineOutput).lines = nil
+
+	out := cast(world, alice, "path shift")
+	if alice.Location != 2 || !strings.Contains(out, game.ErrRoomFull.Error()) {
+		t.Fatalf("expected the full sanctum to turn alice away, got room %d: %s", alice.Location, out)
+	}
+	if seen := strings.Join(bob.Output.(*lineOutput).lines, "\n"); strings.Contains(seen, "Alice vanishes!") {
+		t.Fatalf("expected no one to see alice vanish, bob saw: %s", seen)
+	}
+
+	alice.Location, bob.Location = 4, 1
+	if out := cast(world, alice, "kinship call bob"); bob.Location != 1 || !strings.Contains(out, game.ErrRoomFull.Error()) {
+		t.Fatalf("expected bob kept out of the full sanctum, got room %d: %s", bob.Location, out)
+	}
+}
+
+func TestIdentifyAndDetectReport(t *testing.T) {
+	world, alice, bob := newEffectWorld(t)
+	alice.Inventory = append(alice.Inventory, &game.Object{
+		Keywords: []string{"helm"}, Short: "a steel helm", Type: "armor",
+		Weight: 5, Cost: 20, EquipSlot: "head", ArmorVal: 3,
+	})
+
+	out := cast(world, alice, "knowing helm")
+	if !strings.Contains(out, "A steel helm is an armor, weighing 5 pounds and worth 20 gold.") ||
+		!strings.Contains(out, "It is worn on the head and gives 3 armor.") {
+		t.Fatalf("expected knowing to describe the helm, got: %s", out)
+	}
+
+	bob.Location = 1
+	if out := cast(world, alice, "sense life"); !strings.Contains(out, "Bob") {
+		t.Fatalf("expected sense life to find bob in the temple, got: %s", out)
+	}
+}
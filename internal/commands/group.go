@@ -0,0 +1,130 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"njata/internal/game"
+)
+
+func cmdFollow(ctx Context, args string) {
+	if ctx.Player == nil {
+		ctx.Output.WriteLine("You must be logged in.")
+		return
+	}
+
+	name, _ := game.FirstArg(args)
+	if name == "" {
+		ctx.Output.WriteLine("Follow whom?")
+		return
+	}
+
+	target := ctx.Player
+	if !strings.EqualFold(name, "self") && !strings.EqualFold(name, "me") {
+		var ok bool
+		target, ok = ctx.World.FindPlayerInRoom(ctx.Player, name)
+		if !ok {
+			ctx.Output.WriteLine("They aren't here.")
+			return
+		}
+	}
+
+	if err := ctx.World.Follow(ctx.Player, target); err != nil {
+		ctx.Output.WriteLine(err.Error())
+	}
+}
+
+// cmdGroup shows the player's group, adds or removes a follower, or sets
+// the group's loot rule ("group loot split").
+func cmdGroup(ctx Context, args string) {
+	if ctx.Player == nil {
+		ctx.Output.WriteLine("You must be logged in.")
+		return
+	}
+
+	name, rest := game.FirstArg(args)
+	if name == "" {
+		showGroup(ctx)
+		return
+	}
+
+	if strings.EqualFold(name, "loot") {
+		rule, _ := game.FirstArg(rest)
+		if rule == "" {
+			ctx.Output.WriteLine(fmt.Sprintf("Your group's loot rule is %s. (syntax: group loot <%s>)",
+				ctx.World.LootRule(ctx.Player), strings.Join(game.LootRules, "|")))
+			return
+		}
+		if err := ctx.World.SetLootRule(ctx.Player, rule); err != nil {
+			ctx.Output.WriteLine(err.Error())
+		}
+		return
+	}
+
+	target, ok := ctx.World.FindPlayerInRoom(ctx.Player, name)
+	if !ok {
+		ctx.Output.WriteLine("They aren't here.")
+		return
+	}
+	if err := ctx.World.GroupToggle(ctx.Player, target); err != nil {
+		ctx.Output.WriteLine(err.Error())
+	}
+}
+
+func showGroup(ctx Context) {
+	members := ctx.World.GroupMembers(ctx.Player)
+	if len(members) < 2 {
+		ctx.Output.WriteLine("You aren't in a group.")
+		return
+	}
+
+	leader := members[0]
+	ctx.Output.WriteLine(fmt.Sprintf("&Y%s's group&w (loot: %s)", game.CapitalizeName(leader.Name), ctx.World.LootRule(leader)))
+	for _, member := range members {
+		role := ""
+		if member == leader {
+			role = " (leader)"
+		}
+		ctx.Output.WriteLine(fmt.Sprintf("  [%4d/%-4d hp %4d/%-4d mana %4d/%-4d mv] %s%s",
+			member.HP, member.MaxHP, member.Mana, member.MaxMana, member.Move, member.MaxMove,
+			game.CapitalizeName(member.Name), role))
+	}
+}
+
+func cmdUngroup(ctx Context, args string) {
+	if ctx.Player == nil {
+		ctx.Output.WriteLine("You must be logged in.")
+		return
+	}
+
+	var target *game.Player
+	if name, _ := game.FirstArg(args); name != "" {
+		var ok bool
+		target, ok = ctx.World.FindPlayer(name)
+		if !ok {
+			ctx.Output.WriteLine("They aren't here.")
+			return
+		}
+	}
+
+	if err := ctx.World.Ungroup(ctx.Player, target); err != nil {
+		ctx.Output.WriteLine(err.Error())
+	}
+}
+
+func cmdGtell(ctx Context, args string) {
+	if ctx.Player == nil {
+		ctx.Output.WriteLine("You must be logged in.")
+		return
+	}
+
+	message := strings.TrimSpace(args)
+	if message == "" {
+		ctx.Output.WriteLine("Tell your group what?")
+		return
+	}
+
+	if err := ctx.World.GroupTell(ctx.Player, message); err != nil {
+		ctx.Output.WriteLine(err.Error())
+	}
+}
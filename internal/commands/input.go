@@ -0,0 +1,157 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"njata/internal/game"
+	"njata/internal/parser"
+)
+
+const (
+	maxAliasDepth       = 5  // nested alias expansions before giving up
+	maxExpandedCommands = 30 // commands a single input line may expand into
+	maxAliases          = 50 // aliases a player may define
+)
+
+// Expand turns one line of player input into the commands to execute. It
+// handles "!" history, separators, aliases and speedwalk strings.
+func (r *Registry) Expand(p *game.Player, line string, separator string) ([]string, error) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return nil, nil
+	}
+
+	if p != nil {
+		if line == "!" {
+			if p.LastCommand == "" {
+				return nil, fmt.Errorf("No previous command to repeat.")
+			}
+			line = p.LastCommand
+		} else {
+			p.LastCommand = line
+		}
+	}
+
+	var out []string
+	if err := r.expandInto(p, line, separator, 0, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *Registry) expandInto(p *game.Player, line string, separator string, depth int, out *[]string) error {
+	for _, part := range parser.SplitCommands(line, separator) {
+		command, args := parser.ParseInput(part)
+		if command == "" {
+			continue
+		}
+
+		if p != nil && p.Aliases != nil {
+			if template, ok := p.Aliases[command]; ok {
+				if depth >= maxAliasDepth {
+					return fmt.Errorf("Alias '%s' nests too deeply.", command)
+				}
+				if err := r.expandInto(p, parser.ExpandAlias(template, args), separator, depth+1, out); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		if args == "" && !r.Has(command) {
+			if steps, ok := parser.ExpandSpeedwalk(command, maxExpandedCommands); ok {
+				*out = append(*out, steps...)
+				if len(*out) > maxExpandedCommands {
+					return fmt.Errorf("That expands into too many commands.")
+				}
+				continue
+			}
+		}
+
+		*out = append(*out, part)
+		if len(*out) > maxExpandedCommands {
+			return fmt.Errorf("That expands into too many commands.")
+		}
+	}
+
+	return nil
+}
+
+func cmdAlias(ctx Context, args string) {
+	if ctx.Player == nil {
+		ctx.Output.WriteLine("You must be logged in.")
+		return
+	}
+
+	p := ctx.Player
+	name, template := parser.ParseInput(args)
+
+	if name == "" {
+		if len(p.Aliases) == 0 {
+			ctx.Output.WriteLine("You have no aliases defined.")
+			ctx.Output.WriteLine("Usage: alias <name> <commands>   (use $* for arguments, $1-$9 for single words)")
+			return
+		}
+
+		names := make([]string, 0, len(p.Aliases))
+		for alias := range p.Aliases {
+			names = append(names, alias)
+		}
+		sort.Strings(names)
+
+		ctx.Output.WriteLine("Your aliases:")
+		for _, alias := range names {
+			ctx.Output.WriteLine(fmt.Sprintf("  %-12s %s", alias, p.Aliases[alias]))
+		}
+		return
+	}
+
+	if template == "" {
+		if existing, ok := p.Aliases[name]; ok {
+			ctx.Output.WriteLine(fmt.Sprintf("%s: %s", name, existing))
+		} else {
+			ctx.Output.WriteLine(fmt.Sprintf("You have no alias called '%s'.", name))
+		}
+		return
+	}
+
+	if name == "alias" || name == "unalias" || strings.HasPrefix(name, "!") {
+		ctx.Output.WriteLine("You can't alias that.")
+		return
+	}
+
+	if p.Aliases == nil {
+		p.Aliases = make(map[string]string)
+	}
+	if _, exists := p.Aliases[name]; !exists && len(p.Aliases) >= maxAliases {
+		ctx.Output.WriteLine(fmt.Sprintf("You can't have more than %d aliases.", maxAliases))
+		return
+	}
+
+	template = strings.ReplaceAll(template, "~", "")
+	p.Aliases[name] = template
+	ctx.Output.WriteLine(fmt.Sprintf("Alias set: %s = %s", name, template))
+}
+
+func cmdUnalias(ctx Context, args string) {
+	if ctx.Player == nil {
+		ctx.Output.WriteLine("You must be logged in.")
+		return
+	}
+
+	name := strings.ToLower(strings.TrimSpace(args))
+	if name == "" {
+		ctx.Output.WriteLine("Usage: unalias <name>")
+		return
+	}
+
+	if _, ok := ctx.Player.Aliases[name]; !ok {
+		ctx.Output.WriteLine(fmt.Sprintf("You have no alias called '%s'.", name))
+		return
+	}
+
+	delete(ctx.Player.Aliases, name)
+	ctx.Output.WriteLine(fmt.Sprintf("Alias '%s' removed.", name))
+}
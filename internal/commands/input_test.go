@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"njata/internal/game"
+)
+
+func newExpandRegistry() *Registry {
+	reg := NewRegistry()
+	noop := func(ctx Context, args string) {}
+	for _, name := range []string{"look", "get", "wear", "north", "east", "n", "e", "kill"} {
+		reg.Register(name, noop)
+	}
+	return reg
+}
+
+func TestExpandSeparatorAndSpeedwalk(t *testing.T) {
+	reg := newExpandRegistry()
+	p := &game.Player{Name: "Alice"}
+
+	got, err := reg.Expand(p, "get all;2n1e;look", ";")
+	if err != nil {
+		t.Fatalf("expand: %v", err)
+	}
+	want := "get all|north|north|east|look"
+	if strings.Join(got, "|") != want {
+		t.Fatalf("expected %q, got %q", want, strings.Join(got, "|"))
+	}
+}
+
+func TestExpandUnknownWordIsNotSpeedwalk(t *testing.T) {
+	reg := newExpandRegistry()
+	p := &game.Player{Name: "Alice"}
+
+	got, err := reg.Expand(p, "end", ";")
+	if err != nil || len(got) != 1 || got[0] != "end" {
+		t.Fatalf("expected 'end' to be left alone, got %v %v", got, err)
+	}
+}
+
+func TestExpandAliasAndHistory(t *testing.T) {
+	reg := newExpandRegistry()
+	p := &game.Player{Name: "Alice", Aliases: map[string]string{"k": "kill $*"}}
+
+	got, err := reg.Expand(p, "k goblin", ";")
+	if err != nil || len(got) != 1 || got[0] != "kill goblin" {
+		t.Fatalf("unexpected alias expansion: %v %v", got, err)
+	}
+
+	got, err = reg.Expand(p, "!", ";")
+	if err != nil || len(got) != 1 || got[0] != "kill goblin" {
+		t.Fatalf("unexpected history expansion: %v %v", got, err)
+	}
+}
+
+func TestExpandRecursiveAliasIsLimited(t *testing.T) {
+	reg := newExpandRegistry()
+	p := &game.Player{Name: "Alice", Aliases: map[string]string{"a": "b", "b": "a"}}
+
+	if _, err := reg.Expand(p, "a", ";"); err == nil {
+		t.Fatalf("expected recursive alias to be rejected")
+	}
+}
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/rand"
 	"os"
@@ -9,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"njata/internal/bans"
 	"njata/internal/game"
 	"njata/internal/persist"
 	"njata/internal/races"
@@ -29,6 +31,7 @@ func RegisterBuiltins(registry *Registry) {
 	registry.Register("remove", cmdRemove)
 	registry.Register("get", cmdGet)
 	registry.Register("drop", cmdDrop)
+	registry.Register("give", cmdGive)
 	registry.Register("hair", cmdHair)
 	registry.Register("eyes", cmdEyes)
 	registry.Register("exits", cmdExits)
@@ -51,9 +54,38 @@ func RegisterBuiltins(registry *Registry) {
 	registry.Register("spawn", cmdSpawn)
 	registry.Register("teleport", cmdTeleport)
 	registry.Register("restore", cmdRestore)
+	registry.Register("ban", cmdBan)
+	registry.Register("allow", cmdAllow)
 	registry.Register("help", cmdHelp)
+	registry.Register("alias", cmdAlias)
+	registry.Register("unalias", cmdUnalias)
 	registry.Register("quit", cmdQuit)
+	registry.Register("socials", cmdSocials)
+	registry.Register("time", cmdTime)
+	registry.Register("weather", cmdWeather)
+	registry.Register("stand", positionCommand(game.PositionStanding))
+	registry.Register("sit", positionCommand(game.PositionSitting))
+	registry.Register("rest", positionCommand(game.PositionResting))
+	registry.Register("sleep", positionCommand(game.PositionSleeping))
+	registry.Register("wake", cmdWake)
+	registry.Register("list", cmdList)
+	registry.Register("buy", cmdBuy)
+	registry.Register("sell", cmdSell)
+	registry.Register("value", cmdValue)
+	registry.Register("deposit", cmdDeposit)
+	registry.Register("withdraw", cmdWithdraw)
+	registry.Register("balance", cmdBalance)
+	registry.Register("economy", cmdEconomy)
+	registry.Register("quest", cmdQuest)
+	registry.Register("follow", cmdFollow)
+	registry.Register("group", cmdGroup)
+	registry.Register("ungroup", cmdUngroup)
+	registry.Register("gtell", cmdGtell)
+	registry.Register("pvp", cmdPvP)
+	registry.Register("pkills", cmdPKills)
+	registerChannels(registry)
 	registerMovement(registry)
+	registry.SetFallback(executeSocial)
 }
 
 func cmdConsider(ctx Context, args string) {
@@ -74,7 +106,7 @@ func cmdConsider(ctx Context, args string) {
 	}
 
 	if targetPlayer, ok := ctx.World.FindPlayerInRoom(ctx.Player, args); ok {
-		assessment := compareCombatScores(combatScorePlayer(ctx.Player), combatScorePlayer(targetPlayer))
+		assessment := compareCombatScores(game.CombatScore(ctx.Player), game.CombatScore(targetPlayer))
 		ctx.Output.WriteLine(fmt.Sprintf("You size up %s. %s", game.CapitalizeName(targetPlayer.Name), assessment))
 		return
 	}
@@ -85,27 +117,10 @@ func cmdConsider(ctx Context, args string) {
 		return
 	}
 
-	assessment := compareCombatScores(combatScorePlayer(ctx.Player), combatScoreMob(mob))
+	assessment := compareCombatScores(game.CombatScore(ctx.Player), combatScoreMob(mob))
 	ctx.Output.WriteLine(fmt.Sprintf("You size up %s. %s", mob.Short, assessment))
 }
 
-func combatScorePlayer(p *game.Player) int {
-	if p == nil {
-		return 1
-	}
-
-	score := 0
-	score += p.MaxHP
-	score += p.Strength * 5
-	score += p.Dexterity * 3
-	score += p.Constitution * 4
-	score += p.Armor * 2
-	if score < 1 {
-		score = 1
-	}
-	return score
-}
-
 func combatScoreMob(mob *game.Mobile) int {
 	if mob == nil {
 		return 1
@@ -153,6 +168,17 @@ func compareCombatScores(playerScore int, targetScore int) string {
 
 // DisplayRoomView is a shared function to display a room view consistently
 func DisplayRoomView(output game.Output, view game.RoomView, autoExits bool) {
+	if view.Dark {
+		output.WriteLine(view.Description)
+		for _, mob := range view.Mobiles {
+			output.WriteLine(mob)
+		}
+		if len(view.Others) > 0 {
+			output.WriteLine("Also here: " + strings.Join(view.Others, ", "))
+		}
+		return
+	}
+
 	output.WriteLine(view.Name)
 	if view.Description != "" {
 		output.WriteLine(view.Description)
@@ -185,6 +211,11 @@ func DisplayRoomView(output game.Output, view game.RoomView, autoExits bool) {
 }
 
 func cmdLook(ctx Context, args string) {
+	if ctx.Player != nil && game.IsAsleep(ctx.Player) {
+		ctx.Output.WriteLine("You can't see anything, you're sleeping!")
+		return
+	}
+
 	trimmed := strings.TrimSpace(args)
 	if trimmed != "" {
 		keyword := trimmed
@@ -209,10 +240,17 @@ func cmdLook(ctx Context, args string) {
 
 		if obj, ok := ctx.World.FindObjectInRoom(ctx.Player, keyword); ok {
 			ctx.Output.WriteLine(obj.Long)
+			writeContents(ctx, obj)
 			return
 		}
 
 		if obj, ok := ctx.World.FindObjectInInventory(ctx.Player, keyword); ok {
+			ctx.Output.WriteLine(obj.Long)
+			writeContents(ctx, obj)
+			return
+		}
+
+		if obj, ok := ctx.World.FindObjectInEquipment(ctx.Player, keyword); ok {
 			ctx.Output.WriteLine(obj.Long)
 			return
 		}
@@ -235,6 +273,27 @@ func cmdLook(ctx Context, args string) {
 	DisplayRoomView(ctx.Output, view, ctx.Player.AutoExits)
 }
 
+// writeContents lists what a corpse or container holds.
+func writeContents(ctx Context, obj *game.Object) {
+	contents := ctx.World.ContainerContents(obj)
+	if !game.IsCorpse(obj) && len(contents) == 0 {
+		return
+	}
+	if len(contents) == 0 {
+		ctx.Output.WriteLine("It is empty.")
+		return
+	}
+
+	ctx.Output.WriteLine(fmt.Sprintf("%s contains:", capitalize(obj.Short)))
+	for _, item := range contents {
+		label := item.Short
+		if label == "" {
+			label = "something"
+		}
+		ctx.Output.WriteLine("  " + label)
+	}
+}
+
 func writePlayerLook(output game.Output, target *game.Player) {
 	name := game.CapitalizeName(target.Name)
 	output.WriteLine(fmt.Sprintf("You see %s.", name))
@@ -259,7 +318,9 @@ func cmdSay(ctx Context, args string) {
 		return
 	}
 
-	ctx.World.BroadcastSay(ctx.Player, args)
+	if err := ctx.World.BroadcastSay(ctx.Player, args); err != nil {
+		ctx.Output.WriteLine(err.Error())
+	}
 }
 
 func cmdChat(ctx Context, args string) {
@@ -268,7 +329,9 @@ func cmdChat(ctx Context, args string) {
 		return
 	}
 
-	ctx.World.BroadcastChat(ctx.Player, args)
+	if err := ctx.World.BroadcastChat(ctx.Player, args); err != nil {
+		ctx.Output.WriteLine(err.Error())
+	}
 }
 
 func cmdWho(ctx Context, args string) {
@@ -291,10 +354,15 @@ func cmdStats(ctx Context, args string) {
 		sexName = sexNames[p.Sex]
 	}
 
-	ctx.Output.WriteLine(fmt.Sprintf("Race: %s | Sex: %s", raceName, sexName))
+	position := p.Position
+	if position == "" {
+		position = game.PositionStanding
+	}
+
+	ctx.Output.WriteLine(fmt.Sprintf("Race: %s | Sex: %s | Position: %s", raceName, sexName, position))
 	ctx.Output.WriteLine("")
-	ctx.Output.WriteLine(fmt.Sprintf("HP:    %d/%d | Mana: %d/%d", p.HP, p.MaxHP, p.Mana, p.MaxMana))
-	ctx.Output.WriteLine(fmt.Sprintf("Gold: %d", p.Gold))
+	ctx.Output.WriteLine(fmt.Sprintf("HP:    %d/%d | Mana: %d/%d | Move: %d/%d", p.HP, p.MaxHP, p.Mana, p.MaxMana, p.Move, p.MaxMove))
+	ctx.Output.WriteLine(fmt.Sprintf("Gold: %d | Bank: %d", p.Gold, p.Bank))
 	ctx.Output.WriteLine("")
 
 	ctx.Output.WriteLine(fmt.Sprintf("STR: %2d | DEX: %2d | CON: %2d", p.Strength, p.Dexterity, p.Constitution))
@@ -366,14 +434,16 @@ func cmdWear(ctx Context, args string) {
 		return
 	}
 
-	trimmed := strings.TrimSpace(args)
-	if strings.EqualFold(trimmed, "all") {
-		items := append([]*game.Object(nil), ctx.Player.Inventory...)
+	keyword, rest := game.FirstArg(args)
+	if keyword == "" {
+		ctx.Output.WriteLine("Wear what?")
+		return
+	}
+
+	if game.ParseTarget(keyword).All {
+		items := ctx.World.ResolveInventory(ctx.Player, keyword)
 		worn := 0
 		for _, obj := range items {
-			if obj == nil {
-				continue
-			}
 			slot, ok := resolveEquipSlot(obj, "")
 			if !ok {
 				continue
@@ -383,19 +453,7 @@ func cmdWear(ctx Context, args string) {
 				continue
 			}
 			if ctx.World.EquipObject(ctx.Player, obj, slot) {
-				// Apply armor bonus
-				if obj.ArmorVal != 0 {
-					ctx.Player.Armor += obj.ArmorVal
-				}
-				label := obj.Short
-				if label == "" {
-					label = "something"
-				}
-				if slot == game.EquipWield {
-					ctx.Output.WriteLine(fmt.Sprintf("You equip %s.", label))
-				} else {
-					ctx.Output.WriteLine(fmt.Sprintf("You wear %s on your %s.", label, slot))
-				}
+				applyWear(ctx, obj, slot)
 				worn++
 			}
 		}
@@ -406,16 +464,9 @@ func cmdWear(ctx Context, args string) {
 		return
 	}
 
-	fields := strings.Fields(trimmed)
-	if len(fields) == 0 {
-		ctx.Output.WriteLine("Wear what?")
-		return
-	}
-
-	keyword := fields[0]
 	var slotOverride string
-	if len(fields) > 1 {
-		slotOverride = fields[1]
+	if fields := strings.Fields(rest); len(fields) > 0 {
+		slotOverride = fields[0]
 	}
 
 	obj, found := ctx.World.FindObjectInInventory(ctx.Player, keyword)
@@ -441,6 +492,11 @@ func cmdWear(ctx Context, args string) {
 		return
 	}
 
+	applyWear(ctx, obj, slot)
+}
+
+// applyWear applies an equipped item's bonuses and reports it.
+func applyWear(ctx Context, obj *game.Object, slot string) {
 	// Apply armor bonus
 	if obj.ArmorVal != 0 {
 		ctx.Player.Armor += obj.ArmorVal
@@ -463,23 +519,23 @@ func cmdRemove(ctx Context, args string) {
 		return
 	}
 
-	trimmed := strings.TrimSpace(args)
-	if strings.EqualFold(trimmed, "all") {
+	keyword, _ := game.FirstArg(args)
+	if keyword == "" {
+		ctx.Output.WriteLine("Remove what?")
+		return
+	}
+
+	if game.ParseTarget(keyword).All {
 		removed := 0
-		for _, slot := range game.EquipSlotOrder {
-			obj, ok := ctx.World.UnequipObject(ctx.Player, slot)
+		for _, obj := range ctx.World.ResolveEquipment(ctx.Player, keyword) {
+			slot, ok := ctx.World.FindEquippedSlot(ctx.Player, obj)
 			if !ok {
 				continue
 			}
-			// Remove armor bonus
-			if obj.ArmorVal != 0 {
-				ctx.Player.Armor -= obj.ArmorVal
-			}
-			label := obj.Short
-			if label == "" {
-				label = "something"
+			if _, ok := ctx.World.UnequipObject(ctx.Player, slot); !ok {
+				continue
 			}
-			ctx.Output.WriteLine(fmt.Sprintf("You remove %s from your %s.", label, slot))
+			applyRemove(ctx, obj, slot)
 			removed++
 		}
 		if removed == 0 {
@@ -489,28 +545,13 @@ func cmdRemove(ctx Context, args string) {
 		return
 	}
 
-	fields := strings.Fields(trimmed)
-	if len(fields) == 0 {
-		ctx.Output.WriteLine("Remove what?")
-		return
-	}
-
-	keyword := fields[0]
 	if slot, ok := normalizeEquipSlot(keyword); ok {
 		obj, ok := ctx.World.UnequipObject(ctx.Player, slot)
 		if !ok {
 			ctx.Output.WriteLine(fmt.Sprintf("You are not wearing anything on your %s.", slot))
 			return
 		}
-		// Remove armor bonus
-		if obj.ArmorVal != 0 {
-			ctx.Player.Armor -= obj.ArmorVal
-		}
-		label := obj.Short
-		if label == "" {
-			label = "something"
-		}
-		ctx.Output.WriteLine(fmt.Sprintf("You remove %s from your %s.", label, slot))
+		applyRemove(ctx, obj, slot)
 		return
 	}
 
@@ -531,6 +572,11 @@ func cmdRemove(ctx Context, args string) {
 		return
 	}
 
+	applyRemove(ctx, obj, slot)
+}
+
+// applyRemove removes an unequipped item's bonuses and reports it.
+func applyRemove(ctx Context, obj *game.Object, slot string) {
 	// Remove armor bonus
 	if obj.ArmorVal != 0 {
 		ctx.Player.Armor -= obj.ArmorVal
@@ -646,57 +692,27 @@ func objectHasAnyKeyword(obj *game.Object, keywords []string) bool {
 	return false
 }
 
-func objectMatchesKeyword(obj *game.Object, keyword string) bool {
-	if obj == nil {
-		return false
-	}
-
-	key := strings.ToLower(strings.TrimSpace(keyword))
-	if key == "" {
-		return false
-	}
-
-	for _, objKeyword := range obj.Keywords {
-		if strings.ToLower(objKeyword) == key {
-			return true
-		}
-	}
-
-	return strings.Contains(strings.ToLower(obj.Short), key)
-}
-
 func cmdGet(ctx Context, args string) {
 	if ctx.Player == nil {
 		ctx.Output.WriteLine("You must be logged in to get items.")
 		return
 	}
 
-	keyword := strings.TrimSpace(args)
+	keyword, container := splitContainer(ctx, args)
 	if keyword == "" {
 		ctx.Output.WriteLine("Get what?")
 		return
 	}
 
-	lower := strings.ToLower(keyword)
-	if lower == "all" || strings.HasPrefix(lower, "all ") || strings.HasPrefix(lower, "all.") {
-		filter := ""
-		if lower != "all" {
-			filter = strings.TrimSpace(keyword[4:])
-		}
+	if container != "" {
+		getFromContainer(ctx, keyword, container)
+		return
+	}
 
-		objects := ctx.World.RoomObjectsSnapshot(ctx.Player)
+	if game.ParseTarget(keyword).All {
 		picked := 0
-		for _, obj := range objects {
-			if obj == nil {
-				continue
-			}
-			if filter != "" && !objectMatchesKeyword(obj, filter) {
-				continue
-			}
-			if obj.Flags != nil && (obj.Flags["notake"] || obj.Flags["no_take"]) {
-				continue
-			}
-			if obj.Type == "fountain" || obj.Type == "furniture" {
+		for _, obj := range ctx.World.ResolveRoomObjects(ctx.Player, keyword) {
+			if !game.CanTake(obj) {
 				continue
 			}
 			if !ctx.World.RemoveObjectFromRoom(ctx.Player, obj) {
@@ -723,12 +739,7 @@ func cmdGet(ctx Context, args string) {
 		return
 	}
 
-	if obj.Flags != nil && (obj.Flags["notake"] || obj.Flags["no_take"]) {
-		ctx.Output.WriteLine("You can't take that.")
-		return
-	}
-
-	if obj.Type == "fountain" || obj.Type == "furniture" {
+	if !game.CanTake(obj) {
 		ctx.Output.WriteLine("You can't take that.")
 		return
 	}
@@ -746,6 +757,54 @@ func cmdGet(ctx Context, args string) {
 	ctx.Output.WriteLine(fmt.Sprintf("You pick up %s.", label))
 }
 
+// splitContainer splits the arguments to get into the item and the
+// container it comes out of. An explicit "from" or "in" always separates
+// them. Otherwise the whole phrase names the item if anything in the room
+// matches it, and failing that the first word names the item and the rest
+// the container, as in "get all corpse".
+func splitContainer(ctx Context, args string) (string, string) {
+	words := game.SplitArgs(args)
+	for i := 1; i < len(words)-1; i++ {
+		if strings.EqualFold(words[i], "from") || strings.EqualFold(words[i], "in") {
+			return strings.Join(words[:i], " "), strings.Join(words[i+1:], " ")
+		}
+	}
+
+	phrase := strings.Join(words, " ")
+	if len(words) < 2 || len(ctx.World.ResolveRoomObjects(ctx.Player, phrase)) > 0 {
+		return phrase, ""
+	}
+	return words[0], strings.Join(words[1:], " ")
+}
+
+// getFromContainer takes items out of a corpse or container in the room or
+// the player's inventory.
+func getFromContainer(ctx Context, keyword string, containerArg string) {
+	container, found := ctx.World.FindObjectInRoom(ctx.Player, containerArg)
+	if !found {
+		container, found = ctx.World.FindObjectInInventory(ctx.Player, containerArg)
+	}
+	if !found {
+		ctx.Output.WriteLine("You don't see that here.")
+		return
+	}
+
+	taken, err := ctx.World.TakeFromContainer(ctx.Player, container, keyword)
+	if err != nil {
+		ctx.Output.WriteLine(err.Error())
+		return
+	}
+
+	for _, obj := range taken {
+		label := obj.Short
+		if label == "" {
+			label = "something"
+		}
+		ctx.Output.WriteLine(fmt.Sprintf("You get %s from %s.", label, container.Short))
+	}
+	ctx.World.Act(fmt.Sprintf("$n gets something from %s.", container.Short), game.PlayerSubject(ctx.Player), game.Subject{}, nil, game.ToRoom)
+}
+
 func cmdDrop(ctx Context, args string) {
 	if ctx.Player == nil {
 		ctx.Output.WriteLine("You must be logged in to drop items.")
@@ -758,22 +817,14 @@ func cmdDrop(ctx Context, args string) {
 		return
 	}
 
-	lower := strings.ToLower(keyword)
-	if lower == "all" || strings.HasPrefix(lower, "all ") || strings.HasPrefix(lower, "all.") {
-		filter := ""
-		if lower != "all" {
-			filter = strings.TrimSpace(keyword[4:])
-		}
+	if err := ctx.World.CanDrop(ctx.Player); err != nil {
+		ctx.Output.WriteLine(err.Error())
+		return
+	}
 
-		items := append([]*game.Object(nil), ctx.Player.Inventory...)
+	if game.ParseTarget(keyword).All {
 		dropped := 0
-		for _, obj := range items {
-			if obj == nil {
-				continue
-			}
-			if filter != "" && !objectMatchesKeyword(obj, filter) {
-				continue
-			}
+		for _, obj := range ctx.World.ResolveInventory(ctx.Player, keyword) {
 			if !ctx.World.RemoveObjectFromInventory(ctx.Player, obj) {
 				continue
 			}
@@ -811,6 +862,66 @@ func cmdDrop(ctx Context, args string) {
 	ctx.Output.WriteLine(fmt.Sprintf("You drop %s.", label))
 }
 
+func cmdGive(ctx Context, args string) {
+	if ctx.Player == nil {
+		ctx.Output.WriteLine("You must be logged in to give items.")
+		return
+	}
+
+	keyword, rest := game.FirstArg(args)
+	recipient, _ := game.FirstArg(rest)
+	if keyword == "" || recipient == "" {
+		ctx.Output.WriteLine("Give what to whom?")
+		return
+	}
+
+	// "give 10 gold bob" hands over coins rather than an item
+	if amount, err := strconv.Atoi(keyword); err == nil {
+		giveGold(ctx, amount, rest)
+		return
+	}
+
+	obj, found := ctx.World.FindObjectInInventory(ctx.Player, keyword)
+	if !found {
+		ctx.Output.WriteLine("You aren't carrying that.")
+		return
+	}
+
+	var to game.Subject
+	if other, ok := ctx.World.FindPlayerInRoom(ctx.Player, recipient); ok && other != ctx.Player {
+		to = game.PlayerSubject(other)
+	} else if mob, ok := ctx.World.FindMobInRoom(ctx.Player, recipient); ok {
+		to = game.MobileSubject(mob)
+	} else {
+		ctx.Output.WriteLine("They aren't here.")
+		return
+	}
+
+	if err := ctx.World.GiveObject(ctx.Player, obj, to); err != nil {
+		ctx.Output.WriteLine(err.Error())
+	}
+}
+
+// giveGold handles "give <amount> gold <player>".
+func giveGold(ctx Context, amount int, args string) {
+	currency, rest := game.FirstArg(args)
+	recipient, _ := game.FirstArg(rest)
+	if !strings.EqualFold(currency, "gold") && !strings.EqualFold(currency, "coins") || recipient == "" {
+		ctx.Output.WriteLine("Give how much gold to whom?")
+		return
+	}
+
+	other, ok := ctx.World.FindPlayerInRoom(ctx.Player, recipient)
+	if !ok || other == ctx.Player {
+		ctx.Output.WriteLine("They aren't here.")
+		return
+	}
+
+	if err := ctx.World.GiveGold(ctx.Player, amount, other); err != nil {
+		ctx.Output.WriteLine(err.Error())
+	}
+}
+
 func cmdHair(ctx Context, args string) {
 	if ctx.Player == nil {
 		ctx.Output.WriteLine("You must be logged in to set your hair description.")
@@ -968,9 +1079,21 @@ func cmdCast(ctx Context, args string) {
 	var skillProgress *skills.PlayerSkillProgress
 	var hasSkill bool
 
+	// A quoted spell name ('arcane bolt' goblin) is taken as-is
+	if args[0] == '\'' || args[0] == '"' {
+		name, rest := game.FirstArg(args)
+		if candidate := skills.GetSpellByName(name); candidate != nil {
+			if progress, ok := p.Skills[candidate.ID]; ok && progress.Learned {
+				spell = candidate
+				skillProgress = progress
+				targetKeyword = rest
+			}
+		}
+	}
+
 	// Try matching from longest to shortest spell name
 	words := strings.Fields(args)
-	for i := len(words); i > 0; i-- {
+	for i := len(words); i > 0 && spell == nil; i-- {
 		potentialSpellName := strings.Join(words[:i], " ")
 		spell = skills.GetSpellByName(potentialSpellName)
 
@@ -1000,23 +1123,19 @@ func cmdCast(ctx Context, args string) {
 		return
 	}
 
-	// Handle targeting based on spell type
-	var targetMob *game.Mobile
-	needsTarget := spell.Targeting.Mode == "hostile_single" || spell.Targeting.Mode == "hostile_area"
-
-	if needsTarget {
-		if targetKeyword == "" {
-			ctx.Output.WriteLine("Cast on whom?")
-			return
-		}
+	if err := ctx.World.CanCastHere(p); err != nil {
+		ctx.Output.WriteLine(err.Error())
+		return
+	}
 
-		// Find target mob by keyword
-		mob, found := ctx.World.FindMobInRoom(p, targetKeyword)
-		if !found {
-			ctx.Output.WriteLine(fmt.Sprintf("You don't see '%s' here.", targetKeyword))
-			return
-		}
-		targetMob = mob
+	// Handle targeting based on spell type
+	if spell.Targeting.Mode == "hostile_single" && targetKeyword == "" {
+		ctx.Output.WriteLine("Cast on whom?")
+		return
+	}
+	targets, found := resolveTargets(ctx, spell, targetKeyword)
+	if !found {
+		return
 	}
 
 	// Cast the spell!
@@ -1024,6 +1143,8 @@ func cmdCast(ctx Context, args string) {
 	skillProgress.UpdateCooldown()
 	skillProgress.UpdateProficiency(1) // +1% proficiency per cast
 
+	actor, target := game.PlayerSubject(p), targets.subject()
+
 	// Calculate damage if it's a damage spell
 	totalDamage := 0
 	if spell.Effects.Damage != "" && spell.Effects.Damage != "0" {
@@ -1046,46 +1167,49 @@ func cmdCast(ctx Context, args string) {
 		proficiencyBonus := skillProgress.Proficiency / 20
 		totalDamage += proficiencyBonus
 
-		// Deal damage to target
-		if targetMob != nil {
-			died, loot := ctx.World.DamageMob(p, targetMob, totalDamage)
+		// Deal damage to targets, after their saving throws and defenses
+		switch {
+		case len(targets.area) > 0:
+			hitArea(ctx, spell, targets.area, totalDamage, skillProgress.Proficiency)
+			ctx.Output.WriteLine(fmt.Sprintf("Mana remaining: %d/%d", p.Mana, p.MaxMana))
+		case targets.mob != nil:
+			result := game.ResolveHit(spell, target, totalDamage)
 
 			// Show messages
-			msg := strings.ReplaceAll(spell.Messages.Cast, "$actor", p.Name)
-			msg = strings.ReplaceAll(msg, "$spell", spell.Name)
-			msg = strings.ReplaceAll(msg, "$target", targetMob.Short)
-			msg = strings.TrimSuffix(msg, ".") // Remove trailing period before appending damage
-			ctx.Output.WriteLine(fmt.Sprintf("%s for &R%d&w damage! (Proficiency: %d%%)",
-				msg, totalDamage, skillProgress.Proficiency))
+			ctx.World.Act(spell.Messages.CastRoom, actor, target, spellVars(spell, result.Damage), game.ToRoom)
+			announceHit(ctx, spell, target, result, skillProgress.Proficiency)
 			ctx.Output.WriteLine(fmt.Sprintf("Mana remaining: %d/%d", p.Mana, p.MaxMana))
 
-			// Broadcast to room
-			roomMsg := strings.ReplaceAll(spell.Messages.Cast, "$actor", p.Name)
-			roomMsg = strings.ReplaceAll(roomMsg, "$target", targetMob.Short)
-			ctx.World.BroadcastCombatMessage(p, roomMsg)
-
-			if died {
-				deathMsg := fmt.Sprintf("&R%s falls to the ground, defeated!&w", targetMob.Short)
-				ctx.Output.WriteLine(deathMsg)
-				ctx.World.BroadcastCombatMessage(p, deathMsg)
-				if len(loot) > 0 {
-					ctx.Output.WriteLine("You loot: " + strings.Join(loot, ", ") + ".")
-				}
-			} else {
-				hpMsg := fmt.Sprintf("%s has &Y%d/%d&w HP remaining.", targetMob.Short, targetMob.HP, targetMob.MaxHP)
-				ctx.Output.WriteLine(hpMsg)
-			}
+			hitMob(ctx, targets.mob, result.Damage)
+		case targets.player != nil:
+			hitPlayer(ctx, spell, targets.player, totalDamage, skillProgress.Proficiency)
+			ctx.Output.WriteLine(fmt.Sprintf("Mana remaining: %d/%d", p.Mana, p.MaxMana))
 		}
-	} else {
-		// Non-damage spell (utility, healing, etc.)
-		msg := strings.ReplaceAll(spell.Messages.Cast, "$actor", p.Name)
-		msg = strings.ReplaceAll(msg, "$spell", spell.Name)
-		if targetMob != nil {
-			msg = strings.ReplaceAll(msg, "$target", targetMob.Short)
+		return
+	}
+
+	// Non-damage spell (utility, healing, etc.)
+	vars := spellVars(spell, 0)
+	cast, castRoom := spell.Messages.Cast, spell.Messages.CastRoom
+	if targets.object != nil {
+		cast = strings.ReplaceAll(cast, "$target", targets.object.Short)
+		castRoom = strings.ReplaceAll(castRoom, "$target", targets.object.Short)
+	}
+
+	msg := ctx.World.FormatAct(cast, p, actor, target, vars)
+	ctx.Output.WriteLine(fmt.Sprintf("%s (Proficiency: %d%%)", msg, skillProgress.Proficiency))
+	ctx.World.Act(castRoom, actor, target, vars, game.ToRoom)
+	// A save can only stop a spell that does something; a hostile effect with
+	// no damage to halve is resisted entirely
+	if effect, ok := spellEffects[spell.Effects.Type]; ok {
+		if spell.Targeting.Mode == "hostile_single" && game.SavingThrow(target, spell.Effects.SaveType, spell.Effects.SaveDC) {
+			ctx.World.Act(spell.Messages.Miss, actor, target, vars, game.ToActor)
+			ctx.World.Act(spell.Messages.Miss, actor, target, vars, game.ToRoom)
+		} else {
+			effect(ctx, spellCast{spell, targets, skillProgress.Proficiency})
 		}
-		ctx.Output.WriteLine(fmt.Sprintf("%s (Proficiency: %d%%)", msg, skillProgress.Proficiency))
-		ctx.Output.WriteLine(fmt.Sprintf("Mana remaining: %d/%d", p.Mana, p.MaxMana))
 	}
+	ctx.Output.WriteLine(fmt.Sprintf("Mana remaining: %d/%d", p.Mana, p.MaxMana))
 }
 
 // rollDice rolls XdY dice (e.g., "1d6" rolls 1 six-sided die)
@@ -1153,11 +1277,15 @@ func cmdSlash(ctx Context, args string) {
 		}
 	}
 
-	// Find target mob in current room
+	if err := ctx.World.CanFight(p); err != nil {
+		ctx.Output.WriteLine(err.Error())
+		return
+	}
+
+	// Find target mob (or willing player) in current room
 	targetKeyword := strings.ToLower(args)
-	mob, found := ctx.World.FindMobInRoom(p, targetKeyword)
+	mob, victim, found := findHostile(ctx, targetKeyword)
 	if !found {
-		ctx.Output.WriteLine(fmt.Sprintf("You don't see '%s' here.", args))
 		return
 	}
 
@@ -1176,27 +1304,106 @@ func cmdSlash(ctx Context, args string) {
 	skillProgress.UpdateCooldown()
 	skillProgress.UpdateProficiency(1) // +1% proficiency per use
 
+	if victim != nil {
+		hitPlayer(ctx, spell, victim, totalDamage, skillProgress.Proficiency)
+		return
+	}
+
 	// Deal damage to mob
-	died, loot := ctx.World.DamageMob(p, mob, totalDamage)
+	result := game.ResolveHit(spell, game.MobileSubject(mob), totalDamage)
 
 	// Show messages
 	playerMsg := fmt.Sprintf("You slash at %s for &R%d&w damage! (Proficiency: %d%%)",
-		mob.Short, totalDamage, skillProgress.Proficiency)
+		mob.Short, result.Damage, skillProgress.Proficiency)
 	ctx.Output.WriteLine(playerMsg)
+	reportDefense(ctx, spell, game.MobileSubject(mob), result)
+
+	ctx.World.Act("$n slashes at $N!", game.PlayerSubject(p), game.MobileSubject(mob), nil, game.ToRoom)
+
+	hitMob(ctx, mob, result.Damage)
+}
 
-	roomMsg := fmt.Sprintf("%s slashes at %s!", game.CapitalizeName(p.Name), mob.Short)
-	ctx.World.BroadcastCombatMessage(p, roomMsg)
+// hitMob deals an attack's damage to mob once the room has seen it land, and
+// reports how the mob fared unless its counter-attack killed the attacker and
+// sent them to the recall room.
+func hitMob(ctx Context, mob *game.Mobile, damage int) {
+	p := ctx.Player
+	here := p.Location
+	died, loot := ctx.World.DamageMob(p, mob, damage)
+	if p.Location != here {
+		return
+	}
+	reportMobHit(ctx, p, mob, died, loot)
+}
 
+// reportMobHit tells the attacker (and, on a kill, the room) how a mob fared after being hit.
+func reportMobHit(ctx Context, p *game.Player, mob *game.Mobile, died bool, loot []string) {
+	actor, target := game.PlayerSubject(p), game.MobileSubject(mob)
 	if died {
-		deathMsg := fmt.Sprintf("&R%s falls to the ground, defeated!&w", mob.Short)
-		ctx.Output.WriteLine(deathMsg)
-		ctx.World.BroadcastCombatMessage(p, deathMsg)
+		ctx.World.Act("&R$N falls to the ground, defeated!&w", actor, target, nil, game.ToActor)
+		ctx.World.Act("&R$N falls to the ground, defeated!&w", actor, target, nil, game.ToRoom)
 		if len(loot) > 0 {
-			ctx.Output.WriteLine("You loot: " + strings.Join(loot, ", ") + ".")
+			ctx.Output.WriteLine("Its corpse holds: " + strings.Join(loot, ", ") + ".")
 		}
+		return
+	}
+
+	ctx.Output.WriteLine(ctx.World.FormatAct(fmt.Sprintf("$N has &Y%d/%d&w HP remaining.", mob.HP, mob.MaxHP), p, actor, target, nil))
+}
+
+// announceHit tells the attacker and target how an attack spell or maneuver
+// landed, and everyone how the target's saving throw and defenses changed it.
+func announceHit(ctx Context, spell *skills.Spell, target game.Subject, result game.HitResult, proficiency int) {
+	p := ctx.Player
+	actor := game.PlayerSubject(p)
+	vars := spellVars(spell, result.Damage)
+
+	if result.Negated {
+		msg := ctx.World.FormatAct(spell.Messages.Cast, p, actor, target, vars)
+		ctx.Output.WriteLine(fmt.Sprintf("%s (Proficiency: %d%%)", msg, proficiency))
+		ctx.World.Act(spell.Messages.Miss, actor, target, vars, game.ToTarget)
 	} else {
-		hpMsg := fmt.Sprintf("%s has &Y%d/%d&w HP remaining.", mob.Short, mob.HP, mob.MaxHP)
-		ctx.Output.WriteLine(hpMsg)
+		msg := strings.TrimSuffix(ctx.World.FormatAct(spell.Messages.Cast, p, actor, target, vars), ".") // Remove trailing period before appending damage
+		ctx.Output.WriteLine(fmt.Sprintf("%s for &R%d&w damage! (Proficiency: %d%%)", msg, result.Damage, proficiency))
+		ctx.World.Act(spell.Messages.Hit, actor, target, vars, game.ToTarget)
+	}
+
+	if result.Saved {
+		save := spell.Messages.Save
+		if save == "" {
+			save = "$N avoids the worst of it."
+			if result.Negated {
+				save = "$N shrugs it off."
+			}
+		}
+		ctx.World.Act(save, actor, target, vars, game.ToActor)
+		ctx.World.Act(save, actor, target, vars, game.ToRoom)
+	}
+
+	reportDefense(ctx, spell, target, result)
+}
+
+// reportDefense tells the attacker when the target is immune, resistant or
+// vulnerable to their attack's damage type.
+func reportDefense(ctx Context, spell *skills.Spell, target game.Subject, result game.HitResult) {
+	actor := game.PlayerSubject(ctx.Player)
+	switch damageType := spell.Effects.DamageType; result.Defense {
+	case game.DefenseImmune:
+		ctx.World.Act(fmt.Sprintf("$N is immune to %s damage!", damageType), actor, target, nil, game.ToActor)
+	case game.DefenseResistant:
+		ctx.World.Act(fmt.Sprintf("$N resists %s damage.", damageType), actor, target, nil, game.ToActor)
+	case game.DefenseVulnerable:
+		ctx.World.Act(fmt.Sprintf("&Y$N is especially vulnerable to %s damage!&w", damageType), actor, target, nil, game.ToActor)
+	}
+}
+
+// spellVars returns the extra act() substitutions available to spell messages.
+// amount fills both $damage and $healing, whichever the spell uses.
+func spellVars(spell *skills.Spell, amount int) game.ActVars {
+	return game.ActVars{
+		"spell":   spell.Name,
+		"damage":  strconv.Itoa(amount),
+		"healing": strconv.Itoa(amount),
 	}
 }
 
@@ -1255,26 +1462,21 @@ func performManeuver(ctx Context, args string, skillID int, commandName string)
 		}
 	}
 
-	needsTarget := spell.Targeting.Mode == "hostile_single" || spell.Targeting.Mode == "hostile_area"
-	if needsTarget && args == "" {
+	if spell.Targeting.Mode == "hostile_single" && args == "" {
 		ctx.Output.WriteLine(fmt.Sprintf("%s whom? (syntax: %s <target>)", spell.Name, commandName))
 		return
 	}
 
-	var targetMob *game.Mobile
-	if needsTarget {
-		mob, found := ctx.World.FindMobInRoom(p, args)
-		if !found {
-			ctx.Output.WriteLine(fmt.Sprintf("You don't see '%s' here.", args))
-			return
-		}
-		targetMob = mob
+	targets, found := resolveTargets(ctx, spell, args)
+	if !found {
+		return
 	}
 
 	skillProgress.UpdateCooldown()
 	skillProgress.UpdateProficiency(1)
 
-	if spell.Effects.Damage != "" && spell.Effects.Damage != "0" && targetMob != nil {
+	actor, target := game.PlayerSubject(p), targets.subject()
+	if spell.Effects.Damage != "" && spell.Effects.Damage != "0" && (spell.Targeting.Mode == "hostile_single" || spell.Targeting.Mode == "hostile_area") {
 		damageFormula := spell.Effects.Damage
 		parts := strings.Split(damageFormula, "+")
 		baseDamage := 0
@@ -1286,38 +1488,25 @@ func performManeuver(ctx Context, args string, skillID int, commandName string)
 		proficiencyBonus := skillProgress.Proficiency / 20
 		totalDamage := baseDamage + statBonus + proficiencyBonus
 
-		died, loot := ctx.World.DamageMob(p, targetMob, totalDamage)
+		switch {
+		case len(targets.area) > 0:
+			hitArea(ctx, spell, targets.area, totalDamage, skillProgress.Proficiency)
+		case targets.player != nil:
+			hitPlayer(ctx, spell, targets.player, totalDamage, skillProgress.Proficiency)
+		default:
+			result := game.ResolveHit(spell, target, totalDamage)
 
-		msg := strings.ReplaceAll(spell.Messages.Cast, "$actor", p.Name)
-		msg = strings.ReplaceAll(msg, "$target", targetMob.Short)
-		msg = strings.TrimSuffix(msg, ".")
-		ctx.Output.WriteLine(fmt.Sprintf("%s for &R%d&w damage! (Proficiency: %d%%)",
-			msg, totalDamage, skillProgress.Proficiency))
+			ctx.World.Act(spell.Messages.CastRoom, actor, target, spellVars(spell, result.Damage), game.ToRoom)
+			announceHit(ctx, spell, target, result, skillProgress.Proficiency)
 
-		roomMsg := strings.ReplaceAll(spell.Messages.Cast, "$actor", p.Name)
-		roomMsg = strings.ReplaceAll(roomMsg, "$target", targetMob.Short)
-		ctx.World.BroadcastCombatMessage(p, roomMsg)
-
-		if died {
-			deathMsg := fmt.Sprintf("&R%s falls to the ground, defeated!&w", targetMob.Short)
-			ctx.Output.WriteLine(deathMsg)
-			ctx.World.BroadcastCombatMessage(p, deathMsg)
-			if len(loot) > 0 {
-				ctx.Output.WriteLine("You loot: " + strings.Join(loot, ", ") + ".")
-			}
-		} else {
-			hpMsg := fmt.Sprintf("%s has &Y%d/%d&w HP remaining.", targetMob.Short, targetMob.HP, targetMob.MaxHP)
-			ctx.Output.WriteLine(hpMsg)
+			hitMob(ctx, targets.mob, result.Damage)
 		}
-
 		return
 	}
 
-	msg := strings.ReplaceAll(spell.Messages.Cast, "$actor", p.Name)
-	if targetMob != nil {
-		msg = strings.ReplaceAll(msg, "$target", targetMob.Short)
-	}
+	msg := ctx.World.FormatAct(spell.Messages.Cast, p, actor, target, spellVars(spell, 0))
 	ctx.Output.WriteLine(fmt.Sprintf("%s (Proficiency: %d%%)", msg, skillProgress.Proficiency))
+	ctx.World.Act(spell.Messages.CastRoom, actor, target, nil, game.ToRoom)
 }
 
 func maneuverStatBonus(parts []string, p *game.Player) int {
@@ -1477,8 +1666,7 @@ func cmdTrain(ctx Context, args string) {
 		return
 	}
 
-	parts := strings.Fields(args)
-	trainerKeyword := parts[0]
+	trainerKeyword, desiredName := game.FirstArg(args)
 
 	p := ctx.Player
 	mob, found := ctx.World.FindMobInRoom(p, trainerKeyword)
@@ -1499,8 +1687,8 @@ func cmdTrain(ctx Context, args string) {
 		return
 	}
 
-	if len(parts) > 1 {
-		desired := strings.ToLower(strings.Join(parts[1:], " "))
+	if desiredName != "" {
+		desired := strings.ToLower(desiredName)
 		if !strings.Contains(strings.ToLower(spell.Name), desired) {
 			ctx.Output.WriteLine(fmt.Sprintf("%s teaches %s. Try: train %s", mob.Short, spell.Name, trainerKeyword))
 			return
@@ -1546,8 +1734,7 @@ func cmdTrain(ctx Context, args string) {
 		ctx.Output.WriteLine(mob.TrainerMessage)
 	}
 
-	ctx.World.BroadcastSystemToRoomExcept(p, fmt.Sprintf("%s trains with %s.",
-		game.CapitalizeName(p.Name), mob.Short))
+	ctx.World.Act("$n trains with $N.", game.PlayerSubject(p), game.MobileSubject(mob), nil, game.ToRoom)
 }
 
 func getPlayerStatValue(p *game.Player, statName string) (int, bool) {
@@ -1599,6 +1786,10 @@ func cmdExits(ctx Context, args string) {
 		ctx.Output.WriteLine("You are nowhere.")
 		return
 	}
+	if view.Dark {
+		ctx.Output.WriteLine("It is too dark to tell.")
+		return
+	}
 	ctx.Output.WriteLine(FormatExits(view.Exits))
 }
 
@@ -1758,7 +1949,7 @@ func cmdSpawn(ctx Context, args string) {
 	}
 
 	ctx.Output.WriteLine(fmt.Sprintf("&GYou summon %s into existence!&w", mob.Short))
-	ctx.World.BroadcastCombatMessage(ctx.Player, fmt.Sprintf("%s summons %s into existence!", game.CapitalizeName(ctx.Player.Name), mob.Short))
+	ctx.World.Act("$n summons $N into existence!", game.PlayerSubject(ctx.Player), game.MobileSubject(mob), nil, game.ToRoom)
 }
 
 func cmdTeleport(ctx Context, args string) {
@@ -1814,9 +2005,93 @@ func cmdRestore(ctx Context, args string) {
 
 	ctx.Player.HP = ctx.Player.MaxHP
 	ctx.Player.Mana = ctx.Player.MaxMana
+	ctx.Player.Move = ctx.Player.MaxMove
+
+	ctx.Output.WriteLine(fmt.Sprintf("&G✓ Restored to full HP, Mana and Move!&w"))
+	ctx.Output.WriteLine(fmt.Sprintf("HP: %d/%d | Mana: %d/%d | Move: %d/%d", ctx.Player.HP, ctx.Player.MaxHP, ctx.Player.Mana, ctx.Player.MaxMana, ctx.Player.Move, ctx.Player.MaxMove))
+}
+
+func cmdBan(ctx Context, args string) {
+	if ctx.Player == nil {
+		ctx.Output.WriteLine("You must be logged in.")
+		return
+	}
+
+	if !ctx.Player.IsKeeper {
+		ctx.Output.WriteLine("You do not have the authority to do that.")
+		return
+	}
+
+	fields := strings.Fields(args)
+	if len(fields) == 0 {
+		list := bans.List()
+		if len(list) == 0 {
+			ctx.Output.WriteLine("No sites are banned.")
+			ctx.Output.WriteLine("Usage: ban <site> [all|newbie] [note]")
+			return
+		}
+
+		ctx.Output.WriteLine("Banned sites:")
+		for _, ban := range list {
+			ctx.Output.WriteLine(fmt.Sprintf("  %-24s %-7s by %-12s %s  %s",
+				ban.Site, ban.Level, ban.BannedBy, ban.BannedAt.Format("2006-01-02"), ban.Note))
+		}
+		return
+	}
 
-	ctx.Output.WriteLine(fmt.Sprintf("&G✓ Restored to full HP and Mana!&w"))
-	ctx.Output.WriteLine(fmt.Sprintf("HP: %d/%d | Mana: %d/%d", ctx.Player.HP, ctx.Player.MaxHP, ctx.Player.Mana, ctx.Player.MaxMana))
+	site := fields[0]
+	level := bans.LevelAll
+	noteStart := 1
+	if len(fields) > 1 {
+		switch strings.ToLower(fields[1]) {
+		case bans.LevelAll, bans.LevelNewbie:
+			level = strings.ToLower(fields[1])
+			noteStart = 2
+		}
+	}
+	note := strings.Join(fields[noteStart:], " ")
+
+	ban, err := bans.Add(site, level, game.CapitalizeName(ctx.Player.Name), note)
+	if err != nil {
+		ctx.Output.WriteLine(fmt.Sprintf("Failed to ban site: %v", err))
+		return
+	}
+
+	if ban.Level == bans.LevelNewbie {
+		ctx.Output.WriteLine(fmt.Sprintf("New characters from %s are now banned.", ban.Site))
+		return
+	}
+	ctx.Output.WriteLine(fmt.Sprintf("%s is now banned.", ban.Site))
+}
+
+func cmdAllow(ctx Context, args string) {
+	if ctx.Player == nil {
+		ctx.Output.WriteLine("You must be logged in.")
+		return
+	}
+
+	if !ctx.Player.IsKeeper {
+		ctx.Output.WriteLine("You do not have the authority to do that.")
+		return
+	}
+
+	site := strings.TrimSpace(args)
+	if site == "" {
+		ctx.Output.WriteLine("Usage: allow <site>")
+		return
+	}
+
+	removed, err := bans.Remove(site)
+	if err != nil {
+		ctx.Output.WriteLine(fmt.Sprintf("Failed to update ban list: %v", err))
+		return
+	}
+	if !removed {
+		ctx.Output.WriteLine(fmt.Sprintf("%s is not banned.", site))
+		return
+	}
+
+	ctx.Output.WriteLine(fmt.Sprintf("%s has been unbanned.", site))
 }
 
 func cmdHelp(ctx Context, args string) {
@@ -1929,36 +2204,83 @@ func loadHelpTopics() map[string]HelpTopic {
 	return helpData
 }
 
+// movementCommands maps the movement command names to the directions they
+// take the player.
+var movementCommands = map[string]string{
+	"north": "north",
+	"south": "south",
+	"east":  "east",
+	"west":  "west",
+	"up":    "up",
+	"down":  "down",
+	"ne":    "northeast",
+	"nw":    "northwest",
+	"se":    "southeast",
+	"sw":    "southwest",
+	"n":     "north",
+	"s":     "south",
+	"e":     "east",
+	"w":     "west",
+	"u":     "up",
+	"d":     "down",
+}
+
+// IsMovement reports whether command moves the player.
+func IsMovement(command string) bool {
+	_, ok := movementCommands[strings.ToLower(command)]
+	return ok
+}
+
 func registerMovement(registry *Registry) {
-	directions := map[string]string{
-		"north": "north",
-		"south": "south",
-		"east":  "east",
-		"west":  "west",
-		"up":    "up",
-		"down":  "down",
-		"ne":    "northeast",
-		"nw":    "northwest",
-		"se":    "southeast",
-		"sw":    "southwest",
-		"n":     "north",
-		"s":     "south",
-		"e":     "east",
-		"w":     "west",
-		"u":     "up",
-		"d":     "down",
-	}
-
-	for name, direction := range directions {
+	for name, direction := range movementCommands {
 		dir := direction
 		registry.Register(name, func(ctx Context, args string) {
 			view, err := ctx.World.MovePlayer(ctx.Player, dir)
+			if isMoveRuleError(err) {
+				ctx.Output.WriteLine(err.Error())
+				return
+			}
 			if err != nil {
 				ctx.Output.WriteLine("You cannot go that way.")
 				return
 			}
 
-			DisplayRoomView(ctx.Output, view, ctx.Player.AutoExits)
+			enterRoom(ctx, view)
+			for _, follower := range view.Followers {
+				followerCtx := Context{World: ctx.World, Player: follower, Output: follower.Output}
+				if next, err := ctx.World.DescribeRoom(follower); err == nil {
+					enterRoom(followerCtx, next)
+				}
+			}
 		})
 	}
 }
+
+// isMoveRuleError reports whether a movement failure has a message worth
+// showing the player rather than the generic "You cannot go that way."
+func isMoveRuleError(err error) bool {
+	return errors.Is(err, game.ErrRoomFull) ||
+		errors.Is(err, game.ErrExhausted) ||
+		errors.Is(err, game.ErrNeedBoat) ||
+		errors.Is(err, game.ErrNeedFlight) ||
+		errors.Is(err, game.ErrAsleep) ||
+		errors.Is(err, game.ErrNotStanding)
+}
+
+// enterRoom shows the player the room they just arrived in, then applies the
+// room's entry effects (death traps, teleports) and shows wherever they end up.
+func enterRoom(ctx Context, view game.RoomView) {
+	location := ctx.Player.Location
+	if !ctx.World.RoomHasFlag(location, game.RoomFlagTeleport) || ctx.World.RoomHasFlag(location, game.RoomFlagTeleShowDesc) || ctx.Player.IsKeeper {
+		DisplayRoomView(ctx.Output, view, ctx.Player.AutoExits)
+	}
+
+	if ctx.World.ApplyRoomEntry(ctx.Player) {
+		if next, err := ctx.World.DescribeRoom(ctx.Player); err == nil {
+			DisplayRoomView(ctx.Output, next, ctx.Player.AutoExits)
+		}
+	}
+
+	ctx.World.RunGreetTriggers(ctx.Player)
+	ctx.World.MobsNoticeArrival(ctx.Player)
+}
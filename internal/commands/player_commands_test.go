@@ -1,6 +1,10 @@
 package commands
 
-import "testing"
+import (
+    "testing"
+
+    "njata/internal/game"
+)
 
 func TestFormatExits(t *testing.T) {
     exits := []string{"west", "north", "up", "southeast"}
@@ -18,3 +22,28 @@ func TestFormatExitsNone(t *testing.T) {
         t.Fatalf("expected %q, got %q", want, got)
     }
 }
+
+func TestSplitContainer(t *testing.T) {
+    rooms := map[int]*game.Room{1: {Vnum: 1, Name: "Glade", Flags: map[string]bool{}, Exits: map[string]int{}}}
+    world := game.CreateWorldFromRooms(rooms, 1)
+    player := &game.Player{Name: "alice", Location: 1}
+    if err := world.AddPlayer(player); err != nil {
+        t.Fatalf("add player: %v", err)
+    }
+    world.AddObjectToRoom(player, &game.Object{Short: "a big sword", Keywords: []string{"big", "sword"}})
+    ctx := Context{World: world, Player: player}
+
+    cases := []struct{ args, keyword, container string }{
+        {"big sword", "big sword", ""},
+        {"sword from corpse", "sword", "corpse"},
+        {"'big sword' in chest", "big sword", "chest"},
+        {"all corpse", "all", "corpse"},
+        {"coins", "coins", ""},
+    }
+    for _, c := range cases {
+        keyword, container := splitContainer(ctx, c.args)
+        if keyword != c.keyword || container != c.container {
+            t.Fatalf("splitContainer(%q) = %q, %q; want %q, %q", c.args, keyword, container, c.keyword, c.container)
+        }
+    }
+}
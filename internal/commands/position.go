@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"strings"
+
+	"njata/internal/game"
+)
+
+// positionCommand returns a handler that moves the player into pos.
+func positionCommand(pos string) Handler {
+	return func(ctx Context, args string) {
+		if ctx.Player == nil {
+			ctx.Output.WriteLine("You must be logged in.")
+			return
+		}
+
+		if err := ctx.World.ChangePosition(ctx.Player, pos); err != nil {
+			ctx.Output.WriteLine(err.Error())
+		}
+	}
+}
+
+// cmdWake stands the player up, or wakes a sleeping player in the room.
+func cmdWake(ctx Context, args string) {
+	if ctx.Player == nil {
+		ctx.Output.WriteLine("You must be logged in.")
+		return
+	}
+
+	args = strings.TrimSpace(args)
+	if args == "" {
+		if !game.IsAsleep(ctx.Player) {
+			ctx.Output.WriteLine("You are already awake.")
+			return
+		}
+		if err := ctx.World.ChangePosition(ctx.Player, game.PositionStanding); err != nil {
+			ctx.Output.WriteLine(err.Error())
+		}
+		return
+	}
+
+	target, ok := ctx.World.FindPlayerInRoom(ctx.Player, args)
+	if !ok {
+		ctx.Output.WriteLine("They aren't here.")
+		return
+	}
+	if target == ctx.Player {
+		cmdWake(ctx, "")
+		return
+	}
+
+	if err := ctx.World.WakePlayer(ctx.Player, target); err != nil {
+		ctx.Output.WriteLine(err.Error())
+	}
+}
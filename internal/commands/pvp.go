@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"njata/internal/game"
+	"njata/internal/skills"
+)
+
+// cmdPvP shows or changes whether the player will fight other players.
+func cmdPvP(ctx Context, args string) {
+	if ctx.Player == nil {
+		ctx.Output.WriteLine("You must be logged in.")
+		return
+	}
+
+	p := ctx.Player
+	arg, _ := game.FirstArg(args)
+	switch strings.ToLower(arg) {
+	case "":
+		if p.PvP {
+			ctx.Output.WriteLine("You are willing to fight other players.")
+		} else {
+			ctx.Output.WriteLine("You are not fighting other players.")
+		}
+		if wait := game.PvPToggleCooldown - time.Since(p.PvPToggled); !p.PvPToggled.IsZero() && wait > 0 {
+			ctx.Output.WriteLine(fmt.Sprintf("You can change your mind in %d minute(s).", int(wait.Minutes())+1))
+		}
+	case "on":
+		if err := ctx.World.SetPvP(p, true); err != nil {
+			ctx.Output.WriteLine(err.Error())
+			return
+		}
+		ctx.Output.WriteLine("&RYou are now willing to fight other players.&w")
+	case "off":
+		if err := ctx.World.SetPvP(p, false); err != nil {
+			ctx.Output.WriteLine(err.Error())
+			return
+		}
+		ctx.Output.WriteLine("You will no longer fight other players.")
+	default:
+		ctx.Output.WriteLine("Syntax: pvp [on | off]")
+	}
+}
+
+// cmdPKills shows keepers the most recent player kills.
+func cmdPKills(ctx Context, args string) {
+	if ctx.Player == nil {
+		ctx.Output.WriteLine("You must be logged in.")
+		return
+	}
+
+	if !ctx.Player.IsKeeper {
+		ctx.Output.WriteLine("You do not have the authority to do that.")
+		return
+	}
+
+	kills := ctx.World.PKills()
+	if len(kills) == 0 {
+		ctx.Output.WriteLine("No player has slain another.")
+		return
+	}
+
+	ctx.Output.WriteLine("&YPlayer kills&w")
+	for i := len(kills) - 1; i >= 0; i-- {
+		kill := kills[i]
+		ctx.Output.WriteLine(fmt.Sprintf("  %s  %-12s killed %-12s in room %d",
+			kill.When.Format("2006-01-02 15:04"), kill.Killer, kill.Victim, kill.Room))
+	}
+}
+
+// findHostile finds who the player means to attack: a mobile in the room,
+// or another player who has agreed to fight. It explains to the player when
+// there is nobody they may attack.
+func findHostile(ctx Context, keyword string) (*game.Mobile, *game.Player, bool) {
+	if mob, ok := ctx.World.FindMobInRoom(ctx.Player, keyword); ok {
+		return mob, nil, true
+	}
+
+	if victim, ok := ctx.World.FindPlayerInRoom(ctx.Player, keyword); ok {
+		if err := ctx.World.CanAttackPlayer(ctx.Player, victim); err != nil {
+			ctx.Output.WriteLine(err.Error())
+			return nil, nil, false
+		}
+		return nil, victim, true
+	}
+
+	ctx.Output.WriteLine(fmt.Sprintf("You don't see '%s' here.", keyword))
+	return nil, nil, false
+}
+
+// hitPlayer lands an attack spell or maneuver on another player, who may
+// save against it or shrug it off.
+func hitPlayer(ctx Context, spell *skills.Spell, victim *game.Player, damage, proficiency int) {
+	actor, target := game.PlayerSubject(ctx.Player), game.PlayerSubject(victim)
+	ctx.World.Act(spell.Messages.CastRoom, actor, target, spellVars(spell, damage), game.ToNotTarget)
+	result := game.ResolveHit(spell, target, damage)
+	announceHit(ctx, spell, target, result, proficiency)
+	hurtPlayer(ctx, victim, result.Damage)
+}
+
+// hurtPlayer deals damage from the player's attack to victim and tells the
+// attacker how they are holding up.
+func hurtPlayer(ctx Context, victim *game.Player, damage int) {
+	p := ctx.Player
+	if !ctx.World.DamagePlayer(p, victim, damage) {
+		ctx.Output.WriteLine(ctx.World.FormatAct(fmt.Sprintf("$N has &Y%d/%d&w HP remaining.", victim.HP, victim.MaxHP), p, game.PlayerSubject(p), game.PlayerSubject(victim), nil))
+	}
+}
@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"njata/internal/game"
+	"njata/internal/quests"
+	"njata/internal/skills"
+)
+
+const questSyntax = "Syntax: quest [list | info <quest> | accept <quest> | abandon <quest> | complete <quest>]"
+
+// cmdQuest handles "quest list", "quest info", "quest accept",
+// "quest abandon" and "quest complete".
+func cmdQuest(ctx Context, args string) {
+	if ctx.Player == nil {
+		ctx.Output.WriteLine("You must be logged in.")
+		return
+	}
+
+	sub, rest := game.FirstArg(args)
+	sub = strings.ToLower(sub)
+	if sub == "" || sub == "list" {
+		questList(ctx)
+		return
+	}
+	if strings.TrimSpace(rest) == "" {
+		ctx.Output.WriteLine(questSyntax)
+		return
+	}
+
+	switch {
+	case strings.HasPrefix("info", sub):
+		questInfo(ctx, rest)
+	case strings.HasPrefix("accept", sub):
+		if _, err := ctx.World.AcceptQuest(ctx.Player, rest); err != nil {
+			ctx.Output.WriteLine(err.Error())
+		}
+	case strings.HasPrefix("abandon", sub):
+		q, err := ctx.World.AbandonQuest(ctx.Player, rest)
+		if err != nil {
+			ctx.Output.WriteLine(err.Error())
+			return
+		}
+		ctx.Output.WriteLine(fmt.Sprintf("You abandon the quest '%s'.", q.Name))
+	case strings.HasPrefix("complete", sub):
+		if _, err := ctx.World.CompleteQuest(ctx.Player, rest); err != nil {
+			ctx.Output.WriteLine(err.Error())
+		}
+	default:
+		ctx.Output.WriteLine(questSyntax)
+	}
+}
+
+func questList(ctx Context) {
+	active, completed := ctx.World.PlayerQuests(ctx.Player)
+	offers := ctx.World.OfferedQuests(ctx.Player)
+
+	if len(active) == 0 {
+		ctx.Output.WriteLine("You are not on any quests.")
+	} else {
+		ctx.Output.WriteLine("&YYour quests:&w")
+		for _, q := range active {
+			statuses := ctx.World.QuestObjectives(ctx.Player, q)
+			done := 0
+			for _, status := range statuses {
+				if status.Done() {
+					done++
+				}
+			}
+			ctx.Output.WriteLine(fmt.Sprintf("  [%3d] %-30s %d/%d objectives", q.ID, q.Name, done, len(statuses)))
+		}
+	}
+
+	if len(offers) > 0 {
+		ctx.Output.WriteLine("&YOffered here:&w")
+		for _, offer := range offers {
+			giver := ctx.World.FormatAct("$N", ctx.Player, game.PlayerSubject(ctx.Player), game.MobileSubject(offer.Giver), nil)
+			ctx.Output.WriteLine(fmt.Sprintf("  [%3d] %-30s from %s", offer.Quest.ID, offer.Quest.Name, giver))
+		}
+	}
+
+	if len(completed) > 0 {
+		ctx.Output.WriteLine(fmt.Sprintf("You have completed %d quest(s).", len(completed)))
+	}
+}
+
+func questInfo(ctx Context, arg string) {
+	q, taken := ctx.World.FindQuest(ctx.Player, arg)
+	if q == nil {
+		ctx.Output.WriteLine("You know of no quest like that.")
+		return
+	}
+
+	ctx.Output.WriteLine(fmt.Sprintf("&Y%s&w", q.Name))
+	if q.Description != "" {
+		ctx.Output.WriteLine(q.Description)
+	}
+
+	ctx.Output.WriteLine("Objectives:")
+	for _, status := range ctx.World.QuestObjectives(ctx.Player, q) {
+		mark := " "
+		if taken && status.Done() {
+			mark = "x"
+		}
+		line := fmt.Sprintf("  [%s] %s", mark, status.Objective.Description)
+		if taken && status.Need > 1 {
+			line += fmt.Sprintf(" (%d/%d)", status.Have, status.Need)
+		}
+		ctx.Output.WriteLine(line)
+	}
+
+	if rewards := describeRewards(q.Rewards); len(rewards) > 0 {
+		ctx.Output.WriteLine("Rewards: " + strings.Join(rewards, ", "))
+	}
+	if !taken {
+		ctx.Output.WriteLine(fmt.Sprintf("Type 'quest accept %d' to take it on.", q.ID))
+	}
+}
+
+// describeRewards lists a quest's rewards for the info display.
+func describeRewards(rewards quests.Rewards) []string {
+	var parts []string
+	if rewards.Gold > 0 {
+		parts = append(parts, fmt.Sprintf("%d gold", rewards.Gold))
+	}
+	if n := len(rewards.Items); n == 1 {
+		parts = append(parts, "an item")
+	} else if n > 1 {
+		parts = append(parts, fmt.Sprintf("%d items", n))
+	}
+	if reward := rewards.Proficiency; reward != nil {
+		if spell := skills.GetSpell(reward.SpellID); spell != nil {
+			parts = append(parts, fmt.Sprintf("+%d%% %s proficiency", reward.Amount, spell.Name))
+		}
+	}
+	if spell := skills.GetSpell(rewards.TeachSpell); spell != nil {
+		parts = append(parts, "the spell "+spell.Name)
+	}
+	return parts
+}
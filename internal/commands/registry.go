@@ -7,9 +7,14 @@ import (
 
 type Handler func(Context, string)
 
+// FallbackHandler is tried when no registered command matches. It returns
+// false if it did not handle the command either.
+type FallbackHandler func(ctx Context, command string, args string) bool
+
 type Registry struct {
     handlers map[string]Handler
     ordered []string // maintain registration order for consistent prefix matching
+    fallback FallbackHandler
 }
 
 func NewRegistry() *Registry {
@@ -33,23 +38,55 @@ func (r *Registry) Register(name string, handler Handler) {
 
 func (r *Registry) Execute(ctx Context, command string, args string) bool {
     lower := strings.ToLower(command)
-    
-    // First check for exact match
-    if handler, ok := r.handlers[lower]; ok {
+    name, handler := r.resolve(lower)
+
+    // Scripts in the room, or on what the player carries, may take over a
+    // command, whether it is typed in full or abbreviated
+    if ctx.World != nil && ctx.Player != nil {
+        if ctx.World.RunCommandTriggers(ctx.Player, lower, args) {
+            return true
+        }
+        if name != "" && name != lower && ctx.World.RunCommandTriggers(ctx.Player, name, args) {
+            return true
+        }
+    }
+
+    if handler != nil {
         handler(ctx, args)
         return true
     }
+
+    if r.fallback != nil {
+        return r.fallback(ctx, command, args)
+    }
     
-    // Then check for prefix match (in registration order)
+    return false
+}
+
+// resolve finds the command that lower (already lower-cased) names: an exact
+// match first, then the first registered command it abbreviates.
+func (r *Registry) resolve(lower string) (string, Handler) {
+    if handler, ok := r.handlers[lower]; ok {
+        return lower, handler
+    }
+
     for _, name := range r.ordered {
         if StringPrefix(lower, name) {
-            handler := r.handlers[name]
-            handler(ctx, args)
-            return true
+            return name, r.handlers[name]
         }
     }
-    
-    return false
+    return "", nil
+}
+
+// SetFallback installs a handler for input that matches no command (e.g. socials).
+func (r *Registry) SetFallback(fallback FallbackHandler) {
+    r.fallback = fallback
+}
+
+// Has reports whether command would resolve to a handler, exactly or by prefix.
+func (r *Registry) Has(command string) bool {
+    name, _ := r.resolve(strings.ToLower(command))
+    return name != ""
 }
 
 func (r *Registry) List() []string {
@@ -2,6 +2,9 @@ package commands
 
 import (
 	"testing"
+
+	"njata/internal/game"
+	"njata/internal/script"
 )
 
 func TestStringPrefix(t *testing.T) {
@@ -106,3 +109,53 @@ func TestRegistryPrefixMatching(t *testing.T) {
 		t.Errorf("Non-matching command 'xyz' should return false")
 	}
 }
+
+func TestRegistryFallback(t *testing.T) {
+	reg := NewRegistry()
+	reg.Register("look", func(ctx Context, args string) {})
+
+	var got string
+	reg.SetFallback(func(ctx Context, command string, args string) bool {
+		if command != "smile" {
+			return false
+		}
+		got = command + " " + args
+		return true
+	})
+
+	if !reg.Execute(Context{}, "smile", "bob") || got != "smile bob" {
+		t.Fatalf("expected fallback to handle smile, got %q", got)
+	}
+	if reg.Execute(Context{}, "xyzzy", "") {
+		t.Fatalf("expected unknown command to be unhandled")
+	}
+}
+
+// lineOutput records the lines written to a player.
+type lineOutput struct{ lines []string }
+
+func (o *lineOutput) Write(text string)     { o.lines = append(o.lines, text) }
+func (o *lineOutput) WriteLine(text string) { o.lines = append(o.lines, text) }
+
+func TestCommandTriggersMatchAbbreviations(t *testing.T) {
+	rooms := map[int]*game.Room{1: {Vnum: 1, Name: "Cellar", Flags: map[string]bool{}, Exits: map[string]int{}, Scripts: []script.Script{
+		{Trigger: "command", Arg: "pull", Code: []string{"echo The lever creaks."}},
+	}}}
+	world := game.CreateWorldFromRooms(rooms, 1)
+	out := &lineOutput{}
+	player := &game.Player{Name: "alice", Location: 1, Output: out}
+	if err := world.AddPlayer(player); err != nil {
+		t.Fatalf("add player: %v", err)
+	}
+
+	reg := NewRegistry()
+	pulled := false
+	reg.Register("pull", func(ctx Context, args string) { pulled = true })
+
+	if !reg.Execute(Context{World: world, Player: player}, "pul", "lever") {
+		t.Fatalf("expected the command to be handled")
+	}
+	if pulled || len(out.lines) != 1 || out.lines[0] != "The lever creaks." {
+		t.Fatalf("expected the room's script to take over the abbreviated command, got %v", out.lines)
+	}
+}
@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"njata/internal/game"
+)
+
+func cmdList(ctx Context, args string) {
+	if ctx.Player == nil {
+		ctx.Output.WriteLine("You must be logged in.")
+		return
+	}
+
+	keeper, items, err := ctx.World.ShopList(ctx.Player, args)
+	if err != nil {
+		ctx.Output.WriteLine(err.Error())
+		return
+	}
+	if len(items) == 0 {
+		if strings.TrimSpace(args) == "" {
+			ctx.Output.WriteLine("You can't buy anything here.")
+		} else {
+			ctx.Output.WriteLine("You can't buy that here.")
+		}
+		return
+	}
+
+	name := ctx.World.FormatAct("$N", ctx.Player, game.PlayerSubject(ctx.Player), game.MobileSubject(keeper), nil)
+	ctx.Output.WriteLine(fmt.Sprintf("%s has for sale:", name))
+	ctx.Output.WriteLine("[ Price] Item")
+	for _, item := range items {
+		line := fmt.Sprintf("[%6d] %s", item.Price, item.Object.Short)
+		if item.Count > 1 {
+			line += fmt.Sprintf(" (%d)", item.Count)
+		}
+		ctx.Output.WriteLine(line)
+	}
+}
+
+func cmdBuy(ctx Context, args string) {
+	if ctx.Player == nil {
+		ctx.Output.WriteLine("You must be logged in.")
+		return
+	}
+
+	// "buy 3 ale" buys several at once
+	quantity := 1
+	first, rest := game.FirstArg(args)
+	if n, err := strconv.Atoi(first); err == nil && rest != "" {
+		quantity, args = n, rest
+	}
+	if strings.TrimSpace(args) == "" {
+		ctx.Output.WriteLine("Buy what?")
+		return
+	}
+
+	if err := ctx.World.BuyObject(ctx.Player, args, quantity); err != nil {
+		ctx.Output.WriteLine(err.Error())
+	}
+}
+
+func cmdSell(ctx Context, args string) {
+	if ctx.Player == nil {
+		ctx.Output.WriteLine("You must be logged in.")
+		return
+	}
+
+	keyword := strings.TrimSpace(args)
+	if keyword == "" {
+		ctx.Output.WriteLine("Sell what?")
+		return
+	}
+
+	obj, found := ctx.World.FindObjectInInventory(ctx.Player, keyword)
+	if !found {
+		ctx.Output.WriteLine("You aren't carrying that.")
+		return
+	}
+
+	if err := ctx.World.SellObject(ctx.Player, obj); err != nil {
+		ctx.Output.WriteLine(err.Error())
+	}
+}
+
+func cmdValue(ctx Context, args string) {
+	if ctx.Player == nil {
+		ctx.Output.WriteLine("You must be logged in.")
+		return
+	}
+
+	keyword := strings.TrimSpace(args)
+	if keyword == "" {
+		ctx.Output.WriteLine("Value what?")
+		return
+	}
+
+	obj, found := ctx.World.FindObjectInInventory(ctx.Player, keyword)
+	if !found {
+		ctx.Output.WriteLine("You aren't carrying that.")
+		return
+	}
+
+	keeper, price, err := ctx.World.Appraise(ctx.Player, obj)
+	if err != nil {
+		ctx.Output.WriteLine(err.Error())
+		return
+	}
+	vars := game.ActVars{"price": strconv.Itoa(price), "object": obj.Short}
+	ctx.Output.WriteLine(ctx.World.FormatAct("$N tells you 'I'll give you $price gold for $object.'", ctx.Player, game.PlayerSubject(ctx.Player), game.MobileSubject(keeper), vars))
+}
@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"njata/internal/socials"
+)
+
+// executeSocial is the registry fallback: input that matches no command is
+// tried as a social.
+func executeSocial(ctx Context, command string, args string) bool {
+	if ctx.Player == nil {
+		return false
+	}
+
+	social := socials.Find(command)
+	if social == nil {
+		return false
+	}
+
+	if err := ctx.World.PerformSocial(ctx.Player, social, args); err != nil {
+		ctx.Output.WriteLine(err.Error())
+	}
+	return true
+}
+
+func cmdSocials(ctx Context, args string) {
+	names := socials.Names()
+	if len(names) == 0 {
+		ctx.Output.WriteLine("No socials are available.")
+		return
+	}
+
+	filter := strings.ToLower(strings.TrimSpace(args))
+	const perRow = 6
+
+	var row []string
+	shown := 0
+	for _, name := range names {
+		if filter != "" && !strings.HasPrefix(name, filter) {
+			continue
+		}
+		row = append(row, fmt.Sprintf("%-12s", name))
+		shown++
+		if len(row) == perRow {
+			ctx.Output.WriteLine(strings.TrimRight(strings.Join(row, ""), " "))
+			row = row[:0]
+		}
+	}
+	if len(row) > 0 {
+		ctx.Output.WriteLine(strings.TrimRight(strings.Join(row, ""), " "))
+	}
+
+	if shown == 0 {
+		ctx.Output.WriteLine(fmt.Sprintf("No socials start with '%s'.", filter))
+		return
+	}
+	ctx.Output.WriteLine(fmt.Sprintf("%d socials.", shown))
+}
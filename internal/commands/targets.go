@@ -0,0 +1,155 @@
+package commands
+
+import (
+	"strings"
+
+	"njata/internal/game"
+	"njata/internal/skills"
+)
+
+// spellTargets is who or what a spell or maneuver is aimed at, depending on
+// its targeting mode.
+type spellTargets struct {
+	mob    *game.Mobile       // hostile_single
+	player *game.Player       // hostile_single on a willing player, ally_single, any_player and self
+	object *game.Object       // any_object
+	area   []game.SpellTarget // hostile_area, named target first
+}
+
+// subject returns the single character targeted, for act() messages.
+func (t spellTargets) subject() game.Subject {
+	switch {
+	case t.mob != nil:
+		return game.MobileSubject(t.mob)
+	case t.player != nil:
+		return game.PlayerSubject(t.player)
+	case len(t.area) > 0:
+		return t.area[0].Subject()
+	}
+	return game.Subject{}
+}
+
+// resolveTargets finds the targets of spell for its targeting mode. When
+// there is nothing to aim it at, it tells the player why and returns false.
+func resolveTargets(ctx Context, spell *skills.Spell, keyword string) (spellTargets, bool) {
+	p := ctx.Player
+	var t spellTargets
+
+	switch mode := spell.Targeting.Mode; mode {
+	case "hostile_single", "hostile_area":
+		if err := ctx.World.CanFight(p); err != nil {
+			ctx.Output.WriteLine(err.Error())
+			return t, false
+		}
+		if mode == "hostile_single" || keyword != "" {
+			mob, victim, found := findHostile(ctx, keyword)
+			if !found {
+				return t, false
+			}
+			t.mob, t.player = mob, victim
+		}
+		if mode == "hostile_single" {
+			return t, true
+		}
+
+		t.area = areaTargets(ctx, spell, t.mob, t.player)
+		t.mob, t.player = nil, nil
+		if len(t.area) == 0 {
+			ctx.Output.WriteLine("There is nobody here to attack.")
+			return t, false
+		}
+
+	case "ally_single":
+		if keyword == "" || strings.EqualFold(keyword, "self") || strings.EqualFold(keyword, "me") {
+			t.player = p
+			break
+		}
+		ally, ok := ctx.World.FindPlayerInRoom(p, keyword)
+		if !ok {
+			ctx.Output.WriteLine("They aren't here.")
+			return t, false
+		}
+		t.player = ally
+
+	case "any_player":
+		if keyword == "" {
+			ctx.Output.WriteLine("Cast on whom?")
+			return t, false
+		}
+		other, ok := ctx.World.FindPlayer(keyword)
+		if !ok {
+			ctx.Output.WriteLine("You can't find them.")
+			return t, false
+		}
+		t.player = other
+
+	case "any_object":
+		if keyword == "" {
+			ctx.Output.WriteLine("Cast on what?")
+			return t, false
+		}
+		obj, ok := ctx.World.FindObjectInInventory(p, keyword)
+		if !ok {
+			obj, ok = ctx.World.FindObjectInRoom(p, keyword)
+		}
+		if !ok {
+			ctx.Output.WriteLine("You don't see that here.")
+			return t, false
+		}
+		t.object = obj
+
+	default: // self
+		t.player = p
+	}
+	return t, true
+}
+
+// areaTargets lists everyone an area attack hits, putting the target the
+// player named (if any) first.
+func areaTargets(ctx Context, spell *skills.Spell, mob *game.Mobile, victim *game.Player) []game.SpellTarget {
+	all := ctx.World.AreaTargets(ctx.Player, spell.Targeting.Radius)
+	if mob == nil && victim == nil {
+		return all
+	}
+
+	targets := []game.SpellTarget{{Mobile: mob, Player: victim}}
+	for _, target := range all {
+		if target.Distance == 0 && (mob != nil && target.Mobile == mob || victim != nil && target.Player == victim) {
+			continue
+		}
+		targets = append(targets, target)
+	}
+	return targets
+}
+
+// hitArea lands an area spell or maneuver on each of its targets. Each one
+// saves on its own, and those in other rooms take less damage the further
+// away they are.
+func hitArea(ctx Context, spell *skills.Spell, targets []game.SpellTarget, damage, proficiency int) {
+	p := ctx.Player
+	here := p.Location
+	actor := game.PlayerSubject(p)
+	ctx.World.Act(spell.Messages.CastRoom, actor, targets[0].Subject(), spellVars(spell, damage), game.ToRoom)
+
+	for _, target := range targets {
+		if p.Location != here {
+			return // the caster fell before the spell was done
+		}
+
+		subject := target.Subject()
+		result := game.ResolveHit(spell, subject, game.AreaDamage(damage, target.Distance))
+		announceHit(ctx, spell, subject, result, proficiency)
+
+		switch {
+		case target.Player != nil:
+			hurtPlayer(ctx, target.Player, result.Damage)
+		case target.Distance > 0:
+			ctx.World.Act("&R$n is caught in the blast of $spell!&w", subject, game.Subject{}, spellVars(spell, result.Damage), game.ToRoom)
+			if died, _ := ctx.World.StrikeMob(p, target.Mobile, result.Damage); died {
+				ctx.World.Act("&R$N falls, defeated!&w", actor, subject, nil, game.ToActor)
+			}
+		default:
+			hitMob(ctx, target.Mobile, result.Damage)
+		}
+	}
+}
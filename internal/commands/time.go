@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"fmt"
+
+	"njata/internal/game"
+)
+
+func cmdTime(ctx Context, args string) {
+	clock := ctx.World.Clock()
+	ctx.Output.WriteLine(clock.String())
+
+	switch clock.Sun() {
+	case game.SunRise:
+		ctx.Output.WriteLine("The sun is rising.")
+	case game.SunSet:
+		ctx.Output.WriteLine("The sun is setting.")
+	case game.SunDark:
+		ctx.Output.WriteLine(fmt.Sprintf("It is night, and the moon is %s.", clock.MoonPhase()))
+	}
+}
+
+func cmdWeather(ctx Context, args string) {
+	if ctx.Player == nil {
+		ctx.Output.WriteLine("You must be logged in.")
+		return
+	}
+
+	weather, ok := ctx.World.RoomWeather(ctx.Player.Location)
+	if !ok {
+		ctx.Output.WriteLine("You can't see the sky from here.")
+		return
+	}
+
+	ctx.Output.WriteLine(weather.Description())
+	if ctx.World.IsNight() && weather.Sky == game.SkyCloudless {
+		if phase := ctx.World.Clock().MoonPhase(); phase == game.MoonNew {
+			ctx.Output.WriteLine("There is no moon tonight.")
+		} else {
+			ctx.Output.WriteLine(fmt.Sprintf("The %s moon hangs in the sky.", phase))
+		}
+	}
+}
@@ -9,6 +9,35 @@ import (
 type Config struct {
     StartRoomVnum        int `json:"start_room_vnum"`
     RespawnDefaultMinutes int `json:"respawn_default_minutes"`
+
+    // Link-dead and idle handling. Zero disables the corresponding behavior.
+    LinkDeadGraceMinutes int `json:"linkdead_grace_minutes"`
+    IdleLimboMinutes     int `json:"idle_limbo_minutes"`
+    IdleLogoutMinutes    int `json:"idle_logout_minutes"`
+    LimboRoomVnum        int `json:"limbo_room_vnum"`
+
+    // Death. The dead wake up in the recall room (0 = start room). Zero
+    // disables the corresponding penalty; zero decay keeps corpses until reboot.
+    RecallRoomVnum        int `json:"recall_room_vnum"`
+    DeathGoldLossPercent  int `json:"death_gold_loss_percent"`
+    DeathProficiencyLoss  int `json:"death_proficiency_loss"`
+    CorpseDecayMinutes    int `json:"corpse_decay_minutes"`
+    MobCorpseDecayMinutes int `json:"mob_corpse_decay_minutes"`
+
+    // Connection security. Zero disables the corresponding limit.
+    MaxConnectionsPerIP int `json:"max_connections_per_ip"`
+    MaxLineLength       int `json:"max_line_length"`
+    CommandsPerSecond   int `json:"commands_per_second"`
+    CommandBurst        int `json:"command_burst"`
+    SpamRepeatLimit     int `json:"spam_repeat_limit"`
+
+    // Several commands may be typed on one line separated by this string (default ";").
+    CommandSeparator string `json:"command_separator"`
+
+    // Optional TLS listener. Disabled when TLSPort is zero.
+    TLSPort     int    `json:"tls_port"`
+    TLSCertFile string `json:"tls_cert_file"`
+    TLSKeyFile  string `json:"tls_key_file"`
 }
 
 // Load reads the config file if it exists. Missing files return defaults.
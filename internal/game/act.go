@@ -0,0 +1,336 @@
+package game
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Subject is a player or mobile named in an act() template.
+type Subject struct {
+	Player *Player
+	Mobile *Mobile
+}
+
+// PlayerSubject wraps a player for use in Act.
+func PlayerSubject(p *Player) Subject {
+	return Subject{Player: p}
+}
+
+// MobileSubject wraps a mobile for use in Act.
+func MobileSubject(m *Mobile) Subject {
+	return Subject{Mobile: m}
+}
+
+// IsZero reports whether the subject refers to nobody.
+func (s Subject) IsZero() bool {
+	return s.Player == nil && s.Mobile == nil
+}
+
+func (s Subject) is(p *Player) bool {
+	return p != nil && s.Player == p
+}
+
+// name returns how the subject is written in a sentence.
+func (s Subject) name() string {
+	switch {
+	case s.Player != nil:
+		return CapitalizeName(s.Player.Name)
+	case s.Mobile != nil:
+		if strings.TrimSpace(s.Mobile.Short) == "" {
+			return "the creature"
+		}
+		return s.Mobile.Short
+	}
+	return "someone"
+}
+
+// Pronoun sets, indexed by pronounSet.
+const (
+	pronounsIt = iota
+	pronounsHe
+	pronounsShe
+	pronounsThey
+)
+
+var (
+	subjectPronouns    = [...]string{"it", "he", "she", "they"}
+	objectPronouns     = [...]string{"it", "him", "her", "them"}
+	possessivePronouns = [...]string{"its", "his", "her", "their"}
+)
+
+// pronounSet picks he/she for male/female characters. Neuter players are
+// "they"; genderless mobiles are "it".
+func (s Subject) pronounSet() int {
+	switch {
+	case s.Player != nil:
+		switch s.Player.Sex {
+		case 1:
+			return pronounsHe
+		case 2:
+			return pronounsShe
+		}
+		return pronounsThey
+	case s.Mobile != nil:
+		switch strings.ToLower(s.Mobile.Gender) {
+		case "male":
+			return pronounsHe
+		case "female":
+			return pronounsShe
+		}
+	}
+	return pronounsIt
+}
+
+// location returns the room a subject is in, or 0 if unknown.
+func (w *World) subjectLocation(s Subject) int {
+	if s.Player != nil {
+		return s.Player.Location
+	}
+	if s.Mobile != nil {
+		for vnum, room := range w.rooms {
+			for _, mob := range room.Mobiles {
+				if mob == s.Mobile {
+					return vnum
+				}
+			}
+		}
+	}
+	return 0
+}
+
+// CanSee reports whether viewer can make out subject. Keepers see everything;
+// in a dark room only infravision or darkvision reveals other characters.
+func (w *World) CanSee(viewer *Player, subject Subject) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.canSee(viewer, subject)
+}
+
+func (w *World) canSee(viewer *Player, subject Subject) bool {
+	if viewer == nil || subject.is(viewer) || viewer.IsKeeper {
+		return true
+	}
+
+	room, ok := w.rooms[viewer.Location]
+	if !ok || !w.roomIsDark(room) {
+		return true
+	}
+	return playerVision(viewer) != ""
+}
+
+// ActVars holds extra $word substitutions for a template, e.g. "damage" for $damage.
+type ActVars map[string]string
+
+// ActAudience selects who receives an Act message.
+type ActAudience int
+
+const (
+	ToActor     ActAudience = iota // only the actor
+	ToTarget                       // only the target (if a player)
+	ToRoom                         // everyone in the room except the actor
+	ToNotTarget                    // everyone in the room except the actor and target
+)
+
+// FormatAct renders template for one viewer. Codes:
+//
+//	$n, $N          actor and target names
+//	$e/$E $m/$M $s/$S  actor/target subject, object and possessive pronouns
+//	$actor, $target names from the viewer's perspective: "you" when the viewer
+//	                is that character ("yourself" for a target acting on
+//	                themself), and "$actor's" becomes "your"
+//	$word           any key in vars
+//	$$              a literal dollar sign
+//
+// Characters the viewer cannot see are written as "someone". Words starting
+// a sentence are capitalized.
+func (w *World) FormatAct(template string, viewer *Player, actor Subject, target Subject, vars ActVars) string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.formatAct(template, viewer, actor, target, vars)
+}
+
+func (w *World) formatAct(template string, viewer *Player, actor Subject, target Subject, vars ActVars) string {
+	seen := func(s Subject) string {
+		if !w.canSee(viewer, s) {
+			return "someone"
+		}
+		return s.name()
+	}
+	perspective := func(s Subject, possessive bool) string {
+		if s == target && target.is(viewer) && actor.is(viewer) && !possessive {
+			return "yourself"
+		}
+		if s.is(viewer) {
+			if possessive {
+				return "your"
+			}
+			return "you"
+		}
+		if possessive {
+			return seen(s) + "'s"
+		}
+		return seen(s)
+	}
+	pronoun := func(table []string, s Subject) string {
+		if !w.canSee(viewer, s) {
+			return table[pronounsThey]
+		}
+		return table[s.pronounSet()]
+	}
+
+	var b strings.Builder
+	sentenceStart := true
+	write := func(text string, fromCode bool) {
+		if text == "" {
+			return
+		}
+		if sentenceStart && fromCode {
+			text = capitalizeFirst(text)
+		}
+		b.WriteString(text)
+	}
+
+	for i := 0; i < len(template); i++ {
+		c := template[i]
+		switch {
+		case c == '&' && i+1 < len(template):
+			// Colour codes don't affect sentence boundaries.
+			b.WriteByte(c)
+			b.WriteByte(template[i+1])
+			i++
+			continue
+		case c != '$' || i+1 >= len(template):
+			b.WriteByte(c)
+			if c == '.' || c == '!' || c == '?' {
+				sentenceStart = true
+			} else if c != ' ' && c != '\t' {
+				sentenceStart = false
+			}
+			continue
+		}
+
+		if word := leadingWord(template[i+1:]); word == "actor" || word == "target" {
+			subject := actor
+			if word == "target" {
+				subject = target
+			}
+			possessive := strings.HasPrefix(template[i+1+len(word):], "'s")
+			write(perspective(subject, possessive), true)
+			i += len(word)
+			if possessive {
+				i += 2
+			}
+			sentenceStart = false
+			continue
+		} else if value, ok := vars[word]; ok && word != "" {
+			write(value, false)
+			i += len(word)
+			sentenceStart = false
+			continue
+		}
+
+		i++
+		switch template[i] {
+		case 'n':
+			write(seen(actor), true)
+		case 'N':
+			write(seen(target), true)
+		case 'e':
+			write(pronoun(subjectPronouns[:], actor), true)
+		case 'E':
+			write(pronoun(subjectPronouns[:], target), true)
+		case 'm':
+			write(pronoun(objectPronouns[:], actor), true)
+		case 'M':
+			write(pronoun(objectPronouns[:], target), true)
+		case 's':
+			write(pronoun(possessivePronouns[:], actor), true)
+		case 'S':
+			write(pronoun(possessivePronouns[:], target), true)
+		case '$':
+			b.WriteByte('$')
+		default:
+			b.WriteByte('$')
+			b.WriteByte(template[i])
+		}
+		sentenceStart = false
+	}
+
+	return capitalizeFirst(b.String())
+}
+
+// leadingWord returns the run of lowercase letters and underscores at the start of s.
+// Only "actor", "target" and keys in vars are treated as named codes, so
+// legacy forms such as "$mself" still expand as $m followed by "self".
+func leadingWord(s string) string {
+	end := 0
+	for end < len(s) && (s[end] >= 'a' && s[end] <= 'z' || s[end] == '_') {
+		end++
+	}
+	return s[:end]
+}
+
+// capitalizeFirst upper-cases the first letter of s, skipping leading colour codes.
+func capitalizeFirst(s string) string {
+	i := 0
+	for i+1 < len(s) && s[i] == '&' {
+		i += 2
+	}
+	if i >= len(s) {
+		return s
+	}
+	r := rune(s[i])
+	if r >= 'a' && r <= 'z' {
+		return s[:i] + string(unicode.ToUpper(r)) + s[i+1:]
+	}
+	return s
+}
+
+// Act renders template for each member of the audience and sends it. The room
+// is the actor's, or the target's when the actor is a mobile without one.
+// Viewers who ignore a player actor do not receive room messages.
+func (w *World) Act(template string, actor Subject, target Subject, vars ActVars, to ActAudience) {
+	if template == "" {
+		return
+	}
+
+	type delivery struct {
+		player *Player
+		line   string
+	}
+	var deliveries []delivery
+
+	w.mu.RLock()
+	switch to {
+	case ToActor:
+		if actor.Player != nil {
+			deliveries = append(deliveries, delivery{actor.Player, w.formatAct(template, actor.Player, actor, target, vars)})
+		}
+	case ToTarget:
+		if target.Player != nil {
+			deliveries = append(deliveries, delivery{target.Player, w.formatAct(template, target.Player, actor, target, vars)})
+		}
+	case ToRoom, ToNotTarget:
+		location := w.subjectLocation(actor)
+		if location == 0 {
+			location = w.subjectLocation(target)
+		}
+		for _, player := range w.players {
+			if player.Location != location || actor.is(player) {
+				continue
+			}
+			if to == ToNotTarget && target.is(player) {
+				continue
+			}
+			if isIgnoring(player, actor.Player) {
+				continue
+			}
+			deliveries = append(deliveries, delivery{player, w.formatAct(template, player, actor, target, vars)})
+		}
+	}
+	w.mu.RUnlock()
+
+	for _, d := range deliveries {
+		d.player.Output.WriteLine(d.line)
+	}
+}
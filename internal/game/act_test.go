@@ -0,0 +1,73 @@
+package game
+
+import "testing"
+
+func TestFormatActPerspectives(t *testing.T) {
+	world := CreateDefaultWorld()
+	alice := &Player{Name: "alice", Sex: 2, Output: &bufferOutput{}}
+	bob := &Player{Name: "bob", Sex: 1, Output: &bufferOutput{}}
+	carol := &Player{Name: "carol", Output: &bufferOutput{}}
+	for _, p := range []*Player{alice, bob, carol} {
+		if err := world.AddPlayer(p); err != nil {
+			t.Fatalf("add player: %v", err)
+		}
+	}
+
+	actor, target := PlayerSubject(alice), PlayerSubject(bob)
+	template := "$actor's bolt strikes $target. $e grins at $M."
+
+	tests := []struct {
+		viewer *Player
+		want   string
+	}{
+		{alice, "Your bolt strikes Bob. She grins at him."},
+		{bob, "Alice's bolt strikes you. She grins at him."},
+		{carol, "Alice's bolt strikes Bob. She grins at him."},
+	}
+	for _, tt := range tests {
+		if got := world.FormatAct(template, tt.viewer, actor, target, nil); got != tt.want {
+			t.Errorf("viewer %s: expected %q, got %q", tt.viewer.Name, tt.want, got)
+		}
+	}
+}
+
+func TestFormatActPronounsAndCapitalization(t *testing.T) {
+	world := CreateDefaultWorld()
+	viewer := &Player{Name: "viewer"}
+	neuter := PlayerSubject(&Player{Name: "sam"})
+	goblin := MobileSubject(&Mobile{Short: "a goblin", Gender: "neutral"})
+	queen := MobileSubject(&Mobile{Short: "the queen", Gender: "female"})
+
+	if got := world.FormatAct("$n stretches $s arms.", viewer, neuter, Subject{}, nil); got != "Sam stretches their arms." {
+		t.Errorf("unexpected neuter player line %q", got)
+	}
+	if got := world.FormatAct("&R$n snarls at $N; $e bares $s teeth.", viewer, goblin, queen, nil); got != "&RA goblin snarls at the queen; it bares its teeth." {
+		t.Errorf("unexpected mobile line %q", got)
+	}
+	if got := world.FormatAct("You hit. $N staggers for $damage.", viewer, neuter, queen, ActVars{"damage": "5"}); got != "You hit. The queen staggers for 5." {
+		t.Errorf("unexpected sentence capitalization %q", got)
+	}
+	if got := world.FormatAct("$n ACKS $mself.", viewer, neuter, Subject{}, nil); got != "Sam ACKS themself." {
+		t.Errorf("unexpected legacy code expansion %q", got)
+	}
+}
+
+func TestFormatActHidesUnseen(t *testing.T) {
+	rooms := map[int]*Room{
+		1: {Vnum: 1, Name: "Cellar", Flags: map[string]bool{"dark": true}, Exits: map[string]int{}},
+	}
+	world := CreateWorldFromRooms(rooms, 1)
+	alice := &Player{Name: "alice", Sex: 2, Output: &bufferOutput{}}
+	bobOut := &bufferOutput{}
+	bob := &Player{Name: "bob", Output: bobOut}
+	for _, p := range []*Player{alice, bob} {
+		if err := world.AddPlayer(p); err != nil {
+			t.Fatalf("add player: %v", err)
+		}
+	}
+
+	world.BroadcastSay(alice, "hello")
+	if !bobOut.Contains("Someone says 'hello'") {
+		t.Fatalf("expected unseen speaker to be 'someone'")
+	}
+}
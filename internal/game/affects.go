@@ -0,0 +1,82 @@
+package game
+
+import "time"
+
+// Affect is a timed effect on a player, such as a spell's buff or debuff.
+type Affect struct {
+	Name    string
+	Expires time.Time // zero means it lasts until removed
+	NoRegen bool      // suppresses natural regeneration while active
+}
+
+func (a Affect) expired(now time.Time) bool {
+	return !a.Expires.IsZero() && !now.Before(a.Expires)
+}
+
+// AddAffect places an affect on the player, replacing any affect of the same name.
+func (w *World) AddAffect(p *Player, affect Affect) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for i, existing := range p.Affects {
+		if existing.Name == affect.Name {
+			p.Affects[i] = affect
+			return
+		}
+	}
+	p.Affects = append(p.Affects, affect)
+}
+
+// RemoveAffect strips the named affect. It returns false if the player didn't have it.
+func (w *World) RemoveAffect(p *Player, name string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for i, existing := range p.Affects {
+		if existing.Name == name {
+			p.Affects = append(p.Affects[:i], p.Affects[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// HasAffect reports whether the named affect is on the player.
+func (w *World) HasAffect(p *Player, name string) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	now := time.Now()
+	for _, affect := range p.Affects {
+		if affect.Name == name && !affect.expired(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// expireAffects drops affects that have run out and returns their names.
+// Callers hold w.mu.
+func expireAffects(p *Player, now time.Time) []string {
+	var expired []string
+	kept := p.Affects[:0]
+	for _, affect := range p.Affects {
+		if affect.expired(now) {
+			expired = append(expired, affect.Name)
+			continue
+		}
+		kept = append(kept, affect)
+	}
+	p.Affects = kept
+	return expired
+}
+
+// regenSuppressed reports whether an active affect blocks regeneration.
+func regenSuppressed(p *Player) bool {
+	for _, affect := range p.Affects {
+		if affect.NoRegen {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,373 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// channelHistorySize is how many messages each channel keeps for "history".
+const channelHistorySize = 20
+
+// Channel is a named global communication channel.
+type Channel struct {
+	Name       string
+	Label      string // shown in brackets, e.g. [Chat]
+	Color      string // SMAUG color code for the whole line
+	KeeperOnly bool   // only Keepers may hear or speak
+	IC         bool   // in-character: out-of-character talk is refused
+	Local      bool   // room speech with a command of its own, not a global channel
+	history    []channelMessage
+}
+
+// channelMessage is one entry in a channel's scrollback.
+type channelMessage struct {
+	speaker string // normalized name, used to honor ignore lists
+	line    string
+}
+
+// Channel errors returned to the speaker.
+var (
+	ErrUnknownChannel = fmt.Errorf("There is no such channel.")
+	ErrChannelDenied  = fmt.Errorf("You can't use that channel.")
+	ErrChannelOff     = fmt.Errorf("You have that channel turned off.")
+	ErrOOCOnICChannel = fmt.Errorf("Keep out-of-character talk to OOC channels. See 'help rules'.")
+)
+
+func defaultChannels() map[string]*Channel {
+	channels := []*Channel{
+		{Name: "chat", Label: "Chat", Color: "&Y"},
+		{Name: "ooc", Label: "OOC", Color: "&C"},
+		{Name: "newbie", Label: "Newbie", Color: "&G"},
+		{Name: "keeper", Label: "Keeper", Color: "&P", KeeperOnly: true},
+		{Name: "say", IC: true, Local: true},
+		{Name: "whisper", IC: true, Local: true},
+		{Name: "yell", IC: true, Local: true},
+		{Name: "emote", IC: true, Local: true},
+	}
+
+	byName := make(map[string]*Channel, len(channels))
+	for _, ch := range channels {
+		byName[ch.Name] = ch
+	}
+	return byName
+}
+
+// oocMarkers are conventional ways of marking out-of-character speech.
+var oocMarkers = []string{"((", "[ooc]", "ooc:"}
+
+// checkChannelRules enforces the named channel's rules on message.
+func (w *World) checkChannelRules(name string, message string) error {
+	w.mu.RLock()
+	ch, ok := w.channels[name]
+	w.mu.RUnlock()
+	if ok && ch.IC {
+		return checkICRules(message)
+	}
+	return nil
+}
+
+// globalChannel looks up a channel players can join, toggle and read the
+// history of. Callers hold w.mu.
+func (w *World) globalChannel(name string) (*Channel, bool) {
+	ch, ok := w.channels[name]
+	if !ok || ch.Local {
+		return nil, false
+	}
+	return ch, true
+}
+
+// checkICRules enforces rule 1 of 'help rules' on in-character speech.
+func checkICRules(message string) error {
+	lower := strings.ToLower(message)
+	for _, marker := range oocMarkers {
+		if strings.Contains(lower, marker) {
+			return ErrOOCOnICChannel
+		}
+	}
+	return nil
+}
+
+// ChannelNames returns the channels the player may use, sorted by name.
+func (w *World) ChannelNames(player *Player) []string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	names := make([]string, 0, len(w.channels))
+	for name, ch := range w.channels {
+		if ch.Local {
+			continue
+		}
+		if ch.KeeperOnly && (player == nil || !player.IsKeeper) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ChannelEnabled reports whether the player is listening to a channel.
+func ChannelEnabled(player *Player, name string) bool {
+	return player.ChannelsOff == nil || !player.ChannelsOff[name]
+}
+
+// SetChannel turns a channel on or off for the player.
+func (w *World) SetChannel(player *Player, name string, on bool) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	ch, ok := w.globalChannel(name)
+	if !ok {
+		return ErrUnknownChannel
+	}
+	if ch.KeeperOnly && !player.IsKeeper {
+		return ErrChannelDenied
+	}
+
+	if on {
+		delete(player.ChannelsOff, name)
+		return nil
+	}
+
+	if player.ChannelsOff == nil {
+		player.ChannelsOff = make(map[string]bool)
+	}
+	player.ChannelsOff[name] = true
+	return nil
+}
+
+// IsIgnoring reports whether listener is ignoring speaker.
+func (w *World) IsIgnoring(listener *Player, speaker *Player) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return isIgnoring(listener, speaker)
+}
+
+// isIgnoring is IsIgnoring for callers that hold w.mu.
+func isIgnoring(listener *Player, speaker *Player) bool {
+	if listener == nil || speaker == nil || listener.Ignoring == nil {
+		return false
+	}
+	return listener.Ignoring[normalizeName(speaker.Name)]
+}
+
+// IgnoredNames returns the names the player ignores, capitalized and sorted.
+func (w *World) IgnoredNames(player *Player) []string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	names := make([]string, 0, len(player.Ignoring))
+	for ignored := range player.Ignoring {
+		names = append(names, CapitalizeName(ignored))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ToggleIgnore starts or stops ignoring a name. It returns true if the name is now ignored.
+// The list is replaced rather than changed in place, so the autosave, which
+// reads it without w.mu, never sees it mid-update.
+func (w *World) ToggleIgnore(player *Player, name string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	key := normalizeName(name)
+	ignoring := make(map[string]bool, len(player.Ignoring)+1)
+	for ignored := range player.Ignoring {
+		ignoring[ignored] = true
+	}
+	ignored := !ignoring[key]
+	if ignored {
+		ignoring[key] = true
+	} else {
+		delete(ignoring, key)
+	}
+	player.Ignoring = ignoring
+	return ignored
+}
+
+// BroadcastChannel sends a message on a named channel and records it in the channel's history.
+func (w *World) BroadcastChannel(speaker *Player, name string, message string) error {
+	if err := w.CanSpeak(speaker); err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	ch, ok := w.globalChannel(name)
+	if !ok {
+		w.mu.Unlock()
+		return ErrUnknownChannel
+	}
+	if ch.KeeperOnly && !speaker.IsKeeper {
+		w.mu.Unlock()
+		return ErrChannelDenied
+	}
+	if !ChannelEnabled(speaker, name) {
+		w.mu.Unlock()
+		return ErrChannelOff
+	}
+	if ch.IC {
+		if err := checkICRules(message); err != nil {
+			w.mu.Unlock()
+			return err
+		}
+	}
+
+	speakerName := CapitalizeName(speaker.Name)
+	ch.history = append(ch.history, channelMessage{
+		speaker: normalizeName(speaker.Name),
+		line:    fmt.Sprintf("%s[%s] %s %s: %s&w", ch.Color, time.Now().Format("15:04"), ch.Label, speakerName, message),
+	})
+	if len(ch.history) > channelHistorySize {
+		ch.history = ch.history[len(ch.history)-channelHistorySize:]
+	}
+
+	listeners := make([]*Player, 0, len(w.players))
+	for _, player := range w.players {
+		if player == speaker {
+			continue
+		}
+		if ch.KeeperOnly && !player.IsKeeper {
+			continue
+		}
+		if !ChannelEnabled(player, name) || isIgnoring(player, speaker) {
+			continue
+		}
+		listeners = append(listeners, player)
+	}
+	w.mu.Unlock()
+
+	speaker.Output.WriteLine(fmt.Sprintf("%s[%s] You: %s&w", ch.Color, ch.Label, message))
+	for _, player := range listeners {
+		player.Output.WriteLine(fmt.Sprintf("%s[%s] %s: %s&w", ch.Color, ch.Label, speakerName, message))
+	}
+	return nil
+}
+
+// ChannelHistory returns the recent messages on a channel, oldest first,
+// leaving out anything said by players the reader ignores.
+func (w *World) ChannelHistory(player *Player, name string) ([]string, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	ch, ok := w.globalChannel(name)
+	if !ok {
+		return nil, ErrUnknownChannel
+	}
+	if ch.KeeperOnly && !player.IsKeeper {
+		return nil, ErrChannelDenied
+	}
+
+	history := make([]string, 0, len(ch.history))
+	for _, msg := range ch.history {
+		if player.Ignoring[msg.speaker] {
+			continue
+		}
+		history = append(history, msg.line)
+	}
+	return history, nil
+}
+
+// SendTell delivers a private message and sets the recipient's reply target.
+func (w *World) SendTell(from *Player, toName string, message string) (*Player, error) {
+	if err := w.CanSpeak(from); err != nil {
+		return nil, err
+	}
+
+	target, ok := w.FindPlayer(toName)
+	if !ok {
+		return nil, fmt.Errorf("They aren't here.")
+	}
+	if target == from {
+		return nil, fmt.Errorf("You mutter to yourself.")
+	}
+	if target.LinkDead {
+		return nil, fmt.Errorf("%s is link-dead and can't hear you.", CapitalizeName(target.Name))
+	}
+	if w.IsIgnoring(target, from) {
+		return nil, fmt.Errorf("%s is ignoring you.", CapitalizeName(target.Name))
+	}
+
+	w.mu.Lock()
+	target.ReplyTo = from.Name
+	w.mu.Unlock()
+
+	from.Output.WriteLine(fmt.Sprintf("&RYou tell %s '%s'&w", CapitalizeName(target.Name), message))
+	target.Output.WriteLine(fmt.Sprintf("&R%s tells you '%s'&w", CapitalizeName(from.Name), message))
+	return target, nil
+}
+
+// Whisper sends a message to one player in the room; others only see that a whisper happened.
+func (w *World) Whisper(from *Player, target *Player, message string) error {
+	if err := w.CanSpeak(from); err != nil {
+		return err
+	}
+	if err := w.checkChannelRules("whisper", message); err != nil {
+		return err
+	}
+	if w.IsIgnoring(target, from) {
+		return fmt.Errorf("%s is ignoring you.", CapitalizeName(target.Name))
+	}
+
+	vars := ActVars{"message": message}
+	w.Act("You whisper to $N '$message'", PlayerSubject(from), PlayerSubject(target), vars, ToActor)
+	w.Act("$n whispers to you '$message'", PlayerSubject(from), PlayerSubject(target), vars, ToTarget)
+	w.Act("$n whispers something to $N.", PlayerSubject(from), PlayerSubject(target), nil, ToNotTarget)
+	return nil
+}
+
+// Yell sends a message to the speaker's room and every room adjacent to it.
+func (w *World) Yell(from *Player, message string) error {
+	if err := w.CanSpeak(from); err != nil {
+		return err
+	}
+	if err := w.checkChannelRules("yell", message); err != nil {
+		return err
+	}
+
+	w.mu.RLock()
+	nearby := map[int]bool{}
+	if room, ok := w.rooms[from.Location]; ok {
+		for _, vnum := range room.Exits {
+			nearby[vnum] = true
+		}
+	}
+	delete(nearby, from.Location)
+	here := make([]*Player, 0)
+	adjacent := make([]*Player, 0)
+	for _, player := range w.players {
+		if player == from || isIgnoring(player, from) {
+			continue
+		}
+		if player.Location == from.Location {
+			here = append(here, player)
+		} else if nearby[player.Location] {
+			adjacent = append(adjacent, player)
+		}
+	}
+	w.mu.RUnlock()
+
+	vars := ActVars{"message": message}
+	w.Act("You yell '$message'", PlayerSubject(from), Subject{}, vars, ToActor)
+	for _, player := range here {
+		player.Output.WriteLine(w.FormatAct("$n yells '$message'", player, PlayerSubject(from), Subject{}, vars))
+	}
+	for _, player := range adjacent {
+		player.Output.WriteLine(fmt.Sprintf("Someone nearby yells '%s'", message))
+	}
+	return nil
+}
+
+// Emote shows a free-form action to everyone in the speaker's room.
+func (w *World) Emote(from *Player, action string) error {
+	if err := w.checkChannelRules("emote", action); err != nil {
+		return err
+	}
+
+	vars := ActVars{"action": action}
+	w.Act("$n $action", PlayerSubject(from), Subject{}, vars, ToActor)
+	w.Act("$n $action", PlayerSubject(from), Subject{}, vars, ToRoom)
+	return nil
+}
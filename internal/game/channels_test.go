@@ -0,0 +1,161 @@
+package game
+
+import (
+	"testing"
+)
+
+func newChannelWorld(t *testing.T) (*World, *Player, *Player, *bufferOutput, *bufferOutput) {
+	t.Helper()
+
+	world := CreateDefaultWorld()
+	aliceOut := &bufferOutput{}
+	bobOut := &bufferOutput{}
+	alice := &Player{Name: "Alice", Output: aliceOut}
+	bob := &Player{Name: "Bob", Output: bobOut}
+	if err := world.AddPlayer(alice); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := world.AddPlayer(bob); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return world, alice, bob, aliceOut, bobOut
+}
+
+func TestBroadcastChannelRespectsToggleAndHistory(t *testing.T) {
+	world, alice, bob, _, bobOut := newChannelWorld(t)
+
+	if err := world.BroadcastChannel(alice, "ooc", "first"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bobOut.Contains("[OOC] Alice: first") {
+		t.Fatalf("expected bob to hear ooc message")
+	}
+
+	if err := world.SetChannel(bob, "ooc", false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	world.BroadcastChannel(alice, "ooc", "second")
+	if bobOut.Contains("second") {
+		t.Fatalf("expected muted channel to be silent")
+	}
+
+	history, err := world.ChannelHistory(bob, "ooc")
+	if err != nil || len(history) != 2 {
+		t.Fatalf("expected 2 history lines, got %v (%v)", history, err)
+	}
+}
+
+func TestKeeperChannelIsRestricted(t *testing.T) {
+	world, alice, bob, _, bobOut := newChannelWorld(t)
+
+	if err := world.BroadcastChannel(alice, "keeper", "hi"); err != ErrChannelDenied {
+		t.Fatalf("expected ErrChannelDenied, got %v", err)
+	}
+
+	alice.IsKeeper = true
+	if err := world.BroadcastChannel(alice, "keeper", "hi"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bobOut.Contains("hi") {
+		t.Fatalf("expected non-keeper not to hear keeper channel")
+	}
+	if _, err := world.ChannelHistory(bob, "keeper"); err != ErrChannelDenied {
+		t.Fatalf("expected history to be denied, got %v", err)
+	}
+}
+
+func TestTellSetsReplyAndIgnoreBlocks(t *testing.T) {
+	world, alice, bob, _, bobOut := newChannelWorld(t)
+
+	if _, err := world.SendTell(alice, "bob", "psst"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bobOut.Contains("Alice tells you 'psst'") {
+		t.Fatalf("expected tell to be delivered")
+	}
+	if bob.ReplyTo != "Alice" {
+		t.Fatalf("expected reply target Alice, got %q", bob.ReplyTo)
+	}
+
+	world.ToggleIgnore(bob, "alice")
+	if _, err := world.SendTell(alice, "bob", "again"); err == nil {
+		t.Fatalf("expected ignored tell to fail")
+	}
+	world.BroadcastChannel(alice, "chat", "loud")
+	if bobOut.Contains("loud") {
+		t.Fatalf("expected ignore to apply to channels")
+	}
+	history, _ := world.ChannelHistory(bob, "chat")
+	if len(history) != 0 {
+		t.Fatalf("expected ignored speaker to be hidden from history, got %v", history)
+	}
+}
+
+func TestSayRejectsOOCMarkers(t *testing.T) {
+	world, alice, _, _, bobOut := newChannelWorld(t)
+
+	if err := world.BroadcastSay(alice, "(( brb ))"); err != ErrOOCOnICChannel {
+		t.Fatalf("expected ErrOOCOnICChannel, got %v", err)
+	}
+	if bobOut.Contains("brb") {
+		t.Fatalf("expected OOC say to be blocked")
+	}
+}
+
+func TestICRulesFollowTheChannel(t *testing.T) {
+	world, alice, _, _, bobOut := newChannelWorld(t)
+
+	if err := world.BroadcastChannel(alice, "ooc", "(( brb ))"); err != nil {
+		t.Fatalf("expected OOC talk on ooc, got %v", err)
+	}
+	world.channels["chat"].IC = true
+	if err := world.BroadcastChannel(alice, "chat", "(( back ))"); err != ErrOOCOnICChannel {
+		t.Fatalf("expected an IC channel to refuse OOC talk, got %v", err)
+	}
+	if bobOut.Contains("back") {
+		t.Fatalf("expected OOC chat to be blocked")
+	}
+
+	world.channels["emote"].IC = false
+	if err := world.Emote(alice, "waves (( afk ))"); err != nil {
+		t.Fatalf("expected emote to follow its channel setting, got %v", err)
+	}
+	if err := world.BroadcastChannel(alice, "say", "hello"); err != ErrUnknownChannel {
+		t.Fatalf("expected room speech not to be a global channel, got %v", err)
+	}
+	for _, name := range world.ChannelNames(alice) {
+		if name == "say" {
+			t.Fatalf("expected say to be left out of the channel list")
+		}
+	}
+}
+
+func TestYellReachesAdjacentRooms(t *testing.T) {
+	rooms := map[int]*Room{
+		1: {Vnum: 1, Name: "One", Exits: map[string]int{"east": 2}},
+		2: {Vnum: 2, Name: "Two", Exits: map[string]int{"west": 1, "east": 3}},
+		3: {Vnum: 3, Name: "Three", Exits: map[string]int{"west": 2}},
+	}
+	world := CreateWorldFromRooms(rooms, 1)
+
+	nearOut := &bufferOutput{}
+	farOut := &bufferOutput{}
+	alice := &Player{Name: "Alice", Output: &bufferOutput{}}
+	near := &Player{Name: "Bob", Output: nearOut}
+	far := &Player{Name: "Carol", Output: farOut}
+	for _, p := range []*Player{alice, near, far} {
+		if err := world.AddPlayer(p); err != nil {
+			t.Fatalf("add player: %v", err)
+		}
+	}
+	near.Location = 2
+	far.Location = 3
+
+	world.Yell(alice, "help")
+	if !nearOut.Contains("Someone nearby yells 'help'") {
+		t.Fatalf("expected adjacent player to hear yell")
+	}
+	if farOut.Contains("help") {
+		t.Fatalf("expected distant player not to hear yell")
+	}
+}
@@ -0,0 +1,304 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ObjectTypeCorpse marks the remains of a dead player or mobile. Player
+// corpses record their owner.
+const ObjectTypeCorpse = "corpse"
+
+// DeathPolicy controls what dying costs and where the dead wake up. Zero
+// values disable the corresponding penalty; zero decay times keep corpses
+// indefinitely, though a mobile's corpse is gone after a reboot.
+type DeathPolicy struct {
+	RecallVnum      int           // room the dead wake up in; 0 uses the start room
+	GoldLossPercent int           // share of carried gold lost on death
+	ProficiencyLoss int           // proficiency points lost from each learned skill
+	CorpseDecay     time.Duration // how long a player's corpse lasts
+	MobCorpseDecay  time.Duration // how long a mobile's corpse lasts
+}
+
+// DefaultDeathPolicy applies until SetDeathPolicy is called.
+var DefaultDeathPolicy = DeathPolicy{
+	GoldLossPercent: 10,
+	CorpseDecay:     30 * time.Minute,
+	MobCorpseDecay:  5 * time.Minute,
+}
+
+// ErrNotYourCorpse is returned when looting another player's corpse.
+var ErrNotYourCorpse = errors.New("That isn't your corpse to loot.")
+
+// SetDeathPolicy replaces the world's death rules.
+func (w *World) SetDeathPolicy(policy DeathPolicy) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.death = policy
+}
+
+// newCorpse builds a corpse holding contents, found by "corpse" or any of the
+// dead character's keywords. owner is the normalized name of a dead player,
+// or empty for a mobile.
+func newCorpse(name string, keywords []string, owner string, contents []*Object, decay time.Duration) *Object {
+	corpse := &Object{
+		Keywords: append([]string{"corpse"}, keywords...),
+		Type:     ObjectTypeCorpse,
+		Short:    fmt.Sprintf("the corpse of %s", name),
+		Long:     fmt.Sprintf("The corpse of %s is lying here.", name),
+		Flags:    map[string]bool{},
+		Contents: contents,
+		Owner:    owner,
+	}
+	if decay > 0 {
+		corpse.DecayAt = time.Now().Add(decay)
+	}
+	return corpse
+}
+
+// IsCorpse reports whether obj is a corpse.
+func IsCorpse(obj *Object) bool {
+	return obj != nil && obj.Type == ObjectTypeCorpse
+}
+
+// PlayerCorpse is a player's corpse saved across a reboot, so the belongings
+// it holds aren't lost before their owner gets back to them.
+type PlayerCorpse struct {
+	Room   int           `json:"room"`
+	Corpse Object        `json:"corpse"`
+	Decay  time.Duration `json:"decay,omitempty"` // time left before it decays; 0 never
+}
+
+// PlayerCorpses returns copies of the player corpses lying in the world.
+func (w *World) PlayerCorpses() []PlayerCorpse {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	now := time.Now()
+	var corpses []PlayerCorpse
+	for vnum, room := range w.rooms {
+		for _, obj := range room.Objects {
+			if !IsCorpse(obj) || obj.Owner == "" {
+				continue
+			}
+			saved := PlayerCorpse{Room: vnum, Corpse: *obj}
+			saved.Corpse.Contents = append([]*Object(nil), obj.Contents...)
+			if !obj.DecayAt.IsZero() {
+				saved.Decay = max(obj.DecayAt.Sub(now), time.Second)
+			}
+			corpses = append(corpses, saved)
+		}
+	}
+	sort.Slice(corpses, func(i, j int) bool { return corpses[i].Room < corpses[j].Room })
+	return corpses
+}
+
+// RestorePlayerCorpses lays saved player corpses back where they fell, or in
+// the start room if that room is gone. Their decay picks up where it left
+// off.
+func (w *World) RestorePlayerCorpses(corpses []PlayerCorpse) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := time.Now()
+	for _, saved := range corpses {
+		room, ok := w.rooms[saved.Room]
+		if !ok {
+			if room, ok = w.rooms[w.start]; !ok {
+				continue
+			}
+		}
+		corpse := saved.Corpse
+		if corpse.Flags == nil {
+			corpse.Flags = map[string]bool{}
+		}
+		if saved.Decay > 0 {
+			corpse.DecayAt = now.Add(saved.Decay)
+		}
+		room.Objects = append(room.Objects, &corpse)
+	}
+}
+
+// killPlayer handles a player's death: their belongings are left in a corpse
+// where they fell, the death penalty is applied, and they wake up barely
+// alive in the recall room.
+func (w *World) killPlayer(p *Player) {
+	w.Act("&R$n is DEAD!!&w", PlayerSubject(p), Subject{}, nil, ToRoom)
+
+	w.mu.Lock()
+	policy := w.death
+
+	contents := append([]*Object(nil), p.Inventory...)
+	for _, slot := range EquipSlotOrder {
+		if obj := p.Equipment[slot]; obj != nil {
+			p.Armor -= obj.ArmorVal
+			contents = append(contents, obj)
+		}
+	}
+	p.Inventory = nil
+	p.Equipment = map[string]*Object{}
+
+	if room, ok := w.rooms[p.Location]; ok {
+		corpse := newCorpse(CapitalizeName(p.Name), []string{normalizeName(p.Name)}, normalizeName(p.Name), contents, policy.CorpseDecay)
+		room.Objects = append(room.Objects, corpse)
+	}
+
+	goldLost := 0
+	if policy.GoldLossPercent > 0 {
+		goldLost = p.Gold * policy.GoldLossPercent / 100
+		p.Gold -= goldLost
+		w.burnGold(LedgerDeath, goldLost)
+	}
+	if policy.ProficiencyLoss > 0 {
+		for _, progress := range p.Skills {
+			if progress == nil || !progress.Learned {
+				continue
+			}
+			progress.Proficiency -= policy.ProficiencyLoss
+			if progress.Proficiency < 1 {
+				progress.Proficiency = 1
+			}
+		}
+	}
+
+	recall := w.start
+	if _, ok := w.rooms[policy.RecallVnum]; ok {
+		recall = policy.RecallVnum
+	}
+	p.Location = recall
+	p.HP = 1
+	p.Position = PositionStanding
+	p.LastCombat = time.Time{}
+	p.Affects = nil
+
+	recallName := ""
+	if room, ok := w.rooms[recall]; ok {
+		recallName = room.Name
+	}
+	w.mu.Unlock()
+
+	p.Output.WriteLine("&RYou have been KILLED!&w")
+	if len(contents) > 0 {
+		p.Output.WriteLine("Your belongings lie with your corpse where you fell.")
+	}
+	if goldLost > 0 {
+		p.Output.WriteLine(fmt.Sprintf("You lose %d gold.", goldLost))
+	}
+	if policy.ProficiencyLoss > 0 {
+		p.Output.WriteLine("Your skills feel duller.")
+	}
+	if recallName != "" {
+		p.Output.WriteLine(fmt.Sprintf("You awaken in %s.", recallName))
+	}
+	w.Act("$n appears, looking badly shaken.", PlayerSubject(p), Subject{}, nil, ToRoom)
+}
+
+// TakeFromContainer moves the items matching arg out of container, which
+// must be in the player's room or inventory, into their inventory. Only the
+// owner (or a keeper) may loot a player's corpse.
+func (w *World) TakeFromContainer(p *Player, container *Object, arg string) ([]*Object, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.reachable(p, container) {
+		return nil, fmt.Errorf("You don't see that here.")
+	}
+	if IsCorpse(container) && container.Owner != "" && container.Owner != normalizeName(p.Name) && !p.IsKeeper {
+		return nil, ErrNotYourCorpse
+	}
+
+	taken := SelectObjects(container.Contents, ParseTarget(arg))
+	if len(taken) == 0 {
+		return nil, fmt.Errorf("There is nothing like that in %s.", container.Short)
+	}
+
+	remaining := make([]*Object, 0, len(container.Contents))
+	for _, obj := range container.Contents {
+		keep := true
+		for _, t := range taken {
+			if obj == t {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			remaining = append(remaining, obj)
+		}
+	}
+	container.Contents = remaining
+	p.Inventory = append(p.Inventory, taken...)
+	pocketMoney(p)
+	return taken, nil
+}
+
+// ContainerContents returns a copy of what obj holds.
+func (w *World) ContainerContents(obj *Object) []*Object {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return append([]*Object(nil), obj.Contents...)
+}
+
+// reachable reports whether obj lies in the player's room or inventory.
+// Callers hold w.mu.
+func (w *World) reachable(p *Player, obj *Object) bool {
+	for _, item := range p.Inventory {
+		if item == obj {
+			return true
+		}
+	}
+	room, ok := w.rooms[p.Location]
+	if !ok {
+		return false
+	}
+	for _, item := range room.Objects {
+		if item == obj {
+			return true
+		}
+	}
+	return false
+}
+
+// decayCorpses removes corpses whose time is up. Anything still inside
+// spills onto the floor.
+func (w *World) decayCorpses(now time.Time) {
+	type notice struct {
+		room int
+		line string
+	}
+	var notices []notice
+
+	w.mu.Lock()
+	for vnum, room := range w.rooms {
+		kept := room.Objects[:0]
+		var spilled []*Object
+		for _, obj := range room.Objects {
+			if !IsCorpse(obj) || obj.DecayAt.IsZero() || now.Before(obj.DecayAt) {
+				kept = append(kept, obj)
+				continue
+			}
+			spilled = append(spilled, obj.Contents...)
+			notices = append(notices, notice{vnum, capitalizeFirst(obj.Short) + " decays into dust."})
+		}
+		room.Objects = append(kept, spilled...)
+	}
+
+	type delivery struct {
+		player *Player
+		line   string
+	}
+	var deliveries []delivery
+	for _, n := range notices {
+		for _, p := range w.players {
+			if p.Location == n.room && !IsAsleep(p) {
+				deliveries = append(deliveries, delivery{p, n.line})
+			}
+		}
+	}
+	w.mu.Unlock()
+
+	for _, d := range deliveries {
+		d.player.Output.WriteLine(d.line)
+	}
+}
@@ -0,0 +1,168 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"njata/internal/skills"
+)
+
+func newDeathWorld(t *testing.T) (*World, *Player) {
+	t.Helper()
+
+	rooms := map[int]*Room{
+		1: {Vnum: 1, Name: "Temple", Sector: "inside", Flags: map[string]bool{}, Exits: map[string]int{}},
+		2: {Vnum: 2, Name: "Lair", Sector: "inside", Flags: map[string]bool{}, Exits: map[string]int{}},
+		3: {Vnum: 3, Name: "Shrine", Sector: "inside", Flags: map[string]bool{}, Exits: map[string]int{}},
+	}
+	world := CreateWorldFromRooms(rooms, 1)
+
+	sword := &Object{Keywords: []string{"sword"}, Short: "a sword", ArmorVal: 0}
+	helm := &Object{Keywords: []string{"helm"}, Short: "a helm", ArmorVal: 3}
+	player := &Player{
+		Name:      "alice",
+		Output:    &bufferOutput{},
+		Location:  2,
+		HP:        5,
+		MaxHP:     50,
+		Gold:      100,
+		Armor:     3,
+		Inventory: []*Object{sword},
+		Equipment: map[string]*Object{EquipHead: helm},
+		Skills:    map[int]*skills.PlayerSkillProgress{1001: {SpellID: 1001, Proficiency: 40, Learned: true}},
+	}
+	if err := world.AddPlayer(player); err != nil {
+		t.Fatalf("add player: %v", err)
+	}
+	return world, player
+}
+
+func TestKillPlayerLeavesCorpseAndRecalls(t *testing.T) {
+	world, player := newDeathWorld(t)
+	world.SetDeathPolicy(DeathPolicy{RecallVnum: 3, GoldLossPercent: 10, ProficiencyLoss: 5})
+
+	world.killPlayer(player)
+
+	if player.Location != 3 || player.HP != 1 {
+		t.Fatalf("expected player at recall with 1 HP, got room %d HP %d", player.Location, player.HP)
+	}
+	if len(player.Inventory) != 0 || len(player.Equipment) != 0 || player.Armor != 0 {
+		t.Fatalf("expected player stripped, inv %d eq %d armor %d", len(player.Inventory), len(player.Equipment), player.Armor)
+	}
+	if player.Gold != 90 {
+		t.Fatalf("expected 10%% gold loss, got %d", player.Gold)
+	}
+	if got := player.Skills[1001].Proficiency; got != 35 {
+		t.Fatalf("expected proficiency loss, got %d", got)
+	}
+
+	lair := world.rooms[2]
+	if len(lair.Objects) != 1 || !IsCorpse(lair.Objects[0]) {
+		t.Fatalf("expected a corpse in the lair, got %+v", lair.Objects)
+	}
+	if corpse := lair.Objects[0]; len(corpse.Contents) != 2 || corpse.Owner != "alice" {
+		t.Fatalf("expected corpse to hold alice's two items, got %+v", corpse)
+	}
+}
+
+func TestCorpseRetrievalRules(t *testing.T) {
+	world, alice := newDeathWorld(t)
+	world.killPlayer(alice)
+	corpse := world.rooms[2].Objects[0]
+
+	bob := &Player{Name: "bob", Output: &bufferOutput{}, Location: 2}
+	if err := world.AddPlayer(bob); err != nil {
+		t.Fatalf("add player: %v", err)
+	}
+	if _, err := world.TakeFromContainer(bob, corpse, "all"); !errors.Is(err, ErrNotYourCorpse) {
+		t.Fatalf("expected bob to be refused, got %v", err)
+	}
+
+	if _, err := world.TakeFromContainer(alice, corpse, "all"); err == nil {
+		t.Fatalf("expected alice to need to be in the room")
+	}
+
+	alice.Location = 2
+	taken, err := world.TakeFromContainer(alice, corpse, "all")
+	if err != nil || len(taken) != 2 || len(alice.Inventory) != 2 {
+		t.Fatalf("expected alice to recover both items, got %d (%v)", len(taken), err)
+	}
+}
+
+func TestMobileDeathLeavesLootInCorpse(t *testing.T) {
+	world, player := newDeathWorld(t)
+	world.SetPrototypes(nil, map[int]*Object{7: {Vnum: 7, Keywords: []string{"fang"}, Short: "a fang"}})
+	mob := &Mobile{Keywords: []string{"wolf"}, Short: "a wolf", HP: 5, MaxHP: 5, Loot: []LootEntry{{Vnum: 7, Count: 2}}}
+	world.rooms[2].Mobiles = []*Mobile{mob}
+
+	died, loot := world.DamageMob(player, mob, 10)
+	if !died || len(loot) != 2 {
+		t.Fatalf("expected wolf to die with two loot labels, died %v loot %v", died, loot)
+	}
+	if len(player.Inventory) != 1 {
+		t.Fatalf("expected loot to stay out of the inventory, got %d items", len(player.Inventory))
+	}
+
+	corpse, ok := world.FindObjectInRoom(player, "corpse")
+	if !ok || corpse.Owner != "" || len(corpse.Contents) != 2 {
+		t.Fatalf("expected wolf corpse with loot, got %+v", corpse)
+	}
+	if _, err := world.TakeFromContainer(player, corpse, "fang"); err != nil {
+		t.Fatalf("expected anyone to loot a mobile corpse: %v", err)
+	}
+}
+
+func TestCorpsesDecay(t *testing.T) {
+	world, player := newDeathWorld(t)
+	world.killPlayer(player)
+	corpse := world.rooms[2].Objects[0]
+	corpse.DecayAt = time.Now().Add(-time.Second)
+
+	world.UpdateTick()
+
+	objects := world.rooms[2].Objects
+	if len(objects) != 2 {
+		t.Fatalf("expected the corpse's contents to spill on decay, got %d objects", len(objects))
+	}
+	for _, obj := range objects {
+		if IsCorpse(obj) {
+			t.Fatalf("expected corpse to be gone")
+		}
+	}
+}
+
+func TestPlayerCorpsesSurviveAReboot(t *testing.T) {
+	world, player := newDeathWorld(t)
+	world.SetDeathPolicy(DeathPolicy{RecallVnum: 3, CorpseDecay: time.Hour})
+	world.killPlayer(player)
+	world.rooms[1].Objects = append(world.rooms[1].Objects, newCorpse("a rat", []string{"rat"}, "", nil, time.Minute))
+
+	data, err := json.Marshal(world.PlayerCorpses())
+	if err != nil {
+		t.Fatalf("marshal corpses: %v", err)
+	}
+	var saved []PlayerCorpse
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatalf("unmarshal corpses: %v", err)
+	}
+
+	restored, _ := newDeathWorld(t)
+	restored.RestorePlayerCorpses(saved)
+
+	if len(restored.rooms[1].Objects) != 0 {
+		t.Fatalf("expected mobile corpses to be left behind, got %+v", restored.rooms[1].Objects)
+	}
+	objects := restored.rooms[2].Objects
+	if len(objects) != 1 || !IsCorpse(objects[0]) || objects[0].Owner != "alice" {
+		t.Fatalf("expected alice's corpse back in the lair, got %+v", objects)
+	}
+	corpse := objects[0]
+	if len(corpse.Contents) != 2 {
+		t.Fatalf("expected the corpse to still hold alice's two items, got %d", len(corpse.Contents))
+	}
+	if left := time.Until(corpse.DecayAt); left <= 59*time.Minute || left > time.Hour {
+		t.Fatalf("expected the corpse's decay to carry over, %v left", left)
+	}
+}
@@ -0,0 +1,306 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+)
+
+// ObjectTypeMoney marks a pile of gold coins. Value[0] holds the amount;
+// picking one up adds it to the player's gold.
+const ObjectTypeMoney = "money"
+
+// Ledger sources: where gold comes into and goes out of the world.
+const (
+	LedgerMobDrops   = "mob drops"      // created: gold left on slain mobiles
+	LedgerShopSales  = "shop sales"     // created: shopkeepers paying players
+	LedgerShopBuys   = "shop purchases" // destroyed: players paying shopkeepers
+	LedgerDeath      = "death penalty"  // destroyed: gold lost on dying
+	LedgerAreaResets = "area resets"    // destroyed: gold left lying when an area resets
+)
+
+// ledgerDays is how many game days of history the ledger keeps.
+const ledgerDays = 30
+
+// Ledger records every creation and destruction of gold, so keepers can
+// watch the money supply grow.
+type Ledger struct {
+	Created   map[string]int `json:"created"`   // source -> gold created
+	Destroyed map[string]int `json:"destroyed"` // source -> gold destroyed
+	Days      []LedgerDay    `json:"days"`      // recent game days, oldest first
+}
+
+// LedgerDay totals one game day's gold flows.
+type LedgerDay struct {
+	Year      int `json:"year"`
+	Month     int `json:"month"`
+	Day       int `json:"day"`
+	Created   int `json:"created"`
+	Destroyed int `json:"destroyed"`
+}
+
+// TotalCreated sums the gold created from every source.
+func (l Ledger) TotalCreated() int {
+	total := 0
+	for _, amount := range l.Created {
+		total += amount
+	}
+	return total
+}
+
+// TotalDestroyed sums the gold destroyed by every sink.
+func (l Ledger) TotalDestroyed() int {
+	total := 0
+	for _, amount := range l.Destroyed {
+		total += amount
+	}
+	return total
+}
+
+// today returns the ledger's entry for the date on clock, starting a new one
+// (and dropping the oldest) when the day has changed.
+func (l *Ledger) today(clock GameTime) *LedgerDay {
+	if n := len(l.Days); n > 0 {
+		last := &l.Days[n-1]
+		if last.Year == clock.Year && last.Month == clock.Month && last.Day == clock.Day {
+			return last
+		}
+	}
+	l.Days = append(l.Days, LedgerDay{Year: clock.Year, Month: clock.Month, Day: clock.Day})
+	if len(l.Days) > ledgerDays {
+		l.Days = l.Days[len(l.Days)-ledgerDays:]
+	}
+	return &l.Days[len(l.Days)-1]
+}
+
+// clone returns a deep copy of the ledger.
+func (l Ledger) clone() Ledger {
+	c := Ledger{Created: map[string]int{}, Destroyed: map[string]int{}, Days: append([]LedgerDay(nil), l.Days...)}
+	for source, amount := range l.Created {
+		c.Created[source] = amount
+	}
+	for source, amount := range l.Destroyed {
+		c.Destroyed[source] = amount
+	}
+	return c
+}
+
+// mintGold records amount gold coming into the world. Callers hold w.mu.
+func (w *World) mintGold(source string, amount int) {
+	if amount <= 0 {
+		return
+	}
+	if w.ledger.Created == nil {
+		w.ledger.Created = map[string]int{}
+	}
+	w.ledger.Created[source] += amount
+	w.ledger.today(w.clock).Created += amount
+}
+
+// burnGold records amount gold leaving the world. Callers hold w.mu.
+func (w *World) burnGold(source string, amount int) {
+	if amount <= 0 {
+		return
+	}
+	if w.ledger.Destroyed == nil {
+		w.ledger.Destroyed = map[string]int{}
+	}
+	w.ledger.Destroyed[source] += amount
+	w.ledger.today(w.clock).Destroyed += amount
+}
+
+// Ledger returns a copy of the economy ledger.
+func (w *World) Ledger() Ledger {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.ledger.clone()
+}
+
+// SetLedger restores a saved economy ledger.
+func (w *World) SetLedger(l Ledger) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.ledger = l.clone()
+}
+
+// GoldHeld sums the gold carried and banked by players online.
+func (w *World) GoldHeld() (carried, banked int) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	for _, p := range w.players {
+		carried += p.Gold
+		banked += p.Bank
+	}
+	return carried, banked
+}
+
+// newGoldPile makes a pile of amount gold coins.
+func newGoldPile(amount int) *Object {
+	short := fmt.Sprintf("a pile of %d gold coins", amount)
+	long := "A pile of gold coins lies here."
+	if amount == 1 {
+		short, long = "a gold coin", "A single gold coin lies here."
+	}
+	return &Object{
+		Keywords: []string{"gold", "coins", "pile"},
+		Type:     ObjectTypeMoney,
+		Short:    short,
+		Long:     long,
+		Value:    [4]int{amount},
+		Flags:    map[string]bool{},
+	}
+}
+
+// IsMoney reports whether obj is a pile of gold.
+func IsMoney(obj *Object) bool {
+	return obj != nil && obj.Type == ObjectTypeMoney
+}
+
+// looseGold sums the gold in piles among objs, including inside containers.
+func looseGold(objs []*Object) int {
+	total := 0
+	for _, obj := range objs {
+		if IsMoney(obj) {
+			total += obj.Value[0]
+		}
+		total += looseGold(obj.Contents)
+	}
+	return total
+}
+
+// rollGold picks how much gold a slain mobile leaves.
+func rollGold(mob *Mobile) int {
+	if mob.GoldMax <= 0 {
+		return 0
+	}
+	low := max(mob.GoldMin, 0)
+	if mob.GoldMax <= low {
+		return mob.GoldMax
+	}
+	return low + rand.Intn(mob.GoldMax-low+1)
+}
+
+// pocketMoney turns any gold piles in the player's inventory into gold.
+// Callers hold w.mu.
+func pocketMoney(p *Player) {
+	kept := p.Inventory[:0]
+	for _, obj := range p.Inventory {
+		if IsMoney(obj) {
+			p.Gold += obj.Value[0]
+			continue
+		}
+		kept = append(kept, obj)
+	}
+	p.Inventory = kept
+}
+
+// GiveGold hands amount of the giver's gold to another player in the room.
+func (w *World) GiveGold(giver *Player, amount int, to *Player) error {
+	if IsAsleep(giver) {
+		return ErrAsleep
+	}
+	if amount <= 0 {
+		return errors.New("You can't give less than one coin.")
+	}
+
+	w.mu.Lock()
+	if to == giver || to.Location != giver.Location {
+		w.mu.Unlock()
+		return errors.New("They aren't here.")
+	}
+	if giver.Gold < amount {
+		w.mu.Unlock()
+		return errors.New("You haven't got that much gold.")
+	}
+	giver.Gold -= amount
+	to.Gold += amount
+	w.mu.Unlock()
+
+	self, them := PlayerSubject(giver), PlayerSubject(to)
+	vars := ActVars{"amount": fmt.Sprint(amount)}
+	w.Act("You give $N $amount gold.", self, them, vars, ToActor)
+	w.Act("$n gives you $amount gold.", self, them, vars, ToTarget)
+	w.Act("$n gives $N some gold.", self, them, nil, ToNotTarget)
+	return nil
+}
+
+// ErrNoBank is returned when there is no banker in the room.
+var ErrNoBank = errors.New("You can't do that here.")
+
+// findBanker returns the banker in the player's room. Callers hold w.mu.
+func (w *World) findBanker(p *Player) (*Mobile, error) {
+	if IsAsleep(p) {
+		return nil, ErrAsleep
+	}
+	room, ok := w.rooms[p.Location]
+	if !ok {
+		return nil, ErrNoBank
+	}
+	for _, mob := range room.Mobiles {
+		if mob.IsBanker && mobAwake(mob) && !mobInCombat(mob) {
+			return mob, nil
+		}
+	}
+	return nil, ErrNoBank
+}
+
+// Deposit moves amount of the player's gold into their bank account.
+func (w *World) Deposit(p *Player, amount int) error {
+	return w.bankTransfer(p, amount, true)
+}
+
+// Withdraw moves amount of gold out of the player's bank account.
+func (w *World) Withdraw(p *Player, amount int) error {
+	return w.bankTransfer(p, amount, false)
+}
+
+func (w *World) bankTransfer(p *Player, amount int, deposit bool) error {
+	if amount <= 0 {
+		return errors.New("How much?")
+	}
+
+	w.mu.Lock()
+	banker, err := w.findBanker(p)
+	if err != nil {
+		w.mu.Unlock()
+		return err
+	}
+	if deposit && p.Gold < amount {
+		w.mu.Unlock()
+		return errors.New("You haven't got that much gold.")
+	}
+	if !deposit && p.Bank < amount {
+		w.mu.Unlock()
+		return w.keeperSays(p, banker, "You don't have that much in your account.")
+	}
+	if deposit {
+		p.Gold -= amount
+		p.Bank += amount
+	} else {
+		p.Bank -= amount
+		p.Gold += amount
+	}
+	balance := p.Bank
+	w.mu.Unlock()
+
+	self, them := PlayerSubject(p), MobileSubject(banker)
+	vars := ActVars{"amount": fmt.Sprint(amount), "balance": fmt.Sprint(balance)}
+	if deposit {
+		w.Act("You deposit $amount gold. Your balance is $balance gold.", self, them, vars, ToActor)
+		w.Act("$n deposits some gold with $N.", self, them, vars, ToRoom)
+	} else {
+		w.Act("You withdraw $amount gold. Your balance is $balance gold.", self, them, vars, ToActor)
+		w.Act("$n withdraws some gold from $N.", self, them, vars, ToRoom)
+	}
+	return nil
+}
+
+// Balance returns the player's bank balance, if there is a banker to ask.
+func (w *World) Balance(p *Player) (int, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if _, err := w.findBanker(p); err != nil {
+		return 0, err
+	}
+	return p.Bank, nil
+}
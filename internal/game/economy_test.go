@@ -0,0 +1,112 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSlainMobDropsGoldIntoCorpse(t *testing.T) {
+	world, player := newMobAIWorld(t)
+	mob := placeMob(world, 1, &Mobile{Short: "a merchant", Keywords: []string{"merchant"}, GoldMin: 12, GoldMax: 12})
+
+	died, loot := world.DamageMob(player, mob, 100)
+	if !died || len(loot) != 1 || loot[0] != "a pile of 12 gold coins" {
+		t.Fatalf("expected a gold pile in the loot, got %v", loot)
+	}
+	if ledger := world.Ledger(); ledger.Created[LedgerMobDrops] != 12 || len(ledger.Days) != 1 {
+		t.Fatalf("expected the drop to be recorded, got %+v", ledger)
+	}
+
+	corpse, ok := world.FindObjectInRoom(player, "corpse")
+	if !ok {
+		t.Fatalf("expected a corpse")
+	}
+	if _, err := world.TakeFromContainer(player, corpse, "gold"); err != nil {
+		t.Fatalf("take gold: %v", err)
+	}
+	if player.Gold != 12 || len(player.Inventory) != 0 {
+		t.Fatalf("expected the coins to be pocketed, gold %d inventory %d", player.Gold, len(player.Inventory))
+	}
+}
+
+func TestAreaResetBurnsScavengedGold(t *testing.T) {
+	world, _ := newMobAIWorld(t)
+	mob := placeMob(world, 2, &Mobile{Short: "a magpie", Keywords: []string{"magpie"}})
+	mob.Inventory = []*Object{newGoldPile(7)}
+
+	world.mu.Lock()
+	world.areaLastRespawn["Woods"] = time.Now().Add(-2 * time.Hour)
+	world.mu.Unlock()
+	world.RespawnTick(60, nil)
+
+	if ledger := world.Ledger(); ledger.Destroyed[LedgerAreaResets] != 7 {
+		t.Fatalf("expected the magpie's gold to be burned, got %+v", ledger)
+	}
+}
+
+func TestGiveGold(t *testing.T) {
+	world, player := newMobAIWorld(t)
+	bob := &Player{Name: "bob", Output: &bufferOutput{}, Location: 1}
+	if err := world.AddPlayer(bob); err != nil {
+		t.Fatalf("add player: %v", err)
+	}
+	player.Gold = 30
+
+	if err := world.GiveGold(player, 50, bob); err == nil {
+		t.Fatalf("expected to be unable to give more than carried")
+	}
+	if err := world.GiveGold(player, 20, bob); err != nil {
+		t.Fatalf("give gold: %v", err)
+	}
+	if player.Gold != 10 || bob.Gold != 20 || !bob.Output.(*bufferOutput).Contains("Alice gives you 20 gold.") {
+		t.Fatalf("expected 20 gold to change hands, alice %d bob %d", player.Gold, bob.Gold)
+	}
+}
+
+func TestBankNeedsBanker(t *testing.T) {
+	world, player := newMobAIWorld(t)
+	player.Gold = 100
+
+	if err := world.Deposit(player, 50); err != ErrNoBank {
+		t.Fatalf("expected no bank here, got %v", err)
+	}
+
+	placeMob(world, 1, &Mobile{Short: "a bank teller", IsBanker: true})
+	if err := world.Deposit(player, 80); err != nil {
+		t.Fatalf("deposit: %v", err)
+	}
+	if err := world.Withdraw(player, 100); err == nil {
+		t.Fatalf("expected to be unable to overdraw")
+	}
+	if err := world.Withdraw(player, 30); err != nil {
+		t.Fatalf("withdraw: %v", err)
+	}
+	if balance, err := world.Balance(player); err != nil || balance != 50 || player.Gold != 50 {
+		t.Fatalf("expected 50 banked and 50 on hand, got %d and %d (%v)", balance, player.Gold, err)
+	}
+	if ledger := world.Ledger(); ledger.TotalCreated() != 0 || ledger.TotalDestroyed() != 0 {
+		t.Fatalf("expected banking not to create or destroy gold, got %+v", ledger)
+	}
+}
+
+func TestLedgerTracksSinksByDay(t *testing.T) {
+	world, player, _ := newShopWorld(t)
+
+	if err := world.BuyObject(player, "sword", 2); err != nil {
+		t.Fatalf("buy: %v", err)
+	}
+	world.mu.Lock()
+	world.clock.Day++
+	world.mu.Unlock()
+	if err := world.SellObject(player, player.Inventory[0]); err != nil {
+		t.Fatalf("sell: %v", err)
+	}
+
+	ledger := world.Ledger()
+	if ledger.Destroyed[LedgerShopBuys] != 240 || ledger.Created[LedgerShopSales] != 90 {
+		t.Fatalf("unexpected ledger %+v", ledger)
+	}
+	if len(ledger.Days) != 2 || ledger.Days[0].Destroyed != 240 || ledger.Days[1].Created != 90 {
+		t.Fatalf("expected one entry per day, got %+v", ledger.Days)
+	}
+}
@@ -0,0 +1,260 @@
+package game
+
+import "fmt"
+
+// Calendar dimensions, following legacy/system/time.dat.
+const (
+	HoursPerDay      = 24
+	DaysPerWeek      = 7
+	DaysPerMonth     = 35
+	MonthsPerYear    = 17
+	daysPerMoonPhase = 4
+)
+
+// pulsesPerHour is how many world updates make one game hour.
+const pulsesPerHour = 4
+
+var weekdayNames = [DaysPerWeek]string{
+	"the Moon", "the Bull", "Deception", "Thunder", "Freedom", "the Great Gods", "the Sun",
+}
+
+var monthNames = [MonthsPerYear]string{
+	"Winter", "the Winter Wolf", "the Frost Giant", "the Old Forces",
+	"the Grand Struggle", "the Spring", "Nature", "Futility", "the Dragon",
+	"the Sun", "the Heat", "the Battle", "the Dark Shades", "the Shadows",
+	"the Long Shadows", "the Ancient Darkness", "the Great Evil",
+}
+
+// GameTime is a moment on the world calendar. Day and Month count from zero.
+type GameTime struct {
+	Hour  int `json:"hour"`
+	Day   int `json:"day"`
+	Month int `json:"month"`
+	Year  int `json:"year"`
+}
+
+// defaultClock is the calendar date recorded in legacy/system/time.dat. A
+// fresh world starts at noon on that day.
+var defaultClock = GameTime{Hour: 12, Day: 11, Month: 1, Year: 635}
+
+// advance moves the clock forward one hour, rolling over days, months and years.
+func (t *GameTime) advance() {
+	t.Hour++
+	if t.Hour < HoursPerDay {
+		return
+	}
+	t.Hour = 0
+	t.Day++
+	if t.Day < DaysPerMonth {
+		return
+	}
+	t.Day = 0
+	t.Month++
+	if t.Month < MonthsPerYear {
+		return
+	}
+	t.Month = 0
+	t.Year++
+}
+
+// dayNumber counts days since the start of year zero.
+func (t GameTime) dayNumber() int {
+	return (t.Year*MonthsPerYear+t.Month)*DaysPerMonth + t.Day
+}
+
+// Weekday names the day of the week, e.g. "the Moon".
+func (t GameTime) Weekday() string {
+	return weekdayNames[t.dayNumber()%DaysPerWeek]
+}
+
+// MonthName names the month, e.g. "the Winter Wolf".
+func (t GameTime) MonthName() string {
+	return monthNames[t.Month%MonthsPerYear]
+}
+
+// String renders the date the way the time command shows it.
+func (t GameTime) String() string {
+	hour := t.Hour % 12
+	if hour == 0 {
+		hour = 12
+	}
+	meridiem := "am"
+	if t.Hour >= 12 {
+		meridiem = "pm"
+	}
+	return fmt.Sprintf("It is %d o'clock %s, Day of %s, %s the Month of %s, year %d.",
+		hour, meridiem, t.Weekday(), ordinal(t.Day+1), t.MonthName(), t.Year)
+}
+
+// ordinal renders n as "1st", "2nd", "11th" and so on.
+func ordinal(n int) string {
+	suffix := "th"
+	if n%100 < 11 || n%100 > 13 {
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
+
+// MoonPhase is the moon's phase on a given night.
+type MoonPhase int
+
+const (
+	MoonNew MoonPhase = iota
+	MoonWaxingCrescent
+	MoonFirstQuarter
+	MoonWaxingGibbous
+	MoonFull
+	MoonWaningGibbous
+	MoonLastQuarter
+	MoonWaningCrescent
+	moonPhaseCount
+)
+
+var moonPhaseNames = [moonPhaseCount]string{
+	"new", "waxing crescent", "first quarter", "waxing gibbous",
+	"full", "waning gibbous", "last quarter", "waning crescent",
+}
+
+func (m MoonPhase) String() string {
+	if m < 0 || m >= moonPhaseCount {
+		return "unknown"
+	}
+	return moonPhaseNames[m]
+}
+
+// MoonPhase returns the phase of the moon on this day.
+func (t GameTime) MoonPhase() MoonPhase {
+	return MoonPhase(t.dayNumber() / daysPerMoonPhase % int(moonPhaseCount))
+}
+
+// SunState is the position of the sun.
+type SunState int
+
+const (
+	SunDark SunState = iota
+	SunRise
+	SunLight
+	SunSet
+)
+
+// Sun returns the position of the sun at this hour.
+func (t GameTime) Sun() SunState {
+	switch {
+	case t.Hour == 5:
+		return SunRise
+	case t.Hour >= 6 && t.Hour < 19:
+		return SunLight
+	case t.Hour == 19:
+		return SunSet
+	}
+	return SunDark
+}
+
+// IsNight reports whether outdoor rooms are dark at this hour.
+func (t GameTime) IsNight() bool {
+	sun := t.Sun()
+	return sun == SunDark || sun == SunSet
+}
+
+// sunMessages announce the sun's progress to players outdoors.
+var sunMessages = map[int]string{
+	5:  "&YThe day has begun.&w",
+	6:  "&YThe sun rises in the east.&w",
+	19: "&OThe sun slowly disappears in the west.&w",
+	20: "&zThe night has begun.&w",
+}
+
+// moonriseHour is when the moon comes up; a full moon is announced.
+const moonriseHour = 20
+
+// Clock returns the current game time.
+func (w *World) Clock() GameTime {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.clock
+}
+
+// SetClock sets the game time, e.g. when restoring it after a reboot.
+func (w *World) SetClock(t GameTime) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.clock = t
+	w.night = t.IsNight()
+}
+
+// isOutdoors reports whether a room is open to the sky.
+func isOutdoors(room *Room) bool {
+	if room.Flags["indoors"] {
+		return false
+	}
+	switch room.Sector {
+	case "inside", "underground", SectorUnderwater:
+		return false
+	}
+	return true
+}
+
+// IsOutdoors reports whether the room at vnum is open to the sky.
+func (w *World) IsOutdoors(vnum int) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	room, ok := w.rooms[vnum]
+	return ok && isOutdoors(room)
+}
+
+// advanceHour moves the clock on an hour, updates every area's weather and
+// tells players outdoors about the sun and sky.
+func (w *World) advanceHour() {
+	type delivery struct {
+		player *Player
+		lines  []string
+	}
+	var deliveries []delivery
+
+	w.mu.Lock()
+	w.clock.advance()
+	w.night = w.clock.IsNight()
+	var skyLines []string
+	if msg, ok := sunMessages[w.clock.Hour]; ok {
+		skyLines = append(skyLines, msg)
+	}
+	if w.clock.Hour == moonriseHour && w.clock.MoonPhase() == MoonFull {
+		skyLines = append(skyLines, "&WA full moon rises, bright and cold.&w")
+	}
+
+	weatherMessages := map[string]string{}
+	for area, wx := range w.weather {
+		if msg := wx.evolve(w.clock.Month); msg != "" {
+			weatherMessages[area] = msg
+		}
+	}
+
+	for _, p := range w.players {
+		room, ok := w.rooms[p.Location]
+		if !ok || !isOutdoors(room) || IsAsleep(p) {
+			continue
+		}
+		lines := append([]string(nil), skyLines...)
+		if msg := weatherMessages[room.AreaName]; msg != "" {
+			lines = append(lines, msg)
+		}
+		if len(lines) > 0 {
+			deliveries = append(deliveries, delivery{p, lines})
+		}
+	}
+	w.mu.Unlock()
+
+	for _, d := range deliveries {
+		for _, line := range d.lines {
+			d.player.Output.WriteLine(line)
+		}
+	}
+}
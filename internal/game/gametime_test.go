@@ -0,0 +1,105 @@
+package game
+
+import "testing"
+
+func TestGameTimeAdvanceRollsOver(t *testing.T) {
+	clock := GameTime{Hour: HoursPerDay - 1, Day: DaysPerMonth - 1, Month: MonthsPerYear - 1, Year: 635}
+	clock.advance()
+
+	if clock != (GameTime{Hour: 0, Day: 0, Month: 0, Year: 636}) {
+		t.Fatalf("expected new year, got %+v", clock)
+	}
+}
+
+func TestGameTimeSunAndMoon(t *testing.T) {
+	cases := []struct {
+		hour  int
+		sun   SunState
+		night bool
+	}{
+		{3, SunDark, true},
+		{5, SunRise, false},
+		{12, SunLight, false},
+		{19, SunSet, true},
+		{22, SunDark, true},
+	}
+	for _, tc := range cases {
+		clock := GameTime{Hour: tc.hour}
+		if clock.Sun() != tc.sun || clock.IsNight() != tc.night {
+			t.Fatalf("hour %d: got sun %v night %v", tc.hour, clock.Sun(), clock.IsNight())
+		}
+	}
+
+	if phase := (GameTime{Day: 0}).MoonPhase(); phase != MoonNew {
+		t.Fatalf("expected new moon on day 0, got %s", phase)
+	}
+	if phase := (GameTime{Day: 4 * daysPerMoonPhase}).MoonPhase(); phase != MoonFull {
+		t.Fatalf("expected full moon, got %s", phase)
+	}
+}
+
+func TestGameTimeString(t *testing.T) {
+	clock := GameTime{Hour: 13, Day: 0, Month: 1, Year: 635}
+	want := "It is 1 o'clock pm, Day of " + clock.Weekday() + ", 1st the Month of the Winter Wolf, year 635."
+	if got := clock.String(); got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestSunsetReachesOutdoorPlayersAndDarkens(t *testing.T) {
+	rooms := map[int]*Room{
+		1: {Vnum: 1, Name: "Meadow", Sector: "field", AreaName: "vale", Flags: map[string]bool{}, Exits: map[string]int{}},
+		2: {Vnum: 2, Name: "Inn", Sector: "inside", AreaName: "vale", Flags: map[string]bool{}, Exits: map[string]int{}},
+	}
+	world := CreateWorldFromRooms(rooms, 1)
+	world.SetClock(GameTime{Hour: 19})
+
+	outside := &Player{Name: "alice", Output: &bufferOutput{}, Location: 1}
+	inside := &Player{Name: "bob", Output: &bufferOutput{}, Location: 2}
+	for _, p := range []*Player{outside, inside} {
+		if err := world.AddPlayer(p); err != nil {
+			t.Fatalf("add player: %v", err)
+		}
+	}
+
+	for i := 0; i < pulsesPerHour; i++ {
+		world.UpdateTick()
+	}
+
+	if world.Clock().Hour != 20 || !world.IsNight() {
+		t.Fatalf("expected night at hour 20, got %+v", world.Clock())
+	}
+	if !outside.Output.(*bufferOutput).Contains("The night has begun.") {
+		t.Fatalf("expected outdoor player to see nightfall")
+	}
+	if inside.Output.(*bufferOutput).Contains("The night has begun.") {
+		t.Fatalf("expected indoor player not to see nightfall")
+	}
+}
+
+func TestWeatherEvolvesWithPressure(t *testing.T) {
+	wx := &Weather{Sky: SkyCloudy, Pressure: minPressure, Change: -maxChange}
+	if msg := wx.evolve(0); msg == "" || wx.Sky != SkyRaining {
+		t.Fatalf("expected low pressure to bring rain, got %q sky %s", msg, wx.Sky)
+	}
+
+	wx = &Weather{Sky: SkyLightning, Pressure: maxPressure, Change: maxChange}
+	if msg := wx.evolve(0); msg == "" || wx.Sky != SkyRaining {
+		t.Fatalf("expected high pressure to end the storm, got %q sky %s", msg, wx.Sky)
+	}
+}
+
+func TestRoomWeatherIndoors(t *testing.T) {
+	rooms := map[int]*Room{
+		1: {Vnum: 1, Name: "Meadow", Sector: "field", AreaName: "vale", Flags: map[string]bool{}},
+		2: {Vnum: 2, Name: "Cellar", Sector: "field", AreaName: "vale", Flags: map[string]bool{"indoors": true}},
+	}
+	world := CreateWorldFromRooms(rooms, 1)
+
+	if _, ok := world.RoomWeather(1); !ok {
+		t.Fatalf("expected weather outdoors")
+	}
+	if _, ok := world.RoomWeather(2); ok {
+		t.Fatalf("expected no weather indoors")
+	}
+}
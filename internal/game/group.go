@@ -0,0 +1,427 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Loot rules a group leader can choose between.
+const (
+	LootFree       = "free"       // whoever loots the corpse keeps what they take
+	LootSplit      = "split"      // gold from kills is shared among members present
+	LootRoundRobin = "roundrobin" // gold is shared and items are handed out in turn
+)
+
+// LootRules lists the loot rules in the order they are shown to players.
+var LootRules = []string{LootFree, LootSplit, LootRoundRobin}
+
+// notice is a line for one player, sent once w.mu has been released.
+type notice struct {
+	to   *Player
+	line string
+}
+
+func sendNotices(notices []notice) {
+	for _, n := range notices {
+		n.to.Output.WriteLine(n.line)
+	}
+}
+
+// groupLeader returns the leader of the player's group, or the player
+// themselves if they aren't in one.
+func groupLeader(p *Player) *Player {
+	if p.Leader != nil {
+		return p.Leader
+	}
+	return p
+}
+
+// SameGroup reports whether a and b adventure together.
+func SameGroup(a, b *Player) bool {
+	return a != nil && b != nil && (a == b || a.Leader != nil && a.Leader == b.Leader)
+}
+
+// groupMembers returns everyone in the player's group, leader first, or just
+// the player if they aren't grouped. Callers hold w.mu.
+func (w *World) groupMembers(p *Player) []*Player {
+	if p.Leader == nil {
+		return []*Player{p}
+	}
+	leader := p.Leader
+	members := []*Player{leader}
+	for _, other := range w.players {
+		if other != leader && other.Leader == leader {
+			members = append(members, other)
+		}
+	}
+	sort.Slice(members[1:], func(i, j int) bool { return members[i+1].Name < members[j+1].Name })
+	return members
+}
+
+// groupHere returns the members of the player's group in their room, leader
+// first. Callers hold w.mu.
+func (w *World) groupHere(p *Player) []*Player {
+	var here []*Player
+	for _, member := range w.groupMembers(p) {
+		if member.Location == p.Location && member.HP > 0 {
+			here = append(here, member)
+		}
+	}
+	return here
+}
+
+// GroupMembers returns everyone in the player's group, leader first.
+func (w *World) GroupMembers(p *Player) []*Player {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.groupMembers(p)
+}
+
+// leaveGroup takes the player out of their group, disbanding it if they led
+// it or were its last member. It returns notices for those affected.
+// Callers hold w.mu.
+func (w *World) leaveGroup(p *Player) []notice {
+	if p.Leader == nil {
+		return nil
+	}
+	leader := p.Leader
+	var notices []notice
+	if leader == p {
+		for _, member := range w.groupMembers(p)[1:] {
+			member.Leader = nil
+			notices = append(notices, notice{member, fmt.Sprintf("%s has disbanded the group.", CapitalizeName(p.Name))})
+		}
+		p.Leader = nil
+		return notices
+	}
+
+	p.Leader = nil
+	for _, member := range w.groupMembers(leader) {
+		notices = append(notices, notice{member, fmt.Sprintf("%s has left the group.", CapitalizeName(p.Name))})
+	}
+	if len(w.groupMembers(leader)) == 1 {
+		leader.Leader = nil
+	}
+	return notices
+}
+
+// stopFollowing ends the player following anyone, taking them out of their
+// leader's group. Callers hold w.mu.
+func (w *World) stopFollowing(p *Player) []notice {
+	if p.Master == nil {
+		return nil
+	}
+	master := p.Master
+	p.Master = nil
+	notices := []notice{
+		{p, fmt.Sprintf("You stop following %s.", CapitalizeName(master.Name))},
+		{master, fmt.Sprintf("%s stops following you.", CapitalizeName(p.Name))},
+	}
+	if p.Leader != nil && p.Leader != p {
+		notices = append(notices, w.leaveGroup(p)...)
+	}
+	return notices
+}
+
+// dropGroupLinks clears everything tying the player to others before they
+// leave the game. Callers hold w.mu.
+func (w *World) dropGroupLinks(p *Player) []notice {
+	notices := w.stopFollowing(p)
+	notices = append(notices, w.leaveGroup(p)...)
+	for _, other := range w.players {
+		if other.Master == p {
+			notices = append(notices, w.stopFollowing(other)...)
+		}
+	}
+	return notices
+}
+
+// Follow starts the player following target, or stops them following anyone
+// when target is themselves.
+func (w *World) Follow(p, target *Player) error {
+	w.mu.Lock()
+	if target == p {
+		if p.Master == nil {
+			w.mu.Unlock()
+			return errors.New("You aren't following anyone.")
+		}
+		notices := w.stopFollowing(p)
+		w.mu.Unlock()
+		sendNotices(notices)
+		return nil
+	}
+	if p.Master == target {
+		w.mu.Unlock()
+		return fmt.Errorf("You are already following %s.", CapitalizeName(target.Name))
+	}
+	for leader := target; leader != nil; leader = leader.Master {
+		if leader == p {
+			w.mu.Unlock()
+			return errors.New("Following in loops is not allowed.")
+		}
+	}
+	notices := w.stopFollowing(p)
+	p.Master = target
+	w.mu.Unlock()
+
+	sendNotices(notices)
+	w.Act("You now follow $N.", PlayerSubject(p), PlayerSubject(target), nil, ToActor)
+	w.Act("$n now follows you.", PlayerSubject(p), PlayerSubject(target), nil, ToTarget)
+	return nil
+}
+
+// GroupToggle adds a follower to the leader's group, or removes them if
+// they are already in it.
+func (w *World) GroupToggle(leader, target *Player) error {
+	w.mu.Lock()
+	if leader.Leader != nil && leader.Leader != leader {
+		w.mu.Unlock()
+		return errors.New("You aren't the leader of your group.")
+	}
+	if target == leader {
+		w.mu.Unlock()
+		return errors.New("You can't group yourself.")
+	}
+
+	if target.Leader == leader {
+		notices := w.leaveGroup(target)
+		w.mu.Unlock()
+		sendNotices(notices)
+		w.Act("You remove $N from your group.", PlayerSubject(leader), PlayerSubject(target), nil, ToActor)
+		w.Act("$n removes you from $s group.", PlayerSubject(leader), PlayerSubject(target), nil, ToTarget)
+		return nil
+	}
+
+	if target.Master != leader {
+		w.mu.Unlock()
+		return fmt.Errorf("%s isn't following you.", CapitalizeName(target.Name))
+	}
+	if target.Leader != nil {
+		w.mu.Unlock()
+		return fmt.Errorf("%s is already in a group.", CapitalizeName(target.Name))
+	}
+	leader.Leader = leader
+	target.Leader = leader
+	w.mu.Unlock()
+
+	self, them := PlayerSubject(leader), PlayerSubject(target)
+	w.Act("$N joins your group.", self, them, nil, ToActor)
+	w.Act("You join $n's group.", self, them, nil, ToTarget)
+	w.Act("$N joins $n's group.", self, them, nil, ToNotTarget)
+	return nil
+}
+
+// Ungroup takes target out of the leader's group, or, with no target, takes
+// the player out of their group (disbanding it if they lead it).
+func (w *World) Ungroup(p, target *Player) error {
+	if target != nil {
+		w.mu.RLock()
+		member := target.Leader == p && target != p
+		w.mu.RUnlock()
+		if !member {
+			return fmt.Errorf("%s isn't in your group.", CapitalizeName(target.Name))
+		}
+		return w.GroupToggle(p, target)
+	}
+
+	w.mu.Lock()
+	if p.Leader == nil {
+		w.mu.Unlock()
+		return errors.New("You aren't in a group.")
+	}
+	leading := p.Leader == p
+	notices := w.leaveGroup(p)
+	w.mu.Unlock()
+
+	sendNotices(notices)
+	if leading {
+		p.Output.WriteLine("You disband your group.")
+	} else {
+		p.Output.WriteLine("You leave the group.")
+	}
+	return nil
+}
+
+// SetLootRule changes how the player's group shares loot.
+func (w *World) SetLootRule(p *Player, rule string) error {
+	rule = strings.ToLower(rule)
+	valid := false
+	for _, r := range LootRules {
+		valid = valid || r == rule
+	}
+	if !valid {
+		return fmt.Errorf("Loot rules are: %s.", strings.Join(LootRules, ", "))
+	}
+
+	w.mu.Lock()
+	if p.Leader != nil && p.Leader != p {
+		w.mu.Unlock()
+		return errors.New("Only the group leader can set the loot rules.")
+	}
+	p.LootRule = rule
+	members := w.groupMembers(p)
+	w.mu.Unlock()
+
+	for _, member := range members {
+		member.Output.WriteLine(fmt.Sprintf("The group's loot rule is now %s.", rule))
+	}
+	return nil
+}
+
+// LootRule returns the loot rule of the player's group.
+func (w *World) LootRule(p *Player) string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return lootRule(p)
+}
+
+// lootRule returns the loot rule of the player's group. Callers hold w.mu.
+func lootRule(p *Player) string {
+	if rule := groupLeader(p).LootRule; rule != "" {
+		return rule
+	}
+	return LootFree
+}
+
+// GroupTell sends a message to every member of the player's group.
+func (w *World) GroupTell(p *Player, message string) error {
+	if err := w.CanSpeak(p); err != nil {
+		return err
+	}
+
+	w.mu.RLock()
+	if p.Leader == nil {
+		w.mu.RUnlock()
+		return errors.New("You aren't in a group.")
+	}
+	var listeners []*Player
+	for _, member := range w.groupMembers(p) {
+		if member != p && !isIgnoring(member, p) {
+			listeners = append(listeners, member)
+		}
+	}
+	w.mu.RUnlock()
+
+	name := CapitalizeName(p.Name)
+	p.Output.WriteLine(fmt.Sprintf("&CYou tell the group '%s'&w", message))
+	for _, member := range listeners {
+		member.Output.WriteLine(fmt.Sprintf("&C%s tells the group '%s'&w", name, message))
+	}
+	return nil
+}
+
+// followLeader moves the leader's followers in room from after them through
+// direction, returning everyone who came along (followers of followers
+// included).
+func (w *World) followLeader(leader *Player, from int, direction string) []*Player {
+	w.mu.RLock()
+	var followers []*Player
+	for _, other := range w.players {
+		if other.Master == leader && other.Location == from && !other.LinkDead {
+			followers = append(followers, other)
+		}
+	}
+	w.mu.RUnlock()
+	sort.Slice(followers, func(i, j int) bool { return followers[i].Name < followers[j].Name })
+
+	var moved []*Player
+	for _, f := range followers {
+		w.Act("You follow $N.", PlayerSubject(f), PlayerSubject(leader), nil, ToActor)
+		view, err := w.MovePlayer(f, direction)
+		if err != nil {
+			f.Output.WriteLine(err.Error())
+			continue
+		}
+		moved = append(moved, f)
+		moved = append(moved, view.Followers...)
+	}
+	return moved
+}
+
+// shareLoot hands out what a slain mobile leaves according to the killer's
+// group loot rule, returning whatever is left for the corpse. Callers hold
+// w.mu.
+func (w *World) shareLoot(killer *Player, contents []*Object, gold int) ([]*Object, []notice) {
+	members := w.groupHere(killer)
+	rule := lootRule(killer)
+	if len(members) < 2 || rule == LootFree {
+		if gold > 0 {
+			contents = append(contents, newGoldPile(gold))
+		}
+		return contents, nil
+	}
+
+	var notices []notice
+	if gold > 0 {
+		share := gold / len(members)
+		for _, member := range members {
+			amount := share
+			if member == killer {
+				amount += gold - share*len(members)
+			}
+			if amount <= 0 {
+				continue
+			}
+			member.Gold += amount
+			notices = append(notices, notice{member, fmt.Sprintf("You receive %d gold as your share of the spoils.", amount)})
+		}
+	}
+	if rule != LootRoundRobin {
+		return contents, notices
+	}
+
+	leader := groupLeader(killer)
+	for _, obj := range contents {
+		member := members[leader.LootTurn%len(members)]
+		leader.LootTurn++
+		member.Inventory = append(member.Inventory, obj)
+		pocketMoney(member)
+		for _, other := range members {
+			line := fmt.Sprintf("%s receives %s.", CapitalizeName(member.Name), obj.Short)
+			if other == member {
+				line = fmt.Sprintf("You receive %s.", obj.Short)
+			}
+			notices = append(notices, notice{other, line})
+		}
+	}
+	return nil, notices
+}
+
+// groupAssist has the leader's group members in the room join the fight,
+// each striking mob with a melee blow. Returns whether the mob died and the
+// labels of what its corpse holds.
+func (w *World) groupAssist(leader *Player, mob *Mobile) (died bool, loot []string) {
+	w.mu.RLock()
+	var helpers []*Player
+	if leader.Leader == leader {
+		for _, member := range w.groupHere(leader) {
+			if member != leader && !IsAsleep(member) && !member.LinkDead && positionOf(member.Position) == PositionStanding {
+				helpers = append(helpers, member)
+			}
+		}
+	}
+	w.mu.RUnlock()
+
+	for _, helper := range helpers {
+		w.mu.Lock()
+		room, ok := w.rooms[helper.Location]
+		if !ok || mob.HP <= 0 || !roomHasMobile(room, mob) {
+			w.mu.Unlock()
+			return false, nil
+		}
+		damage := max(rand.Intn(4)+1+helper.Strength/4, 1)
+		w.mu.Unlock()
+
+		vars := ActVars{"damage": strconv.Itoa(damage)}
+		w.Act("You assist $N, striking for &R$damage&w damage!", PlayerSubject(helper), MobileSubject(mob), vars, ToActor)
+		w.Act("$n assists in the attack on $N!", PlayerSubject(helper), MobileSubject(mob), nil, ToRoom)
+		if died, loot := w.strikeMob(helper, mob, damage); died {
+			return true, loot
+		}
+	}
+	return false, nil
+}
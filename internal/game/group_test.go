@@ -0,0 +1,169 @@
+package game
+
+import (
+	"testing"
+)
+
+func newGroupWorld(t *testing.T) (*World, *Player, *Player) {
+	t.Helper()
+
+	world, alice := newMobAIWorld(t)
+	alice.Move, alice.MaxMove = 100, 100
+	bob := &Player{Name: "bob", Output: &bufferOutput{}, Location: 1, HP: 100, MaxHP: 100, Move: 100, MaxMove: 100}
+	if err := world.AddPlayer(bob); err != nil {
+		t.Fatalf("add player: %v", err)
+	}
+	return world, alice, bob
+}
+
+func groupUp(t *testing.T, world *World, leader, member *Player) {
+	t.Helper()
+	if err := world.Follow(member, leader); err != nil {
+		t.Fatalf("follow: %v", err)
+	}
+	if err := world.GroupToggle(leader, member); err != nil {
+		t.Fatalf("group: %v", err)
+	}
+}
+
+func TestFollowersMoveWithLeader(t *testing.T) {
+	world, alice, bob := newGroupWorld(t)
+	carol := &Player{Name: "carol", Output: &bufferOutput{}, Location: 1, HP: 100, MaxHP: 100, Move: 100, MaxMove: 100}
+	if err := world.AddPlayer(carol); err != nil {
+		t.Fatalf("add player: %v", err)
+	}
+
+	if err := world.Follow(bob, alice); err != nil {
+		t.Fatalf("follow: %v", err)
+	}
+	if err := world.Follow(carol, bob); err != nil {
+		t.Fatalf("follow: %v", err)
+	}
+	if err := world.Follow(alice, carol); err == nil {
+		t.Fatalf("expected following in a loop to be refused")
+	}
+
+	view, err := world.MovePlayer(alice, "east")
+	if err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	if bob.Location != 2 || carol.Location != 2 || len(view.Followers) != 2 {
+		t.Fatalf("expected the whole line to follow, bob in %d carol in %d", bob.Location, carol.Location)
+	}
+	if !bob.Output.(*bufferOutput).Contains("You follow Alice.") {
+		t.Fatalf("expected bob to be told he follows")
+	}
+
+	// A resting follower is left behind
+	bob.Position = PositionResting
+	if _, err := world.MovePlayer(alice, "west"); err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	if bob.Location != 2 || carol.Location != 2 {
+		t.Fatalf("expected bob and carol to stay put, bob in %d carol in %d", bob.Location, carol.Location)
+	}
+}
+
+func TestGroupNeedsFollowers(t *testing.T) {
+	world, alice, bob := newGroupWorld(t)
+
+	if err := world.GroupToggle(alice, bob); err == nil {
+		t.Fatalf("expected bob to need to follow first")
+	}
+	groupUp(t, world, alice, bob)
+	if members := world.GroupMembers(bob); len(members) != 2 || members[0] != alice {
+		t.Fatalf("expected alice to lead a group of two, got %v", members)
+	}
+	if err := world.GroupToggle(bob, alice); err == nil {
+		t.Fatalf("expected only the leader to manage the group")
+	}
+
+	if err := world.Ungroup(alice, nil); err != nil {
+		t.Fatalf("ungroup: %v", err)
+	}
+	if alice.Leader != nil || bob.Leader != nil {
+		t.Fatalf("expected the group to be disbanded")
+	}
+	if !bob.Output.(*bufferOutput).Contains("Alice has disbanded the group.") {
+		t.Fatalf("expected bob to hear the group disband")
+	}
+}
+
+func TestGroupLootRules(t *testing.T) {
+	world, alice, bob := newGroupWorld(t)
+	groupUp(t, world, alice, bob)
+	world.SetPrototypes(nil, map[int]*Object{30: {Vnum: 30, Keywords: []string{"fang"}, Short: "a wolf fang"}})
+
+	if err := world.SetLootRule(bob, LootSplit); err == nil {
+		t.Fatalf("expected only the leader to set loot rules")
+	}
+	if err := world.SetLootRule(alice, LootSplit); err != nil {
+		t.Fatalf("loot rule: %v", err)
+	}
+	wolf := placeMob(world, 1, &Mobile{Short: "a wolf", GoldMin: 21, GoldMax: 21, Loot: []LootEntry{{Vnum: 30, Count: 1}}})
+	died, loot := world.DamageMob(bob, wolf, 100)
+	if !died || len(loot) != 1 || loot[0] != "a wolf fang" {
+		t.Fatalf("expected the fang left in the corpse, got %v", loot)
+	}
+	if bob.Gold != 11 || alice.Gold != 10 {
+		t.Fatalf("expected the gold split with the odd coin to the killer, alice %d bob %d", alice.Gold, bob.Gold)
+	}
+
+	if err := world.SetLootRule(alice, LootRoundRobin); err != nil {
+		t.Fatalf("loot rule: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		wolf := placeMob(world, 1, &Mobile{Short: "a wolf", Loot: []LootEntry{{Vnum: 30, Count: 1}}})
+		if _, loot := world.DamageMob(alice, wolf, 100); len(loot) != 0 {
+			t.Fatalf("expected nothing left in the corpse, got %v", loot)
+		}
+	}
+	if len(alice.Inventory) != 1 || len(bob.Inventory) != 1 {
+		t.Fatalf("expected a fang each, alice %d bob %d", len(alice.Inventory), len(bob.Inventory))
+	}
+}
+
+func TestGroupAssistsLeader(t *testing.T) {
+	world, alice, bob := newGroupWorld(t)
+	groupUp(t, world, alice, bob)
+	bob.Strength = 20
+	ogre := placeMob(world, 1, &Mobile{Short: "an ogre", MaxHP: 100, HP: 100})
+
+	if died, _ := world.DamageMob(alice, ogre, 10); died {
+		t.Fatalf("expected the ogre to survive")
+	}
+	if ogre.HP > 84 {
+		t.Fatalf("expected bob to join in, ogre has %d hp", ogre.HP)
+	}
+	if !bob.Output.(*bufferOutput).Contains("You assist an ogre") {
+		t.Fatalf("expected bob to be told he assists")
+	}
+
+	// Members don't drag the leader into their own fights
+	hp := ogre.HP
+	world.DamageMob(bob, ogre, 10)
+	if ogre.HP != hp-10 {
+		t.Fatalf("expected only bob's blow to land, ogre has %d hp", ogre.HP)
+	}
+}
+
+func TestGroupTellAndQuitting(t *testing.T) {
+	world, alice, bob := newGroupWorld(t)
+
+	if err := world.GroupTell(alice, "hello"); err == nil {
+		t.Fatalf("expected an ungrouped player to have nobody to tell")
+	}
+	groupUp(t, world, alice, bob)
+	bob.Location = 3
+	if err := world.GroupTell(alice, "hello"); err != nil {
+		t.Fatalf("gtell: %v", err)
+	}
+	if !bob.Output.(*bufferOutput).Contains("Alice tells the group 'hello'") {
+		t.Fatalf("expected bob to hear the group tell from afar")
+	}
+
+	world.RemovePlayer("alice")
+	if bob.Master != nil || bob.Leader != nil {
+		t.Fatalf("expected bob to be cut loose when alice quits")
+	}
+}
@@ -0,0 +1,99 @@
+package game
+
+import (
+	"njata/internal/races"
+)
+
+// Sectors whose lighting ignores the time of day.
+var (
+	litSectors  = map[string]bool{"inside": true, "city": true}            // lit even at night
+	darkSectors = map[string]bool{"underground": true, "underwater": true} // dark even by day
+)
+
+// Object types that shed light: carried lights and fires lying in the room.
+const (
+	ObjectTypeLight = "light"
+	ObjectTypeFire  = "fire"
+)
+
+// SetNight records whether the sun is down. Outdoor rooms are dark at night.
+func (w *World) SetNight(night bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.night = night
+}
+
+// IsNight reports whether the sun is down.
+func (w *World) IsNight() bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.night
+}
+
+// carriesLight reports whether a player holds or wears a light source.
+func carriesLight(p *Player) bool {
+	return carriesType(p, ObjectTypeLight)
+}
+
+// roomHasLight reports whether anyone present carries a light or a fire burns in the room.
+func (w *World) roomHasLight(room *Room) bool {
+	for _, obj := range room.Objects {
+		if obj != nil && (obj.Type == ObjectTypeLight || obj.Type == ObjectTypeFire) {
+			return true
+		}
+	}
+	for _, player := range w.players {
+		if player.Location == room.Vnum && carriesLight(player) {
+			return true
+		}
+	}
+	return false
+}
+
+// roomIsDark applies the lighting rules: rooms flagged dark, dark sectors and
+// outdoor rooms at night are dark unless a light source is present.
+func (w *World) roomIsDark(room *Room) bool {
+	dark := room.Flags["dark"] || darkSectors[room.Sector]
+	if !dark && w.night && !litSectors[room.Sector] {
+		dark = true
+	}
+	return dark && !w.roomHasLight(room)
+}
+
+// RoomIsDark reports whether the room at vnum is currently dark.
+func (w *World) RoomIsDark(vnum int) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	room, ok := w.rooms[vnum]
+	return ok && w.roomIsDark(room)
+}
+
+// playerVision returns the player's racial vision, if any.
+func playerVision(p *Player) string {
+	if race := races.GetByID(p.Race); race != nil {
+		return race.Vision
+	}
+	return ""
+}
+
+// canSeeRoom reports whether viewer can make out the room itself and the
+// objects in it.
+func (w *World) canSeeRoom(viewer *Player) bool {
+	if viewer == nil || viewer.IsKeeper {
+		return true
+	}
+
+	room, ok := w.rooms[viewer.Location]
+	if !ok || !w.roomIsDark(room) {
+		return true
+	}
+	return playerVision(viewer) == races.VisionDarkvision
+}
+
+// CanSeeRoom reports whether the player can see their surroundings.
+func (w *World) CanSeeRoom(viewer *Player) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.canSeeRoom(viewer)
+}
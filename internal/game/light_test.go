@@ -0,0 +1,109 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"njata/internal/races"
+)
+
+func newDarkWorld(t *testing.T) (*World, *Player) {
+	t.Helper()
+
+	rooms := map[int]*Room{
+		1: {
+			Vnum:        1,
+			Name:        "Cellar",
+			Description: "Damp stone walls.",
+			Flags:       map[string]bool{"dark": true},
+			Exits:       map[string]int{"up": 2},
+			Mobiles:     []*Mobile{{Keywords: []string{"rat"}, Short: "a rat"}},
+			Objects:     []*Object{{Keywords: []string{"coin"}, Short: "a coin"}},
+		},
+		2: {Vnum: 2, Name: "Field", Sector: "field", Flags: map[string]bool{}, Exits: map[string]int{"down": 1}},
+	}
+	world := CreateWorldFromRooms(rooms, 1)
+
+	player := &Player{Name: "alice", Output: &bufferOutput{}}
+	if err := world.AddPlayer(player); err != nil {
+		t.Fatalf("add player: %v", err)
+	}
+	return world, player
+}
+
+func TestDarkRoomHidesContents(t *testing.T) {
+	world, player := newDarkWorld(t)
+
+	view, err := world.DescribeRoom(player)
+	if err != nil {
+		t.Fatalf("describe: %v", err)
+	}
+	if !view.Dark || len(view.Mobiles) != 0 || len(view.Objects) != 0 || len(view.Exits) != 0 {
+		t.Fatalf("expected dark room to hide everything, got %+v", view)
+	}
+	if _, ok := world.FindObjectInRoom(player, "coin"); ok {
+		t.Fatalf("expected coin to be hidden in the dark")
+	}
+	if _, ok := world.FindMobInRoom(player, "rat"); ok {
+		t.Fatalf("expected rat to be hidden in the dark")
+	}
+}
+
+func TestCarriedLightRevealsRoom(t *testing.T) {
+	world, player := newDarkWorld(t)
+	player.Inventory = append(player.Inventory, &Object{Type: ObjectTypeLight, Keywords: []string{"torch"}, Short: "a torch"})
+
+	view, _ := world.DescribeRoom(player)
+	if view.Dark || len(view.Objects) != 1 || len(view.Mobiles) != 1 {
+		t.Fatalf("expected lit room to show contents, got %+v", view)
+	}
+
+	other := &Player{Name: "bob", Output: &bufferOutput{}}
+	world.AddPlayer(other)
+	if world.RoomIsDark(1) {
+		t.Fatalf("expected alice's torch to light the room for everyone")
+	}
+}
+
+func TestNightDarkensOutdoors(t *testing.T) {
+	world, player := newDarkWorld(t)
+	player.Location = 2
+
+	if world.RoomIsDark(2) {
+		t.Fatalf("expected field to be lit by day")
+	}
+	world.SetNight(true)
+	if !world.RoomIsDark(2) {
+		t.Fatalf("expected field to be dark at night")
+	}
+}
+
+// loadRace replaces the race registry with the single race described by
+// definition for the rest of the test.
+func loadRace(t *testing.T, definition string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "race.json"), []byte(definition), 0o644); err != nil {
+		t.Fatalf("write race: %v", err)
+	}
+	t.Cleanup(races.Preserve())
+	if err := races.Load(dir); err != nil {
+		t.Fatalf("load races: %v", err)
+	}
+}
+
+func TestInfravisionSeesCreaturesOnly(t *testing.T) {
+	loadRace(t, `{"name": "Gnome", "race_id": 7, "vision": "infravision"}`)
+
+	world, player := newDarkWorld(t)
+	player.Race = 7
+
+	if _, ok := world.FindMobInRoom(player, "rat"); !ok {
+		t.Fatalf("expected infravision to reveal the rat")
+	}
+	if _, ok := world.FindObjectInRoom(player, "coin"); ok {
+		t.Fatalf("expected infravision not to reveal objects")
+	}
+}
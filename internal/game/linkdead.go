@@ -0,0 +1,221 @@
+package game
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultLimboVnum matches the legacy ROOM_VNUM_LIMBO.
+const DefaultLimboVnum = 2
+
+// LinkDeadPolicy controls how long disconnected and idle characters linger.
+// Zero durations disable the corresponding behavior.
+type LinkDeadPolicy struct {
+	Grace      time.Duration // link-dead characters are logged out after this long
+	IdleLimbo  time.Duration // idle characters are moved to limbo after this long
+	IdleLogout time.Duration // idle characters are logged out after this long
+	LimboVnum  int
+}
+
+// discardOutput swallows messages sent to link-dead players.
+type discardOutput struct{}
+
+func (discardOutput) Write(text string)     {}
+func (discardOutput) WriteLine(text string) {}
+
+// EnsureLimbo makes sure a limbo room exists at vnum, creating a bare one if needed.
+func (w *World) EnsureLimbo(vnum int) {
+	if vnum <= 0 {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.rooms[vnum]; ok {
+		return
+	}
+
+	w.rooms[vnum] = &Room{
+		Vnum:        vnum,
+		Name:        "Limbo",
+		Description: "You float in a formless void, detached from all sensation of physical matter.",
+		Flags:       map[string]bool{"safe": true, "nomob": true},
+		Exits:       map[string]int{},
+		ExDescs:     map[string]string{},
+	}
+}
+
+// DetachPlayer marks a player link-dead when the connection bound to output drops.
+// It returns false if the player has since been rebound to another connection.
+func (w *World) DetachPlayer(player *Player, output Output) bool {
+	if player == nil {
+		return false
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	current, ok := w.players[normalizeName(player.Name)]
+	if !ok || current != player || player.Output != output {
+		return false
+	}
+
+	player.LinkDead = true
+	player.LinkDeadSince = time.Now()
+	player.Output = discardOutput{}
+	player.Disconnect = nil
+	return true
+}
+
+// OwnsPlayer reports whether output is still the connection bound to player.
+func (w *World) OwnsPlayer(player *Player, output Output) bool {
+	if player == nil {
+		return false
+	}
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return player.Output == output
+}
+
+// Reconnect rebinds an existing in-world character to a new connection.
+// Any connection still attached to the character is told to disconnect.
+func (w *World) Reconnect(name string, output Output, disconnect func(reason string)) (*Player, bool) {
+	w.mu.Lock()
+	player, ok := w.players[normalizeName(name)]
+	if !ok {
+		w.mu.Unlock()
+		return nil, false
+	}
+
+	previous := player.Disconnect
+	player.Output = output
+	player.Disconnect = disconnect
+	player.LinkDead = false
+	player.LinkDeadSince = time.Time{}
+	player.LastInput = time.Now()
+	w.mu.Unlock()
+
+	if previous != nil {
+		previous("reconnect")
+	}
+
+	return player, true
+}
+
+// TouchPlayer records input from the player and brings them back from limbo if idle.
+// It returns true if the player was moved back to their previous room.
+func (w *World) TouchPlayer(player *Player) bool {
+	if player == nil {
+		return false
+	}
+
+	w.mu.Lock()
+	player.LastInput = time.Now()
+	from := player.IdleFrom
+	if from == 0 {
+		w.mu.Unlock()
+		return false
+	}
+
+	player.IdleFrom = 0
+	if _, ok := w.rooms[from]; !ok {
+		from = w.start
+	}
+	player.Location = from
+	w.mu.Unlock()
+
+	w.BroadcastSystemToRoomExcept(player, "$n has returned from the void.")
+	return true
+}
+
+// LinkDeadTick enforces the grace period for link-dead characters and the idle limbo/logout timers.
+// Characters that must leave the world are passed to save (if set) and removed.
+func (w *World) LinkDeadTick(policy LinkDeadPolicy, save func(*Player), logger func(string)) {
+	now := time.Now()
+
+	var toLimbo []*Player
+	var toRemove []*Player
+
+	w.mu.Lock()
+	_, hasLimbo := w.rooms[policy.LimboVnum]
+	for _, player := range w.players {
+		switch {
+		case mustLogOut(player, policy, now):
+			toRemove = append(toRemove, player)
+		case hasLimbo && dueForLimbo(player, policy, now):
+			toLimbo = append(toLimbo, player)
+		}
+	}
+	w.mu.Unlock()
+
+	for _, player := range toLimbo {
+		w.BroadcastSystemToRoomExcept(player, "$n disappears into the void.")
+		w.mu.Lock()
+		// They may have typed something while the room was told
+		if !dueForLimbo(player, policy, now) {
+			w.mu.Unlock()
+			continue
+		}
+		player.IdleFrom = player.Location
+		player.Location = policy.LimboVnum
+		w.mu.Unlock()
+		player.Output.WriteLine("You are pulled into the void.")
+	}
+
+	for _, player := range toRemove {
+		w.mu.Lock()
+		// A reconnect or fresh input since the scan keeps them in the game
+		if w.players[normalizeName(player.Name)] != player || !mustLogOut(player, policy, time.Now()) {
+			w.mu.Unlock()
+			continue
+		}
+		if player.IdleFrom != 0 {
+			player.Location = player.IdleFrom
+			player.IdleFrom = 0
+		}
+		linkDead := player.LinkDead
+		disconnect := player.Disconnect
+		w.mu.Unlock()
+
+		if save != nil {
+			save(player)
+		}
+		w.RemovePlayer(player.Name)
+
+		if !linkDead {
+			player.Output.WriteLine("You have been idle too long. Goodbye.")
+			if disconnect != nil {
+				disconnect("idle")
+			}
+		}
+		w.BroadcastSystemToRoomExcept(player, "$n has left the game.")
+
+		if logger != nil {
+			if linkDead {
+				logger(fmt.Sprintf("%s logged out after link-dead grace period", player.Name))
+			} else {
+				logger(fmt.Sprintf("%s logged out for idling", player.Name))
+			}
+		}
+	}
+}
+
+// mustLogOut reports whether player has outstayed the link-dead grace period
+// or the idle logout timer. Callers hold w.mu.
+func mustLogOut(player *Player, policy LinkDeadPolicy, now time.Time) bool {
+	if player.LinkDead {
+		return now.Sub(player.LinkDeadSince) >= policy.Grace
+	}
+	return policy.IdleLogout > 0 && !player.LastInput.IsZero() && now.Sub(player.LastInput) >= policy.IdleLogout
+}
+
+// dueForLimbo reports whether an idle player should be pulled into limbo.
+// Callers hold w.mu.
+func dueForLimbo(player *Player, policy LinkDeadPolicy, now time.Time) bool {
+	if player.LinkDead || player.LastInput.IsZero() || player.IdleFrom != 0 || player.Location == policy.LimboVnum {
+		return false
+	}
+	return policy.IdleLimbo > 0 && now.Sub(player.LastInput) >= policy.IdleLimbo
+}
@@ -0,0 +1,134 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDetachAndReconnect(t *testing.T) {
+	world := CreateDefaultWorld()
+
+	oldOut := &bufferOutput{}
+	player := &Player{Name: "Alice", Output: oldOut}
+	if err := world.AddPlayer(player); err != nil {
+		t.Fatalf("add player: %v", err)
+	}
+
+	if !world.DetachPlayer(player, oldOut) {
+		t.Fatalf("expected detach to succeed")
+	}
+	if !player.LinkDead {
+		t.Fatalf("expected player to be link-dead")
+	}
+
+	names := world.ListPlayers()
+	if len(names) != 1 || names[0] != "Alice (linkdead)" {
+		t.Fatalf("unexpected who list: %v", names)
+	}
+
+	newOut := &bufferOutput{}
+	reconnected, ok := world.Reconnect("alice", newOut, nil)
+	if !ok || reconnected != player {
+		t.Fatalf("expected reconnect to return existing player")
+	}
+	if player.LinkDead || player.Output != newOut {
+		t.Fatalf("expected player rebound to new output")
+	}
+
+	// The stale connection must not be able to detach the player again.
+	if world.DetachPlayer(player, oldOut) {
+		t.Fatalf("expected stale detach to be ignored")
+	}
+}
+
+func TestReconnectDisconnectsPreviousSession(t *testing.T) {
+	world := CreateDefaultWorld()
+
+	var reason string
+	player := &Player{Name: "Alice", Output: &bufferOutput{}, Disconnect: func(r string) { reason = r }}
+	if err := world.AddPlayer(player); err != nil {
+		t.Fatalf("add player: %v", err)
+	}
+
+	if _, ok := world.Reconnect("Alice", &bufferOutput{}, nil); !ok {
+		t.Fatalf("expected reconnect to succeed")
+	}
+	if reason != "reconnect" {
+		t.Fatalf("expected previous session to be disconnected, got %q", reason)
+	}
+}
+
+func TestLinkDeadTickRemovesAfterGrace(t *testing.T) {
+	world := CreateDefaultWorld()
+
+	out := &bufferOutput{}
+	player := &Player{Name: "Alice", Output: out}
+	if err := world.AddPlayer(player); err != nil {
+		t.Fatalf("add player: %v", err)
+	}
+	world.DetachPlayer(player, out)
+	player.LinkDeadSince = time.Now().Add(-11 * time.Minute)
+
+	saved := 0
+	world.LinkDeadTick(LinkDeadPolicy{Grace: 10 * time.Minute}, func(*Player) { saved++ }, nil)
+
+	if _, ok := world.FindPlayer("Alice"); ok {
+		t.Fatalf("expected link-dead player to be removed")
+	}
+	if saved != 1 {
+		t.Fatalf("expected player to be saved once, got %d", saved)
+	}
+}
+
+func TestIdleLimboAndReturn(t *testing.T) {
+	world := CreateDefaultWorld()
+	world.EnsureLimbo(DefaultLimboVnum)
+
+	player := &Player{Name: "Alice", Output: &bufferOutput{}, LastInput: time.Now().Add(-20 * time.Minute)}
+	if err := world.AddPlayer(player); err != nil {
+		t.Fatalf("add player: %v", err)
+	}
+
+	policy := LinkDeadPolicy{IdleLimbo: 15 * time.Minute, IdleLogout: 30 * time.Minute, LimboVnum: DefaultLimboVnum}
+	world.LinkDeadTick(policy, nil, nil)
+
+	if player.Location != DefaultLimboVnum || player.IdleFrom != 1 {
+		t.Fatalf("expected player in limbo from room 1, got location %d from %d", player.Location, player.IdleFrom)
+	}
+
+	if !world.TouchPlayer(player) {
+		t.Fatalf("expected player to return from limbo")
+	}
+	if player.Location != 1 || player.IdleFrom != 0 {
+		t.Fatalf("expected player back in room 1, got %d", player.Location)
+	}
+}
+
+func TestLinkDeadTickSparesReconnectedPlayers(t *testing.T) {
+	world := CreateDefaultWorld()
+
+	names := map[string]string{"alice": "bob", "bob": "alice"}
+	for name := range names {
+		player := &Player{Name: name, Output: &bufferOutput{}, LinkDead: true, LinkDeadSince: time.Now().Add(-time.Hour)}
+		if err := world.AddPlayer(player); err != nil {
+			t.Fatalf("add player: %v", err)
+		}
+	}
+
+	// Whoever is saved first, the other reconnects before their turn comes
+	var saved []string
+	save := func(p *Player) {
+		if len(saved) == 0 {
+			world.Reconnect(names[p.Name], &bufferOutput{}, nil)
+		}
+		saved = append(saved, p.Name)
+	}
+	world.LinkDeadTick(LinkDeadPolicy{Grace: time.Minute}, save, nil)
+
+	if len(saved) != 1 {
+		t.Fatalf("expected only one player saved and removed, got %v", saved)
+	}
+	if names := world.ListPlayers(); len(names) != 1 {
+		t.Fatalf("expected the reconnected player to stay, got %v", names)
+	}
+}
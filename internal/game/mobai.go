@@ -0,0 +1,331 @@
+package game
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Mobile behaviors, switched on per mobile in the area file's "behaviors" map.
+// A mobile without the sentinel behavior wanders, but never out of its area.
+const (
+	BehaviorSentinel   = "sentinel"    // never wanders off
+	BehaviorAggressive = "aggressive"  // attacks players on sight
+	BehaviorScavenger  = "scavenger"   // picks up items lying around
+	BehaviorWimpy      = "wimpy"       // flees when badly hurt
+	BehaviorAssistRace = "assist_race" // joins fights against mobiles of its race
+	BehaviorAssistArea = "assist_area" // joins fights against mobiles from its area
+)
+
+// Behavior tuning. Odds are "one update in n".
+const (
+	wanderOdds   = 3
+	scavengeOdds = 2
+	wimpyPercent = 25 // wimpy mobiles flee below this share of their MaxHP
+)
+
+// HasBehavior reports whether the mobile has the named behavior.
+func (m *Mobile) HasBehavior(behavior string) bool {
+	return m.Behaviors[behavior]
+}
+
+// mobInCombat reports whether the mobile fought recently.
+func mobInCombat(m *Mobile) bool {
+	return !m.LastCombat.IsZero() && time.Since(m.LastCombat) < combatRegenDelay
+}
+
+// mobAwake reports whether the mobile is on its feet and able to act.
+func mobAwake(m *Mobile) bool {
+	return positionOf(m.Position) == PositionStanding
+}
+
+// roomHasMobile reports whether mob is in room.
+func roomHasMobile(room *Room, mob *Mobile) bool {
+	for _, m := range room.Mobiles {
+		if m == mob {
+			return true
+		}
+	}
+	return false
+}
+
+// mobileUpdate lets every mobile act once: aggressive mobiles pick a fight,
+// scavengers tidy up and the rest may wander. What each mobile may do is
+// decided from a snapshot taken under w.mu, since commands change positions
+// and combat state as the update runs.
+func (w *World) mobileUpdate() {
+	type plan struct {
+		mob        *Mobile
+		room       int
+		aggressive bool
+		fighting   bool
+		scavenger  bool
+		sentinel   bool
+	}
+	var plans []plan
+
+	w.mu.RLock()
+	for vnum, room := range w.rooms {
+		for _, m := range room.Mobiles {
+			if !mobAwake(m) {
+				continue
+			}
+			plans = append(plans, plan{
+				mob:        m,
+				room:       vnum,
+				aggressive: m.HasBehavior(BehaviorAggressive),
+				fighting:   mobInCombat(m),
+				scavenger:  m.HasBehavior(BehaviorScavenger),
+				sentinel:   m.HasBehavior(BehaviorSentinel),
+			})
+		}
+	}
+	w.mu.RUnlock()
+
+	for _, pm := range plans {
+		if pm.aggressive && w.mobAggress(pm.mob, pm.room) {
+			continue
+		}
+		if pm.fighting {
+			continue
+		}
+		if pm.scavenger && rand.Intn(scavengeOdds) == 0 && w.mobScavenge(pm.mob, pm.room) {
+			continue
+		}
+		if !pm.sentinel && rand.Intn(wanderOdds) == 0 {
+			w.mobWander(pm.mob, pm.room)
+		}
+	}
+}
+
+// standsIn reports whether p is alive and in the room at vnum.
+func (w *World) standsIn(p *Player, vnum int) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return p.HP > 0 && p.Location == vnum
+}
+
+// mobExits lists the directions a mobile in room may take: exits to rooms
+// that allow mobiles, aren't death traps and lie in the mobile's own area.
+// Callers hold w.mu.
+func (w *World) mobExits(mob *Mobile, room *Room) []string {
+	home := mob.AreaName
+	if home == "" {
+		home = room.AreaName
+	}
+
+	var dirs []string
+	for dir, vnum := range room.Exits {
+		to, ok := w.rooms[vnum]
+		if !ok || to.Flags[RoomFlagNoMob] || to.Flags[RoomFlagDeath] || to.AreaName != home {
+			continue
+		}
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// moveMobile takes mob out of the room at from through a random usable exit,
+// announcing its departure with leaving. It returns false if the mobile had
+// nowhere to go.
+func (w *World) moveMobile(mob *Mobile, from int, leaving string) bool {
+	w.mu.RLock()
+	room, ok := w.rooms[from]
+	if !ok || !roomHasMobile(room, mob) {
+		w.mu.RUnlock()
+		return false
+	}
+	dirs := w.mobExits(mob, room)
+	if len(dirs) == 0 {
+		w.mu.RUnlock()
+		return false
+	}
+	dir := dirs[rand.Intn(len(dirs))]
+	dest := room.Exits[dir]
+	w.mu.RUnlock()
+
+	w.Act(leaving, MobileSubject(mob), Subject{}, ActVars{"direction": dir}, ToRoom)
+
+	w.mu.Lock()
+	room, ok = w.rooms[from]
+	to, toOK := w.rooms[dest]
+	if !ok || !toOK || !roomHasMobile(room, mob) {
+		w.mu.Unlock()
+		return false
+	}
+	kept := make([]*Mobile, 0, len(room.Mobiles))
+	for _, m := range room.Mobiles {
+		if m != mob {
+			kept = append(kept, m)
+		}
+	}
+	room.Mobiles = kept
+	to.Mobiles = append(to.Mobiles, mob)
+	w.mu.Unlock()
+
+	w.Act("$n has arrived.", MobileSubject(mob), Subject{}, nil, ToRoom)
+	if mob.HasBehavior(BehaviorAggressive) {
+		w.mobAggress(mob, dest)
+	}
+	return true
+}
+
+// mobWander moves a mobile to a neighbouring room.
+func (w *World) mobWander(mob *Mobile, from int) bool {
+	return w.moveMobile(mob, from, "$n leaves $direction.")
+}
+
+// mobFlee has a badly hurt mobile run for it.
+func (w *World) mobFlee(mob *Mobile, from int) bool {
+	return w.moveMobile(mob, from, "$n panics and flees $direction!")
+}
+
+// shouldFlee reports whether a wimpy mobile is hurt enough to run.
+func shouldFlee(mob *Mobile) bool {
+	return mob.HasBehavior(BehaviorWimpy) && mob.HP > 0 && mob.HP*percentMultiplier < mob.MaxHP*wimpyPercent
+}
+
+// aggressionTarget picks a player in room for mob to attack. Keepers,
+// link-dead players and anyone in a safe room are left alone.
+// Callers hold w.mu.
+func (w *World) aggressionTarget(room *Room) *Player {
+	if room.Flags[RoomFlagSafe] {
+		return nil
+	}
+	var victims []*Player
+	for _, p := range w.players {
+		if p.Location == room.Vnum && !p.IsKeeper && !p.LinkDead {
+			victims = append(victims, p)
+		}
+	}
+	if len(victims) == 0 {
+		return nil
+	}
+	sort.Slice(victims, func(i, j int) bool { return victims[i].Name < victims[j].Name })
+	return victims[rand.Intn(len(victims))]
+}
+
+// mobAggress has an aggressive mobile attack a player sharing its room. It
+// returns true if it attacked. A mobile that fought recently is already busy
+// and waits for its fight to cool off before picking another.
+func (w *World) mobAggress(mob *Mobile, vnum int) bool {
+	w.mu.RLock()
+	room, ok := w.rooms[vnum]
+	var victim *Player
+	if ok && roomHasMobile(room, mob) && mobAwake(mob) && !mobInCombat(mob) {
+		victim = w.aggressionTarget(room)
+	}
+	w.mu.RUnlock()
+
+	if victim == nil {
+		return false
+	}
+	w.mobAttack(mob, victim)
+	return true
+}
+
+// mobAttack has mob open a fight with target.
+func (w *World) mobAttack(mob *Mobile, target *Player) {
+	attacker, victim := MobileSubject(mob), PlayerSubject(target)
+	w.Act("&R$n screams and attacks you!&w", attacker, victim, nil, ToTarget)
+	w.Act("$n screams and attacks $N!", attacker, victim, nil, ToNotTarget)
+	w.mobCounterAttack(target, mob)
+}
+
+// MobsNoticeArrival lets aggressive mobiles in the player's new room attack
+// them.
+func (w *World) MobsNoticeArrival(p *Player) {
+	if p.IsKeeper {
+		return
+	}
+
+	w.mu.RLock()
+	vnum := p.Location
+	room, ok := w.rooms[vnum]
+	var aggressors []*Mobile
+	if ok && !room.Flags[RoomFlagSafe] {
+		for _, m := range room.Mobiles {
+			if m.HasBehavior(BehaviorAggressive) && mobAwake(m) {
+				aggressors = append(aggressors, m)
+			}
+		}
+	}
+	w.mu.RUnlock()
+
+	for _, m := range aggressors {
+		if !w.standsIn(p, vnum) {
+			return
+		}
+		w.mobAttack(m, p)
+	}
+}
+
+// mobsAssist brings mobiles that side with victim into the player's fight.
+// Mobiles assist their own race or their own area when they have the
+// matching behavior.
+func (w *World) mobsAssist(attacker *Player, victim *Mobile) {
+	type helper struct {
+		mob   *Mobile
+		fresh bool // wasn't fighting yet, so announce it joining in
+	}
+	var helpers []helper
+
+	w.mu.RLock()
+	vnum := attacker.Location
+	room, ok := w.rooms[vnum]
+	if ok {
+		for _, m := range room.Mobiles {
+			if m == victim || !mobAwake(m) {
+				continue
+			}
+			sameRace := m.HasBehavior(BehaviorAssistRace) && victim.Race != "" && strings.EqualFold(m.Race, victim.Race)
+			sameArea := m.HasBehavior(BehaviorAssistArea) && victim.AreaName != "" && m.AreaName == victim.AreaName
+			if sameRace || sameArea {
+				helpers = append(helpers, helper{m, !mobInCombat(m)})
+			}
+		}
+	}
+	w.mu.RUnlock()
+
+	vars := ActVars{"victim": MobileSubject(victim).name()}
+	for _, h := range helpers {
+		if !w.standsIn(attacker, vnum) {
+			return
+		}
+		if h.fresh {
+			w.Act("$n rushes to the aid of $victim!", MobileSubject(h.mob), Subject{}, vars, ToRoom)
+		}
+		w.mobCounterAttack(attacker, h.mob)
+	}
+}
+
+// mobScavenge has a scavenger pick up an item lying in its room. It returns
+// true if it took something.
+func (w *World) mobScavenge(mob *Mobile, vnum int) bool {
+	w.mu.Lock()
+	room, ok := w.rooms[vnum]
+	if !ok || !roomHasMobile(room, mob) {
+		w.mu.Unlock()
+		return false
+	}
+	var taken *Object
+	for i, obj := range room.Objects {
+		if CanTake(obj) {
+			taken = obj
+			room.Objects = append(room.Objects[:i], room.Objects[i+1:]...)
+			break
+		}
+	}
+	if taken != nil {
+		mob.Inventory = append(mob.Inventory, taken)
+	}
+	w.mu.Unlock()
+
+	if taken == nil {
+		return false
+	}
+	w.Act("$n picks up $object.", MobileSubject(mob), Subject{}, ActVars{"object": taken.Short}, ToRoom)
+	return true
+}
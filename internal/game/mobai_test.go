@@ -0,0 +1,162 @@
+package game
+
+import "testing"
+
+func newMobAIWorld(t *testing.T) (*World, *Player) {
+	t.Helper()
+
+	rooms := map[int]*Room{
+		1: {Vnum: 1, Name: "Glade", Sector: "forest", AreaName: "Woods", Flags: map[string]bool{}, Exits: map[string]int{"east": 2}},
+		2: {Vnum: 2, Name: "Thicket", Sector: "forest", AreaName: "Woods", Flags: map[string]bool{}, Exits: map[string]int{"west": 1, "east": 3, "north": 4}},
+		3: {Vnum: 3, Name: "Road", Sector: "field", AreaName: "Plains", Flags: map[string]bool{}, Exits: map[string]int{"west": 2}},
+		4: {Vnum: 4, Name: "Shrine", Sector: "forest", AreaName: "Woods", Flags: map[string]bool{RoomFlagNoMob: true}, Exits: map[string]int{"south": 2}},
+	}
+	world := CreateWorldFromRooms(rooms, 1)
+
+	player := &Player{Name: "alice", Output: &bufferOutput{}, Location: 1, HP: 100, MaxHP: 100}
+	if err := world.AddPlayer(player); err != nil {
+		t.Fatalf("add player: %v", err)
+	}
+	return world, player
+}
+
+func placeMob(world *World, vnum int, mob *Mobile) *Mobile {
+	if mob.AreaName == "" {
+		mob.AreaName = "Woods"
+	}
+	if mob.MaxHP == 0 {
+		mob.MaxHP, mob.HP = 40, 40
+	}
+	world.rooms[vnum].Mobiles = append(world.rooms[vnum].Mobiles, mob)
+	return mob
+}
+
+func TestWanderingMobStaysInAreaAndOutOfNoMob(t *testing.T) {
+	world, _ := newMobAIWorld(t)
+	wolf := placeMob(world, 2, &Mobile{Short: "a wolf", Keywords: []string{"wolf"}})
+
+	// From the thicket the road is another area and the shrine is nomob, so
+	// the only way out is west.
+	for i := 0; i < 10; i++ {
+		if !world.mobWander(wolf, world.subjectLocation(MobileSubject(wolf))) {
+			t.Fatalf("expected wolf to find a way out")
+		}
+		if loc := world.subjectLocation(MobileSubject(wolf)); loc != 1 && loc != 2 {
+			t.Fatalf("wolf wandered into room %d", loc)
+		}
+	}
+}
+
+func TestSentinelMobNeverWanders(t *testing.T) {
+	world, _ := newMobAIWorld(t)
+	guard := placeMob(world, 2, &Mobile{Short: "a guard", Behaviors: map[string]bool{BehaviorSentinel: true}})
+
+	for i := 0; i < 30; i++ {
+		world.mobileUpdate()
+	}
+	if loc := world.subjectLocation(MobileSubject(guard)); loc != 2 {
+		t.Fatalf("expected sentinel to stay put, found in room %d", loc)
+	}
+}
+
+func TestAggressiveMobAttacksArrivals(t *testing.T) {
+	world, player := newMobAIWorld(t)
+	placeMob(world, 1, &Mobile{Short: "a troll", Level: 4, Behaviors: map[string]bool{BehaviorAggressive: true}})
+
+	world.MobsNoticeArrival(player)
+
+	out := player.Output.(*bufferOutput)
+	if !out.Contains("attacks you") || player.HP >= 100 {
+		t.Fatalf("expected the troll to attack, HP %d output %v", player.HP, out.lines)
+	}
+}
+
+func TestAggressiveMobWaitsBetweenAttacks(t *testing.T) {
+	world, player := newMobAIWorld(t)
+	player.MaxHP, player.HP = 1000, 1000
+	placeMob(world, 1, &Mobile{Short: "a troll", Level: 4, Behaviors: map[string]bool{BehaviorAggressive: true, BehaviorSentinel: true}})
+
+	world.mobileUpdate()
+	hp := player.HP
+	if hp >= 1000 {
+		t.Fatalf("expected the troll to attack on its update")
+	}
+
+	world.mobileUpdate()
+	if player.HP != hp {
+		t.Fatalf("expected the troll to wait for its fight to cool off, HP %d -> %d", hp, player.HP)
+	}
+}
+
+func TestAggressiveMobSparesSafeRoomsAndKeepers(t *testing.T) {
+	world, player := newMobAIWorld(t)
+	placeMob(world, 1, &Mobile{Short: "a troll", Behaviors: map[string]bool{BehaviorAggressive: true}})
+
+	world.rooms[1].Flags[RoomFlagSafe] = true
+	world.MobsNoticeArrival(player)
+	if player.HP != 100 {
+		t.Fatalf("expected no attack in a safe room, HP %d", player.HP)
+	}
+
+	world.rooms[1].Flags[RoomFlagSafe] = false
+	player.IsKeeper = true
+	world.MobsNoticeArrival(player)
+	if player.HP != 100 {
+		t.Fatalf("expected keepers to be left alone, HP %d", player.HP)
+	}
+}
+
+func TestMobsAssistSameRace(t *testing.T) {
+	world, player := newMobAIWorld(t)
+	target := placeMob(world, 1, &Mobile{Short: "a goblin", Race: "Goblin"})
+	ally := placeMob(world, 1, &Mobile{Short: "a goblin chief", Race: "goblin", Behaviors: map[string]bool{BehaviorAssistRace: true}})
+	bystander := placeMob(world, 1, &Mobile{Short: "a rabbit", Race: "rabbit", Behaviors: map[string]bool{BehaviorAssistRace: true}})
+
+	world.DamageMob(player, target, 1)
+
+	if ally.LastCombat.IsZero() {
+		t.Fatalf("expected the goblin chief to join the fight")
+	}
+	if !bystander.LastCombat.IsZero() {
+		t.Fatalf("expected the rabbit to stay out of it")
+	}
+	if !player.Output.(*bufferOutput).Contains("rushes to the aid of a goblin") {
+		t.Fatalf("expected an assist message, got %v", player.Output.(*bufferOutput).lines)
+	}
+}
+
+func TestWimpyMobFleesAtLowHP(t *testing.T) {
+	world, player := newMobAIWorld(t)
+	rat := placeMob(world, 1, &Mobile{Short: "a rat", MaxHP: 40, HP: 40, Behaviors: map[string]bool{BehaviorWimpy: true}})
+
+	world.DamageMob(player, rat, 35)
+
+	if loc := world.subjectLocation(MobileSubject(rat)); loc != 2 {
+		t.Fatalf("expected rat to flee east, found in room %d", loc)
+	}
+	if player.HP != 100 {
+		t.Fatalf("expected a fleeing mob not to strike back, HP %d", player.HP)
+	}
+}
+
+func TestScavengerPicksUpItemsAndDropsThemInCorpse(t *testing.T) {
+	world, player := newMobAIWorld(t)
+	crow := placeMob(world, 1, &Mobile{Short: "a crow", Keywords: []string{"crow"}, Behaviors: map[string]bool{BehaviorScavenger: true}})
+	world.rooms[1].Objects = []*Object{
+		{Keywords: []string{"fountain"}, Type: "fountain", Short: "a fountain"},
+		{Keywords: []string{"ring"}, Short: "a ring"},
+	}
+
+	if !world.mobScavenge(crow, 1) {
+		t.Fatalf("expected the crow to pick something up")
+	}
+	if len(crow.Inventory) != 1 || crow.Inventory[0].Short != "a ring" {
+		t.Fatalf("expected the crow to take the ring, got %+v", crow.Inventory)
+	}
+
+	world.DamageMob(player, crow, 100)
+	corpse, ok := world.FindObjectInRoom(player, "corpse")
+	if !ok || len(corpse.Contents) != 1 {
+		t.Fatalf("expected the ring in the crow's corpse, got %+v", corpse)
+	}
+}
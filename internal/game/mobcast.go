@@ -0,0 +1,228 @@
+package game
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"njata/internal/skills"
+)
+
+// MobSkill is a spell or maneuver a mobile knows, from the area file's
+// "skills" list.
+type MobSkill struct {
+	SpellID     int `json:"spell_id"`
+	Proficiency int `json:"proficiency"` // 0-100: the chance the mobile uses it when it could
+}
+
+// healPercent is the share of MaxHP below which a mobile that knows a
+// healing spell would rather mend itself than attack.
+const healPercent = 50
+
+// Mobile attribute indexes, in the order of Mobile.Attributes.
+const (
+	mobAttributeStr = iota
+	mobAttributeInt
+	mobAttributeWis
+	mobAttributeDex
+	mobAttributeCon
+	mobAttributeLck
+	mobAttributeCha
+)
+
+// mobAbility is a skill the mobile has chosen to use this round.
+type mobAbility struct {
+	spell       *skills.Spell
+	proficiency int
+}
+
+// abilityReady reports whether the mobile can use spell right now: it is off
+// cooldown and the mobile has the mana. Callers hold w.mu.
+func abilityReady(m *Mobile, spell *skills.Spell, now time.Time) bool {
+	if m.Mana < spell.ManaCost {
+		return false
+	}
+	ready, ok := m.Cooldowns[spell.ID]
+	return !ok || !now.Before(ready)
+}
+
+func isHealing(spell *skills.Spell) bool {
+	mode := spell.Targeting.Mode
+	return spell.Effects.Healing != "" && (mode == "ally_single" || mode == "self")
+}
+
+func isOffensive(spell *skills.Spell) bool {
+	mode := spell.Targeting.Mode
+	return spell.Effects.Damage != "" && spell.Effects.Damage != "0" && (mode == "hostile_single" || mode == "hostile_area")
+}
+
+// chooseMobAbility picks what the mobile does this round: a healing spell
+// when it is badly hurt, otherwise one of its attacks, or nothing to fall
+// back on a plain melee strike. Callers hold w.mu.
+func chooseMobAbility(m *Mobile, now time.Time) (mobAbility, bool) {
+	var heals, attacks []mobAbility
+	for _, known := range m.Skills {
+		spell := skills.GetSpell(known.SpellID)
+		if spell == nil || !abilityReady(m, spell, now) {
+			continue
+		}
+		ability := mobAbility{spell, known.Proficiency}
+		switch {
+		case isHealing(spell):
+			heals = append(heals, ability)
+		case isOffensive(spell):
+			attacks = append(attacks, ability)
+		}
+	}
+
+	candidates := attacks
+	if len(heals) > 0 && m.HP*percentMultiplier < m.MaxHP*healPercent {
+		candidates = heals
+	}
+	if len(candidates) == 0 {
+		return mobAbility{}, false
+	}
+
+	choice := candidates[rand.Intn(len(candidates))]
+	if rand.Intn(percentMultiplier) >= choice.proficiency {
+		return mobAbility{}, false
+	}
+	return choice, true
+}
+
+// spendAbility spends the mobile's mana and starts the ability's cooldown.
+// Callers hold w.mu.
+func spendAbility(m *Mobile, spell *skills.Spell, now time.Time) {
+	m.Mana -= spell.ManaCost
+	if m.Cooldowns == nil {
+		m.Cooldowns = map[int]time.Time{}
+	}
+	m.Cooldowns[spell.ID] = now.Add(time.Duration(spell.CooldownSeconds) * time.Second)
+}
+
+// mobStat returns the attribute named by a formula letter: S, I, W, D, C, L
+// (luck) or A (charisma), or l for the mobile's level.
+func mobStat(m *Mobile, letter byte) int {
+	switch letter {
+	case 'S':
+		return m.Attributes[mobAttributeStr]
+	case 'I':
+		return m.Attributes[mobAttributeInt]
+	case 'W':
+		return m.Attributes[mobAttributeWis]
+	case 'D':
+		return m.Attributes[mobAttributeDex]
+	case 'C':
+		return m.Attributes[mobAttributeCon]
+	case 'L':
+		return m.Attributes[mobAttributeLck]
+	case 'A':
+		return m.Attributes[mobAttributeCha]
+	case 'l':
+		return m.Level
+	}
+	return 0
+}
+
+// rollFormula evaluates a skills.json formula such as "1d6 + I/2 + l" or
+// "2d8 + 5 + W*1.5" for a mobile.
+func rollFormula(formula string, m *Mobile) int {
+	return rollStats(formula, func(letter byte) int { return mobStat(m, letter) })
+}
+
+// rollStats evaluates a formula with stat letters looked up by stat. Each
+// term is dice, a number, or a stat letter optionally divided or multiplied
+// by a number.
+func rollStats(formula string, stat func(letter byte) int) int {
+	total := 0.0
+	for _, term := range strings.Split(formula, "+") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		if count, size, ok := parseDice(term); ok {
+			for i := 0; i < count; i++ {
+				total += float64(rand.Intn(size) + 1)
+			}
+			continue
+		}
+		if n, err := strconv.Atoi(term); err == nil {
+			total += float64(n)
+			continue
+		}
+
+		value := float64(stat(term[0]))
+		rest := strings.TrimSpace(term[1:])
+		if len(rest) > 1 {
+			if factor, err := strconv.ParseFloat(strings.TrimSpace(rest[1:]), 64); err == nil && factor != 0 {
+				switch rest[0] {
+				case '/':
+					value /= factor
+				case '*':
+					value *= factor
+				}
+			}
+		}
+		total += value
+	}
+	return int(total)
+}
+
+// parseDice reads "XdY" notation.
+func parseDice(term string) (count, size int, ok bool) {
+	left, right, found := strings.Cut(term, "d")
+	if !found {
+		return 0, 0, false
+	}
+	count, err := strconv.Atoi(left)
+	if err != nil || count < 0 {
+		return 0, 0, false
+	}
+	size, err = strconv.Atoi(right)
+	if err != nil || size < 1 {
+		return 0, 0, false
+	}
+	return count, size, true
+}
+
+// abilityAmount rolls an ability's damage or healing, with a bonus for the
+// mobile's proficiency as for players.
+func abilityAmount(formula string, m *Mobile, proficiency int) int {
+	amount := rollFormula(formula, m) + proficiency/20
+	if amount < 1 {
+		amount = 1
+	}
+	return amount
+}
+
+// hitMessage is what the target of a mobile's attack sees, using the
+// spell's hit message and adding the damage if it doesn't mention it.
+func hitMessage(spell *skills.Spell) string {
+	hit := spell.Messages.Hit
+	if hit == "" {
+		hit = spell.Messages.CastRoom
+	}
+	if !strings.Contains(hit, "$damage") {
+		hit = strings.TrimRight(hit, ".!") + " for $damage damage!"
+	}
+	return "&R" + hit + "&w"
+}
+
+// missMessage is what the target of a mobile's attack sees when a save or
+// immunity cancels it.
+func missMessage(spell *skills.Spell) string {
+	if spell.Messages.Miss != "" {
+		return spell.Messages.Miss
+	}
+	return "$n's $spell has no effect on you."
+}
+
+// mobVars returns the act() substitutions for a mobile's ability.
+func mobVars(spell *skills.Spell, amount int) ActVars {
+	return ActVars{
+		"spell":   spell.Name,
+		"damage":  strconv.Itoa(amount),
+		"healing": strconv.Itoa(amount),
+	}
+}
@@ -0,0 +1,109 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"njata/internal/skills"
+)
+
+func loadMobSpells(t *testing.T) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "skills.json")
+	data := `[
+		{"id": 1001, "name": "Arcane Bolt", "mana_cost": 15, "cooldown_seconds": 60,
+		 "targeting": {"mode": "hostile_single"},
+		 "effects": {"damage": "5 + I/2"},
+		 "messages": {"hit": "$actor's arcane bolt strikes you!", "cast_room": "$actor hurls an arcane bolt at $target."}},
+		{"id": 1003, "name": "Mend", "mana_cost": 20, "cooldown_seconds": 60,
+		 "targeting": {"mode": "ally_single"},
+		 "effects": {"healing": "10"},
+		 "messages": {"hit": "Golden light washes over $target, mending $healing wounds!", "cast_room": "$actor casts Mend on $target."}}
+	]`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("write skills: %v", err)
+	}
+	t.Cleanup(skills.Preserve())
+	if err := skills.Load(path); err != nil {
+		t.Fatalf("load skills: %v", err)
+	}
+}
+
+func TestRollFormula(t *testing.T) {
+	mob := &Mobile{Level: 3}
+	mob.Attributes[mobAttributeInt] = 10
+	mob.Attributes[mobAttributeWis] = 10
+
+	if got := rollFormula("5 + I/2 + l", mob); got != 13 {
+		t.Fatalf("expected 13, got %d", got)
+	}
+	if got := rollFormula("W*1.5", mob); got != 15 {
+		t.Fatalf("expected 15, got %d", got)
+	}
+	if got := rollFormula("2d1 + 1", mob); got != 3 {
+		t.Fatalf("expected 3, got %d", got)
+	}
+}
+
+func TestMobCastsSpellThenWaitsForCooldown(t *testing.T) {
+	loadMobSpells(t)
+	world, player := newMobAIWorld(t)
+	mage := placeMob(world, 1, &Mobile{
+		Short:   "a goblin mage",
+		Mana:    50,
+		MaxMana: 50,
+		Skills:  []MobSkill{{SpellID: 1001, Proficiency: 100}},
+	})
+	mage.Attributes[mobAttributeInt] = 10
+
+	world.mobCounterAttack(player, mage)
+
+	out := player.Output.(*bufferOutput)
+	if !out.Contains("A goblin mage's arcane bolt strikes you for 15 damage!") {
+		t.Fatalf("expected the bolt to hit, got %v", out.lines)
+	}
+	if mage.Mana != 35 || player.HP != 85 {
+		t.Fatalf("expected mana spent and damage dealt, mana %d HP %d", mage.Mana, player.HP)
+	}
+
+	world.mobCounterAttack(player, mage)
+	if mage.Mana != 35 || !out.Contains("A goblin mage strikes you") {
+		t.Fatalf("expected a melee strike while the bolt cools down, got %v", out.lines)
+	}
+}
+
+func TestMobHealsWhenHurt(t *testing.T) {
+	loadMobSpells(t)
+	world, player := newMobAIWorld(t)
+	shaman := placeMob(world, 1, &Mobile{
+		Short:   "a goblin shaman",
+		MaxHP:   40,
+		HP:      10,
+		Mana:    50,
+		MaxMana: 50,
+		Skills:  []MobSkill{{SpellID: 1001, Proficiency: 100}, {SpellID: 1003, Proficiency: 100}},
+	})
+
+	world.mobCounterAttack(player, shaman)
+
+	if shaman.HP != 25 || player.HP != 100 {
+		t.Fatalf("expected the shaman to heal instead of attacking, HP %d player HP %d", shaman.HP, player.HP)
+	}
+	if !player.Output.(*bufferOutput).Contains("mending 15 wounds") {
+		t.Fatalf("expected the heal to be announced, got %v", player.Output.(*bufferOutput).lines)
+	}
+}
+
+func TestMobWithoutManaFightsInMelee(t *testing.T) {
+	loadMobSpells(t)
+	world, player := newMobAIWorld(t)
+	mage := placeMob(world, 1, &Mobile{Short: "a goblin mage", Skills: []MobSkill{{SpellID: 1001, Proficiency: 100}}})
+
+	world.mobCounterAttack(player, mage)
+
+	if !player.Output.(*bufferOutput).Contains("A goblin mage strikes you") {
+		t.Fatalf("expected a melee strike, got %v", player.Output.(*bufferOutput).lines)
+	}
+}
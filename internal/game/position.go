@@ -0,0 +1,130 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Character positions. An empty position counts as standing.
+const (
+	PositionStanding = "standing"
+	PositionSitting  = "sitting"
+	PositionResting  = "resting"
+	PositionSleeping = "sleeping"
+)
+
+// Position errors, worded for the player.
+var (
+	ErrAsleep      = errors.New("In your dreams, or what?")
+	ErrNotStanding = errors.New("You need to stand up first.")
+)
+
+// positionOf normalizes a stored position, treating empty as standing.
+func positionOf(pos string) string {
+	if pos == "" {
+		return PositionStanding
+	}
+	return pos
+}
+
+// IsAsleep reports whether the player is sleeping.
+func IsAsleep(p *Player) bool {
+	return positionOf(p.Position) == PositionSleeping
+}
+
+// positionChange holds the messages for moving into a position, for actors
+// who start out awake and for those being woken.
+type positionChange struct {
+	already    string
+	toActor    string
+	toRoom     string
+	wakeActor  string
+	wakeToRoom string
+}
+
+var positionChanges = map[string]positionChange{
+	PositionStanding: {
+		already:    "You are already standing.",
+		toActor:    "You stand up.",
+		toRoom:     "$n stands up.",
+		wakeActor:  "You wake and stand up.",
+		wakeToRoom: "$n wakes and stands up.",
+	},
+	PositionSitting: {
+		already:    "You are already sitting.",
+		toActor:    "You sit down.",
+		toRoom:     "$n sits down.",
+		wakeActor:  "You wake and sit up.",
+		wakeToRoom: "$n wakes and sits up.",
+	},
+	PositionResting: {
+		already:    "You are already resting.",
+		toActor:    "You sit down and rest.",
+		toRoom:     "$n sits down and rests.",
+		wakeActor:  "You wake and sit up to rest.",
+		wakeToRoom: "$n wakes and sits up to rest.",
+	},
+	PositionSleeping: {
+		already: "You are already sleeping.",
+		toActor: "You lie down and go to sleep.",
+		toRoom:  "$n lies down and goes to sleep.",
+	},
+}
+
+// ChangePosition moves the player into pos and tells the room.
+func (w *World) ChangePosition(p *Player, pos string) error {
+	change, ok := positionChanges[pos]
+	if !ok {
+		return fmt.Errorf("unknown position %q", pos)
+	}
+
+	w.mu.Lock()
+	current := positionOf(p.Position)
+	if current == pos {
+		w.mu.Unlock()
+		return errors.New(change.already)
+	}
+	p.Position = pos
+	w.mu.Unlock()
+
+	toActor, toRoom := change.toActor, change.toRoom
+	if current == PositionSleeping {
+		toActor, toRoom = change.wakeActor, change.wakeToRoom
+	}
+	w.Act(toActor, PlayerSubject(p), Subject{}, nil, ToActor)
+	w.Act(toRoom, PlayerSubject(p), Subject{}, nil, ToRoom)
+	return nil
+}
+
+// WakePlayer has actor shake a sleeping player awake. The sleeper is left
+// resting.
+func (w *World) WakePlayer(actor *Player, target *Player) error {
+	if IsAsleep(actor) {
+		return ErrAsleep
+	}
+
+	w.mu.Lock()
+	if !IsAsleep(target) {
+		w.mu.Unlock()
+		return fmt.Errorf("%s is already awake.", CapitalizeName(target.Name))
+	}
+	target.Position = PositionResting
+	w.mu.Unlock()
+
+	self, victim := PlayerSubject(actor), PlayerSubject(target)
+	w.Act("You wake $N.", self, victim, nil, ToActor)
+	w.Act("$n wakes you.", self, victim, nil, ToTarget)
+	w.Act("$n wakes $N.", self, victim, nil, ToNotTarget)
+	return nil
+}
+
+// checkCanMove reports whether the player's position lets them walk away.
+func checkCanMove(p *Player) error {
+	switch positionOf(p.Position) {
+	case PositionSleeping:
+		return ErrAsleep
+	case PositionSitting, PositionResting:
+		return ErrNotStanding
+	}
+	return nil
+}
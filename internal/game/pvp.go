@@ -0,0 +1,138 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// PvPToggleCooldown is how long a player must wait between turning
+// player-versus-player combat on and off, so nobody can dodge a fight.
+const PvPToggleCooldown = 10 * time.Minute
+
+// pvpScoreGap is how much stronger (as a percentage of combat score) an
+// attacker may be than the player they attack.
+const pvpScoreGap = 150
+
+// pkillLogSize is how many player kills the world remembers.
+const pkillLogSize = 100
+
+var (
+	ErrPvPOff      = errors.New("You have not chosen to fight other players. See 'help pvp'.")
+	ErrPvPCooldown = errors.New("You changed your mind too recently. Try again later.")
+)
+
+// PKill records one player slaying another.
+type PKill struct {
+	When   time.Time `json:"when"`
+	Killer string    `json:"killer"`
+	Victim string    `json:"victim"`
+	Room   int       `json:"room"`
+}
+
+// CombatScore rates how dangerous a player is in a fight, from their hit
+// points, attributes and armor.
+func CombatScore(p *Player) int {
+	if p == nil {
+		return 1
+	}
+
+	score := 0
+	score += p.MaxHP
+	score += p.Strength * 5
+	score += p.Dexterity * 3
+	score += p.Constitution * 4
+	score += p.Armor * 2
+	if score < 1 {
+		score = 1
+	}
+	return score
+}
+
+// SetPvP turns the player's willingness to fight other players on or off.
+func (w *World) SetPvP(p *Player, on bool) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if p.PvP == on {
+		return nil
+	}
+	if !p.PvPToggled.IsZero() && time.Since(p.PvPToggled) < PvPToggleCooldown {
+		return ErrPvPCooldown
+	}
+	p.PvP = on
+	p.PvPToggled = time.Now()
+	return nil
+}
+
+// CanAttackPlayer reports whether attacker may attack victim: both must have
+// chosen to fight other players, the room must not be safe, and the victim
+// must not be far weaker than the attacker.
+func (w *World) CanAttackPlayer(attacker, victim *Player) error {
+	if err := w.CanFight(attacker); err != nil {
+		return err
+	}
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	name := CapitalizeName(victim.Name)
+	switch {
+	case victim == attacker:
+		return errors.New("You can't attack yourself.")
+	case victim.Location != attacker.Location:
+		return errors.New("They aren't here.")
+	case !attacker.PvP:
+		return ErrPvPOff
+	case !victim.PvP:
+		return fmt.Errorf("%s has not chosen to fight other players.", name)
+	case SameGroup(attacker, victim):
+		return fmt.Errorf("%s is in your group!", name)
+	case victim.LinkDead:
+		return fmt.Errorf("%s is link-dead; leave them be.", name)
+	case CombatScore(attacker)*100 > CombatScore(victim)*pvpScoreGap:
+		return fmt.Errorf("%s is no match for you; pick on someone your own size.", name)
+	}
+	return nil
+}
+
+// DamagePlayer deals damage to victim on attacker's behalf. A killing blow
+// is logged, and the victim suffers the usual death penalties.
+func (w *World) DamagePlayer(attacker, victim *Player, damage int) (died bool) {
+	w.mu.Lock()
+	now := time.Now()
+	victim.HP -= damage
+	victim.LastCombat = now
+	attacker.LastCombat = now
+	if IsAsleep(victim) {
+		victim.Position = PositionStanding
+	}
+	died = victim.HP <= 0
+	if died {
+		w.pkills = append(w.pkills, PKill{When: now, Killer: CapitalizeName(attacker.Name), Victim: CapitalizeName(victim.Name), Room: victim.Location})
+		if len(w.pkills) > pkillLogSize {
+			w.pkills = w.pkills[len(w.pkills)-pkillLogSize:]
+		}
+	}
+	w.mu.Unlock()
+
+	if died {
+		w.Act("&RYou have slain $N!&w", PlayerSubject(attacker), PlayerSubject(victim), nil, ToActor)
+		w.killPlayer(victim)
+	}
+	return died
+}
+
+// PKills returns the logged player kills, oldest first.
+func (w *World) PKills() []PKill {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return append([]PKill(nil), w.pkills...)
+}
+
+// SetPKills restores a saved player-kill log.
+func (w *World) SetPKills(kills []PKill) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.pkills = append([]PKill(nil), kills...)
+}
@@ -0,0 +1,103 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+func newPvPWorld(t *testing.T) (*World, *Player, *Player) {
+	t.Helper()
+
+	world, alice, bob := newGroupWorld(t)
+	for _, p := range []*Player{alice, bob} {
+		p.Strength, p.Dexterity, p.Constitution, p.Luck = 10, 10, 10, 10
+		if err := world.SetPvP(p, true); err != nil {
+			t.Fatalf("pvp on: %v", err)
+		}
+	}
+	return world, alice, bob
+}
+
+func TestSetPvPCooldown(t *testing.T) {
+	world, alice := newMobAIWorld(t)
+
+	if err := world.SetPvP(alice, true); err != nil {
+		t.Fatalf("first toggle should be free: %v", err)
+	}
+	if err := world.SetPvP(alice, false); err != ErrPvPCooldown {
+		t.Fatalf("expected cooldown, got %v", err)
+	}
+	if !alice.PvP {
+		t.Fatalf("flag should not change during the cooldown")
+	}
+
+	alice.PvPToggled = time.Now().Add(-PvPToggleCooldown)
+	if err := world.SetPvP(alice, false); err != nil || alice.PvP {
+		t.Fatalf("expected toggle after cooldown, got %v", err)
+	}
+}
+
+func TestCanAttackPlayer(t *testing.T) {
+	world, alice, bob := newPvPWorld(t)
+
+	if err := world.CanAttackPlayer(alice, bob); err != nil {
+		t.Fatalf("expected a fair fight, got %v", err)
+	}
+	if err := world.CanAttackPlayer(alice, alice); err == nil {
+		t.Fatalf("expected attacking yourself to be refused")
+	}
+
+	bob.PvP = false
+	if err := world.CanAttackPlayer(alice, bob); err == nil {
+		t.Fatalf("expected an unflagged victim to be protected")
+	}
+	bob.PvP = true
+
+	alice.PvP = false
+	if err := world.CanAttackPlayer(alice, bob); err != ErrPvPOff {
+		t.Fatalf("expected an unflagged attacker to be refused, got %v", err)
+	}
+	alice.PvP = true
+
+	world.rooms[1].Flags[RoomFlagSafe] = true
+	if err := world.CanAttackPlayer(alice, bob); err != ErrSafeRoom {
+		t.Fatalf("expected safe room, got %v", err)
+	}
+	delete(world.rooms[1].Flags, RoomFlagSafe)
+
+	alice.MaxHP, alice.Strength = 500, 40
+	if err := world.CanAttackPlayer(alice, bob); err == nil {
+		t.Fatalf("expected a much stronger attacker to be refused")
+	}
+	if err := world.CanAttackPlayer(bob, alice); err != nil {
+		t.Fatalf("the weaker player may still attack, got %v", err)
+	}
+	alice.MaxHP, alice.Strength = 100, 10
+
+	groupUp(t, world, alice, bob)
+	if err := world.CanAttackPlayer(alice, bob); err == nil {
+		t.Fatalf("expected group members to be protected")
+	}
+}
+
+func TestDamagePlayerLogsKill(t *testing.T) {
+	world, alice, bob := newPvPWorld(t)
+
+	if died := world.DamagePlayer(alice, bob, 10); died || bob.HP != 90 {
+		t.Fatalf("expected bob wounded, hp %d", bob.HP)
+	}
+	if len(world.PKills()) != 0 {
+		t.Fatalf("no kill should be logged yet")
+	}
+
+	if died := world.DamagePlayer(alice, bob, 200); !died {
+		t.Fatalf("expected bob to die")
+	}
+	kills := world.PKills()
+	if len(kills) != 1 || kills[0].Killer != "Alice" || kills[0].Victim != "Bob" || kills[0].Room != 1 {
+		t.Fatalf("unexpected kill log %+v", kills)
+	}
+	if !alice.Output.(*bufferOutput).Contains("You have slain Bob!") {
+		t.Fatalf("expected alice to be told of the kill")
+	}
+}
@@ -0,0 +1,392 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+
+	"njata/internal/quests"
+	"njata/internal/skills"
+)
+
+// LedgerQuests is the ledger source for gold paid out as quest rewards.
+const LedgerQuests = "quest rewards"
+
+// questTeachAmount is the proficiency a spell taught as a quest reward
+// starts at, the same as studying a tome.
+const questTeachAmount = 30
+
+// ErrNoQuest is returned when a quest can't be found among those the player
+// has taken.
+var ErrNoQuest = errors.New("You aren't on a quest like that.")
+
+// QuestOffer is a quest a mobile in the player's room is offering.
+type QuestOffer struct {
+	Quest *quests.Quest
+	Giver *Mobile
+}
+
+// ObjectiveStatus is how far a player has got with one quest objective.
+type ObjectiveStatus struct {
+	Objective quests.Objective
+	Have      int
+	Need      int
+}
+
+// Done reports whether the objective has been met.
+func (s ObjectiveStatus) Done() bool {
+	return s.Have >= s.Need
+}
+
+// questTaken reports whether the player is on or has finished the quest.
+// Callers hold w.mu.
+func questTaken(p *Player, id int) bool {
+	_, ok := p.Quests[id]
+	return ok
+}
+
+// questCompleted reports whether the player has handed the quest in.
+// Callers hold w.mu.
+func questCompleted(p *Player, id int) bool {
+	progress, ok := p.Quests[id]
+	return ok && progress.Completed
+}
+
+// prerequisitesMet reports whether the player has completed every quest q
+// depends on. Callers hold w.mu.
+func prerequisitesMet(p *Player, q *quests.Quest) bool {
+	for _, id := range q.Prerequisites {
+		if !questCompleted(p, id) {
+			return false
+		}
+	}
+	return true
+}
+
+// questGiver returns the awake mobile in the player's room that gives q.
+// Callers hold w.mu.
+func (w *World) questGiver(p *Player, q *quests.Quest) *Mobile {
+	room, ok := w.rooms[p.Location]
+	if !ok {
+		return nil
+	}
+	for _, mob := range room.Mobiles {
+		if mob.Vnum == q.Giver && mobAwake(mob) && !mobInCombat(mob) {
+			return mob
+		}
+	}
+	return nil
+}
+
+// offeredQuests lists the quests givers in the player's room have for them.
+// Callers hold w.mu.
+func (w *World) offeredQuests(p *Player) []QuestOffer {
+	var offers []QuestOffer
+	for _, q := range quests.AllQuests() {
+		if questTaken(p, q.ID) || !prerequisitesMet(p, q) {
+			continue
+		}
+		if giver := w.questGiver(p, q); giver != nil {
+			offers = append(offers, QuestOffer{Quest: q, Giver: giver})
+		}
+	}
+	return offers
+}
+
+// OfferedQuests lists the quests givers in the player's room have for them.
+func (w *World) OfferedQuests(p *Player) []QuestOffer {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.offeredQuests(p)
+}
+
+// PlayerQuests returns the quests the player is on and those they have
+// completed, ordered by ID.
+func (w *World) PlayerQuests(p *Player) (active, completed []*quests.Quest) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	for _, q := range quests.AllQuests() {
+		if progress, ok := p.Quests[q.ID]; ok {
+			if progress.Completed {
+				completed = append(completed, q)
+			} else {
+				active = append(active, q)
+			}
+		}
+	}
+	return active, completed
+}
+
+// activeQuest finds the quest matching arg that the player is on.
+// Callers hold w.mu.
+func activeQuest(p *Player, arg string) (*quests.Quest, *quests.PlayerQuestProgress) {
+	for _, q := range quests.AllQuests() {
+		progress, ok := p.Quests[q.ID]
+		if ok && !progress.Completed && q.Match(arg) {
+			return q, progress
+		}
+	}
+	return nil, nil
+}
+
+// FindQuest finds the quest matching arg among those the player is on, has
+// completed, or is being offered, and reports whether they have taken it.
+func (w *World) FindQuest(p *Player, arg string) (*quests.Quest, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if q, _ := activeQuest(p, arg); q != nil {
+		return q, true
+	}
+	for _, offer := range w.offeredQuests(p) {
+		if offer.Quest.Match(arg) {
+			return offer.Quest, false
+		}
+	}
+	for _, q := range quests.AllQuests() {
+		if questCompleted(p, q.ID) && q.Match(arg) {
+			return q, true
+		}
+	}
+	return nil, false
+}
+
+// objectiveStatus works out how far the player has got with each of q's
+// objectives. Kills and visits are counted as they happen; fetched objects
+// and studied spells are checked against what the player has now.
+// Callers hold w.mu.
+func objectiveStatus(p *Player, q *quests.Quest, progress *quests.PlayerQuestProgress) []ObjectiveStatus {
+	statuses := make([]ObjectiveStatus, len(q.Objectives))
+	for i, objective := range q.Objectives {
+		status := ObjectiveStatus{Objective: objective, Need: objective.Needed()}
+		switch objective.Type {
+		case quests.ObjectiveKill, quests.ObjectiveVisit:
+			if progress != nil && i < len(progress.Counts) {
+				status.Have = progress.Counts[i]
+			}
+		case quests.ObjectiveFetch:
+			for _, obj := range p.Inventory {
+				if obj.Vnum == objective.Vnum {
+					status.Have++
+				}
+			}
+		case quests.ObjectiveStudy:
+			if known, ok := p.Skills[objective.SpellID]; ok && known.Learned {
+				status.Have = 1
+			}
+		}
+		if progress != nil && progress.Completed {
+			status.Have = status.Need
+		}
+		status.Have = min(status.Have, status.Need)
+		statuses[i] = status
+	}
+	return statuses
+}
+
+// QuestObjectives returns the player's progress on each of q's objectives.
+func (w *World) QuestObjectives(p *Player, q *quests.Quest) []ObjectiveStatus {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return objectiveStatus(p, q, p.Quests[q.ID])
+}
+
+// AcceptQuest takes on the quest matching arg from a giver in the room.
+func (w *World) AcceptQuest(p *Player, arg string) (*quests.Quest, error) {
+	if IsAsleep(p) {
+		return nil, ErrAsleep
+	}
+
+	w.mu.Lock()
+	var offer *QuestOffer
+	for _, o := range w.offeredQuests(p) {
+		if o.Quest.Match(arg) {
+			offer = &o
+			break
+		}
+	}
+	if offer == nil {
+		w.mu.Unlock()
+		return nil, errors.New("Nobody here is offering a quest like that.")
+	}
+	q := offer.Quest
+	if p.Quests == nil {
+		p.Quests = make(map[int]*quests.PlayerQuestProgress)
+	}
+	p.Quests[q.ID] = &quests.PlayerQuestProgress{QuestID: q.ID, Counts: make([]int, len(q.Objectives))}
+	w.mu.Unlock()
+
+	self, giver := PlayerSubject(p), MobileSubject(offer.Giver)
+	vars := ActVars{"quest": q.Name, "message": q.AcceptText}
+	if q.AcceptText != "" {
+		w.Act("$n tells you '$message'", giver, self, vars, ToTarget)
+	}
+	w.Act("&YYou have accepted the quest '$quest'.&w", self, giver, vars, ToActor)
+	w.Act("$n speaks quietly with $N.", self, giver, nil, ToRoom)
+	return q, nil
+}
+
+// AbandonQuest gives up the quest matching arg, forgetting any progress.
+func (w *World) AbandonQuest(p *Player, arg string) (*quests.Quest, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	q, _ := activeQuest(p, arg)
+	if q == nil {
+		return nil, ErrNoQuest
+	}
+	delete(p.Quests, q.ID)
+	return q, nil
+}
+
+// CompleteQuest hands in the quest matching arg to its giver, who must be in
+// the room, and pays out its rewards. Fetched objects go to the giver.
+func (w *World) CompleteQuest(p *Player, arg string) (*quests.Quest, error) {
+	if IsAsleep(p) {
+		return nil, ErrAsleep
+	}
+
+	w.mu.Lock()
+	q, progress := activeQuest(p, arg)
+	if q == nil {
+		w.mu.Unlock()
+		return nil, ErrNoQuest
+	}
+	giver := w.questGiver(p, q)
+	if giver == nil {
+		w.mu.Unlock()
+		return nil, fmt.Errorf("You must return to whoever gave you '%s'.", q.Name)
+	}
+	for _, status := range objectiveStatus(p, q, progress) {
+		if !status.Done() {
+			w.mu.Unlock()
+			return nil, w.keeperSays(p, giver, "Come back when you have finished the task I set you.")
+		}
+	}
+
+	for _, objective := range q.Objectives {
+		if objective.Type == quests.ObjectiveFetch {
+			takeObjects(p, objective.Vnum, objective.Needed())
+		}
+	}
+	progress.Completed = true
+	progress.Counts = nil
+	rewards := w.payQuestRewards(p, q.Rewards)
+	w.mu.Unlock()
+
+	self, them := PlayerSubject(p), MobileSubject(giver)
+	vars := ActVars{"quest": q.Name, "message": q.CompleteText}
+	if q.CompleteText != "" {
+		w.Act("$n tells you '$message'", them, self, vars, ToTarget)
+	}
+	w.Act("&YYou have completed the quest '$quest'!&w", self, them, vars, ToActor)
+	w.Act("$N thanks $n warmly.", self, them, nil, ToRoom)
+	for _, line := range rewards {
+		p.Output.WriteLine(line)
+	}
+	return q, nil
+}
+
+// takeObjects removes up to count objects of vnum from the player's
+// inventory. Callers hold w.mu.
+func takeObjects(p *Player, vnum, count int) {
+	kept := p.Inventory[:0]
+	for _, obj := range p.Inventory {
+		if obj.Vnum == vnum && count > 0 {
+			count--
+			continue
+		}
+		kept = append(kept, obj)
+	}
+	p.Inventory = kept
+}
+
+// payQuestRewards grants a quest's rewards, returning a line describing each.
+// Callers hold w.mu.
+func (w *World) payQuestRewards(p *Player, rewards quests.Rewards) []string {
+	var lines []string
+	if rewards.Gold > 0 {
+		p.Gold += rewards.Gold
+		w.mintGold(LedgerQuests, rewards.Gold)
+		lines = append(lines, fmt.Sprintf("You receive %d gold.", rewards.Gold))
+	}
+	for _, vnum := range rewards.Items {
+		proto, ok := w.objects[vnum]
+		if !ok || proto == nil {
+			continue
+		}
+		obj := *proto
+		p.Inventory = append(p.Inventory, &obj)
+		lines = append(lines, fmt.Sprintf("You receive %s.", obj.Short))
+	}
+	if p.Skills == nil {
+		p.Skills = make(map[int]*skills.PlayerSkillProgress)
+	}
+	if reward := rewards.Proficiency; reward != nil && reward.Amount > 0 {
+		if known, ok := p.Skills[reward.SpellID]; ok && known.Learned {
+			known.Proficiency = min(known.Proficiency+reward.Amount, 100)
+			if spell := skills.GetSpell(reward.SpellID); spell != nil {
+				lines = append(lines, fmt.Sprintf("Your proficiency in %s rises to %d%%.", spell.Name, known.Proficiency))
+			}
+		}
+	}
+	if id := rewards.TeachSpell; id != 0 {
+		spell := skills.GetSpell(id)
+		if known, ok := p.Skills[id]; spell != nil && (!ok || !known.Learned) {
+			p.Skills[id] = &skills.PlayerSkillProgress{SpellID: id, Proficiency: questTeachAmount, Learned: true}
+			lines = append(lines, fmt.Sprintf("You learn %s!", spell.Name))
+		}
+	}
+	return lines
+}
+
+// questAdvance counts one kill of, or visit to, vnum towards the player's
+// quests, returning progress notes to show them. Callers hold w.mu.
+func questAdvance(p *Player, objectiveType string, vnum int) []string {
+	var notes []string
+	for _, q := range quests.AllQuests() {
+		progress, ok := p.Quests[q.ID]
+		if !ok || progress.Completed {
+			continue
+		}
+		for i, objective := range q.Objectives {
+			if objective.Type != objectiveType || objective.Vnum != vnum {
+				continue
+			}
+			if len(progress.Counts) < len(q.Objectives) {
+				progress.Counts = append(progress.Counts, make([]int, len(q.Objectives)-len(progress.Counts))...)
+			}
+			need := objective.Needed()
+			if progress.Counts[i] >= need {
+				continue
+			}
+			progress.Counts[i]++
+			note := fmt.Sprintf("&YQuest '%s': %s (%d/%d)&w", q.Name, objective.Description, progress.Counts[i], need)
+			notes = append(notes, note)
+		}
+	}
+	return notes
+}
+
+// questKill credits the player with killing mob. Callers hold w.mu.
+func questKill(p *Player, mob *Mobile) []string {
+	return questAdvance(p, quests.ObjectiveKill, mob.Vnum)
+}
+
+// questVisit credits the player with reaching room vnum. Callers hold w.mu.
+func questVisit(p *Player, vnum int) []string {
+	return questAdvance(p, quests.ObjectiveVisit, vnum)
+}
+
+// placePlayer puts p in the room at vnum and credits any visit objectives
+// it completes, returning the notes to show them once the lock is released.
+// Every way of entering a room goes through here. Callers hold w.mu.
+func placePlayer(p *Player, vnum int) []string {
+	p.Location = vnum
+	return questVisit(p, vnum)
+}
+
+// writeNotes shows the player each note.
+func writeNotes(p *Player, notes []string) {
+	for _, note := range notes {
+		p.Output.WriteLine(note)
+	}
+}
@@ -0,0 +1,173 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"njata/internal/quests"
+	"njata/internal/skills"
+)
+
+func newQuestWorld(t *testing.T) (*World, *Player, *Mobile) {
+	t.Helper()
+
+	loadMobSpells(t)
+	quests.Set([]quests.Quest{
+		{
+			ID: 1, Name: "Wolf Trouble", Giver: 50,
+			Objectives: []quests.Objective{
+				{Type: quests.ObjectiveKill, Vnum: 60, Count: 2, Description: "Slay wolves"},
+				{Type: quests.ObjectiveVisit, Vnum: 3, Description: "Reach the road"},
+			},
+			Rewards: quests.Rewards{Gold: 40, Items: []int{20}, TeachSpell: 1003},
+		},
+		{
+			ID: 2, Name: "Lost Pelts", Giver: 50, Prerequisites: []int{1},
+			Objectives: []quests.Objective{
+				{Type: quests.ObjectiveFetch, Vnum: 21, Count: 2, Description: "Bring back wolf pelts"},
+				{Type: quests.ObjectiveStudy, SpellID: 1001, Description: "Learn Arcane Bolt"},
+			},
+			Rewards: quests.Rewards{Proficiency: &quests.ProficiencyReward{SpellID: 1001, Amount: 15}},
+		},
+	})
+	t.Cleanup(func() { quests.Set(nil) })
+
+	world, player := newMobAIWorld(t)
+	player.Move = 100
+	world.SetPrototypes(nil, map[int]*Object{20: {Vnum: 20, Keywords: []string{"charm"}, Short: "a wolf-tooth charm"}})
+	ranger := placeMob(world, 1, &Mobile{Vnum: 50, Short: "the ranger", Keywords: []string{"ranger"}, Position: PositionStanding})
+	return world, player, ranger
+}
+
+func TestQuestOffersFollowPrerequisites(t *testing.T) {
+	world, player, _ := newQuestWorld(t)
+
+	offers := world.OfferedQuests(player)
+	if len(offers) != 1 || offers[0].Quest.ID != 1 {
+		t.Fatalf("expected only the first quest on offer, got %+v", offers)
+	}
+	if _, err := world.AcceptQuest(player, "pelts"); err == nil {
+		t.Fatalf("expected the second quest to be locked")
+	}
+	if _, err := world.AcceptQuest(player, "wolf"); err != nil {
+		t.Fatalf("accept: %v", err)
+	}
+	if len(world.OfferedQuests(player)) != 0 {
+		t.Fatalf("expected an accepted quest to no longer be offered")
+	}
+
+	player.Location = 2
+	if _, err := world.AcceptQuest(player, "wolf"); err == nil {
+		t.Fatalf("expected no giver away from the ranger")
+	}
+}
+
+func TestKillAndVisitObjectives(t *testing.T) {
+	world, player, _ := newQuestWorld(t)
+	if _, err := world.AcceptQuest(player, "1"); err != nil {
+		t.Fatalf("accept: %v", err)
+	}
+
+	if _, err := world.CompleteQuest(player, "wolf"); err == nil || !strings.Contains(err.Error(), "Come back when") {
+		t.Fatalf("expected the ranger to want the job done first, got %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		wolf := placeMob(world, 1, &Mobile{Vnum: 60, Short: "a wolf", Keywords: []string{"wolf"}})
+		world.DamageMob(player, wolf, 100)
+	}
+	if !player.Output.(*bufferOutput).Contains("Quest 'Wolf Trouble': Slay wolves (2/2)") {
+		t.Fatalf("expected kill progress to be reported")
+	}
+	if _, err := world.MovePlayer(player, "east"); err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	if _, err := world.MovePlayer(player, "east"); err != nil {
+		t.Fatalf("move: %v", err)
+	}
+
+	q := quests.GetQuest(1)
+	for _, status := range world.QuestObjectives(player, q) {
+		if !status.Done() || status.Have != status.Need {
+			t.Fatalf("expected every objective met, got %+v", status)
+		}
+	}
+
+	player.Location = 1
+	if _, err := world.CompleteQuest(player, "wolf"); err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+	if player.Gold != 40 || len(player.Inventory) != 1 || player.Inventory[0].Vnum != 20 {
+		t.Fatalf("expected gold and a charm, gold %d inventory %d", player.Gold, len(player.Inventory))
+	}
+	if known := player.Skills[1003]; known == nil || !known.Learned {
+		t.Fatalf("expected Mend to be taught")
+	}
+	if ledger := world.Ledger(); ledger.Created[LedgerQuests] != 40 {
+		t.Fatalf("expected the reward in the ledger, got %+v", ledger)
+	}
+	if _, completed := world.PlayerQuests(player); len(completed) != 1 {
+		t.Fatalf("expected the quest to be recorded as completed")
+	}
+}
+
+func TestTeleportCreditsVisitObjectives(t *testing.T) {
+	world, player, _ := newQuestWorld(t)
+	if _, err := world.AcceptQuest(player, "1"); err != nil {
+		t.Fatalf("accept: %v", err)
+	}
+
+	if err := world.TeleportPlayer(player, 3); err != nil {
+		t.Fatalf("teleport: %v", err)
+	}
+	if !player.Output.(*bufferOutput).Contains("Quest 'Wolf Trouble': Reach the road") {
+		t.Fatalf("expected arriving by magic to count as a visit")
+	}
+}
+
+func TestFetchAndStudyObjectives(t *testing.T) {
+	world, player, _ := newQuestWorld(t)
+	player.Quests = map[int]*quests.PlayerQuestProgress{1: {QuestID: 1, Completed: true}}
+	if _, err := world.AcceptQuest(player, "pelts"); err != nil {
+		t.Fatalf("accept: %v", err)
+	}
+
+	pelt := &Object{Vnum: 21, Keywords: []string{"pelt"}, Short: "a wolf pelt"}
+	player.Inventory = []*Object{pelt, {Vnum: 21, Keywords: []string{"pelt"}, Short: "a wolf pelt"}}
+	if _, err := world.CompleteQuest(player, "pelts"); err == nil {
+		t.Fatalf("expected the spell to still need studying")
+	}
+
+	player.Skills = map[int]*skills.PlayerSkillProgress{1001: {SpellID: 1001, Proficiency: 30, Learned: true}}
+	if _, err := world.CompleteQuest(player, "pelts"); err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+	if len(player.Inventory) != 0 {
+		t.Fatalf("expected the pelts to be handed over, %d left", len(player.Inventory))
+	}
+	if player.Skills[1001].Proficiency != 45 {
+		t.Fatalf("expected proficiency to rise to 45, got %d", player.Skills[1001].Proficiency)
+	}
+}
+
+func TestAbandonQuest(t *testing.T) {
+	world, player, _ := newQuestWorld(t)
+	if _, err := world.AbandonQuest(player, "wolf"); err != ErrNoQuest {
+		t.Fatalf("expected nothing to abandon, got %v", err)
+	}
+	if _, err := world.AcceptQuest(player, "wolf"); err != nil {
+		t.Fatalf("accept: %v", err)
+	}
+	wolf := placeMob(world, 1, &Mobile{Vnum: 60, Short: "a wolf", Keywords: []string{"wolf"}})
+	world.DamageMob(player, wolf, 100)
+
+	if _, err := world.AbandonQuest(player, "wolf"); err != nil {
+		t.Fatalf("abandon: %v", err)
+	}
+	if _, err := world.AcceptQuest(player, "wolf"); err != nil {
+		t.Fatalf("expected to be able to take the quest again: %v", err)
+	}
+	if status := world.QuestObjectives(player, quests.GetQuest(1)); status[0].Have != 0 {
+		t.Fatalf("expected progress to start over, got %d", status[0].Have)
+	}
+}
@@ -0,0 +1,114 @@
+package game
+
+import (
+	"strings"
+	"time"
+
+	"njata/internal/races"
+)
+
+// combatRegenDelay is how long after a fight natural regeneration stays off.
+const combatRegenDelay = 30 * time.Second
+
+// Regeneration per world update. A character standing around regains
+// 1/regenDivisor of their maximum plus an attribute bonus, scaled by their
+// race's regen percentage and by how restfully they are positioned.
+const (
+	regenDivisor      = 20 // base gain is a twentieth of the maximum
+	regenStatDivisor  = 5  // plus a point per five Constitution (HP) or Wisdom (mana)
+	moveRegenDivisor  = 10 // movement comes back faster: a tenth of MaxMove
+	defaultRegenRate  = 100
+	percentMultiplier = 100
+)
+
+// positionRegen is the regen multiplier, in percent, for each position.
+var positionRegen = map[string]int{
+	PositionStanding: 100,
+	PositionSitting:  150,
+	PositionResting:  200,
+	PositionSleeping: 300,
+}
+
+// regenAmount works out one update's gain towards max.
+func regenAmount(max, stat, racePercent int, position string) int {
+	if max <= 0 {
+		return 0
+	}
+	gain := max/regenDivisor + stat/regenStatDivisor
+	if gain < 1 {
+		gain = 1
+	}
+	if racePercent <= 0 {
+		racePercent = defaultRegenRate
+	}
+	gain = gain * racePercent / percentMultiplier
+	gain = gain * positionRegen[positionOf(position)] / percentMultiplier
+	if gain < 1 {
+		gain = 1
+	}
+	return gain
+}
+
+// restore adds gain to current without exceeding max.
+func restore(current *int, max, gain int) {
+	if *current >= max {
+		return
+	}
+	*current += gain
+	if *current > max {
+		*current = max
+	}
+}
+
+// InCombat reports whether the player fought recently enough that they
+// can't recover naturally.
+func InCombat(p *Player) bool {
+	return !p.LastCombat.IsZero() && time.Since(p.LastCombat) < combatRegenDelay
+}
+
+// regenPlayer restores a player's HP, mana and movement for one update.
+// Callers hold w.mu.
+func regenPlayer(p *Player) {
+	if InCombat(p) || regenSuppressed(p) {
+		return
+	}
+
+	hpRate, manaRate := defaultRegenRate, defaultRegenRate
+	if race := races.GetByID(p.Race); race != nil {
+		hpRate, manaRate = race.HPRegen, race.ManaRegen
+	}
+
+	restore(&p.HP, p.MaxHP, regenAmount(p.MaxHP, p.Constitution, hpRate, p.Position))
+	restore(&p.Mana, p.MaxMana, regenAmount(p.MaxMana, p.Wisdom, manaRate, p.Position))
+
+	moveGain := p.MaxMove / moveRegenDivisor * positionRegen[positionOf(p.Position)] / percentMultiplier
+	if moveGain < 1 {
+		moveGain = 1
+	}
+	restore(&p.Move, p.MaxMove, moveGain)
+}
+
+// mobRace finds the race definition named by a mobile's race, if any.
+func mobRace(name string) *races.RaceJSON {
+	for _, race := range races.List() {
+		if strings.EqualFold(race.Name, name) {
+			return race
+		}
+	}
+	return nil
+}
+
+// regenMobile restores a mobile's HP and mana for one update. Callers hold w.mu.
+func regenMobile(m *Mobile) {
+	if mobInCombat(m) {
+		return
+	}
+
+	hpRate, manaRate := defaultRegenRate, defaultRegenRate
+	if race := mobRace(m.Race); race != nil {
+		hpRate, manaRate = race.HPRegen, race.ManaRegen
+	}
+
+	restore(&m.HP, m.MaxHP, regenAmount(m.MaxHP, m.Attributes[mobAttributeCon], hpRate, m.Position))
+	restore(&m.Mana, m.MaxMana, regenAmount(m.MaxMana, m.Attributes[mobAttributeWis], manaRate, m.Position))
+}
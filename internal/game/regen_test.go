@@ -0,0 +1,146 @@
+package game
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func newRegenWorld(t *testing.T) (*World, *Player) {
+	t.Helper()
+
+	rooms := map[int]*Room{
+		1: {Vnum: 1, Name: "Glade", Sector: "field", Flags: map[string]bool{}, Exits: map[string]int{"north": 2}},
+		2: {Vnum: 2, Name: "Path", Sector: "field", Flags: map[string]bool{}, Exits: map[string]int{"south": 1}},
+	}
+	world := CreateWorldFromRooms(rooms, 1)
+
+	player := &Player{Name: "alice", Output: &bufferOutput{}, HP: 10, MaxHP: 100, Mana: 0, MaxMana: 100}
+	if err := world.AddPlayer(player); err != nil {
+		t.Fatalf("add player: %v", err)
+	}
+	return world, player
+}
+
+func TestRegenScalesWithPosition(t *testing.T) {
+	world, player := newRegenWorld(t)
+
+	world.UpdateTick()
+	standing := player.HP - 10
+	if standing <= 0 || player.Mana <= 0 {
+		t.Fatalf("expected standing regen, HP %d mana %d", player.HP, player.Mana)
+	}
+
+	player.HP = 10
+	player.Position = PositionSleeping
+	world.UpdateTick()
+	if sleeping := player.HP - 10; sleeping <= standing {
+		t.Fatalf("expected sleeping (%d) to heal faster than standing (%d)", sleeping, standing)
+	}
+}
+
+func TestRegenUsesConstitution(t *testing.T) {
+	world, player := newRegenWorld(t)
+	player.Constitution = 20
+
+	world.UpdateTick()
+	if want := 10 + 100/regenDivisor + 20/regenStatDivisor; player.HP != want {
+		t.Fatalf("expected HP %d, got %d", want, player.HP)
+	}
+}
+
+func TestCombatAndAffectsSuppressRegen(t *testing.T) {
+	world, player := newRegenWorld(t)
+
+	player.LastCombat = time.Now()
+	world.UpdateTick()
+	if player.HP != 10 {
+		t.Fatalf("expected no regen in combat, HP %d", player.HP)
+	}
+
+	player.LastCombat = time.Time{}
+	world.AddAffect(player, Affect{Name: "poison", NoRegen: true})
+	world.UpdateTick()
+	if player.HP != 10 {
+		t.Fatalf("expected poison to block regen, HP %d", player.HP)
+	}
+
+	world.AddAffect(player, Affect{Name: "poison", NoRegen: true, Expires: time.Now().Add(-time.Second)})
+	world.UpdateTick()
+	if world.HasAffect(player, "poison") {
+		t.Fatalf("expected poison to wear off")
+	}
+	if !player.Output.(*bufferOutput).Contains("Your poison wears off.") {
+		t.Fatalf("expected wear-off message")
+	}
+}
+
+func TestMobilesRegenerate(t *testing.T) {
+	world, _ := newRegenWorld(t)
+	mob := &Mobile{Keywords: []string{"wolf"}, Short: "a wolf", HP: 5, MaxHP: 40}
+	world.rooms[1].Mobiles = append(world.rooms[1].Mobiles, mob)
+
+	world.UpdateTick()
+	if mob.HP <= 5 {
+		t.Fatalf("expected mobile to regenerate, HP %d", mob.HP)
+	}
+
+	mob.HP = 5
+	mob.LastCombat = time.Now()
+	world.UpdateTick()
+	if mob.HP != 5 {
+		t.Fatalf("expected fighting mobile not to regenerate, HP %d", mob.HP)
+	}
+}
+
+func TestPositionsRestrictMovement(t *testing.T) {
+	world, player := newRegenWorld(t)
+
+	if err := world.ChangePosition(player, PositionResting); err != nil {
+		t.Fatalf("rest: %v", err)
+	}
+	if _, err := world.MovePlayer(player, "north"); !errors.Is(err, ErrNotStanding) {
+		t.Fatalf("expected resting player to need to stand, got %v", err)
+	}
+
+	if err := world.ChangePosition(player, PositionSleeping); err != nil {
+		t.Fatalf("sleep: %v", err)
+	}
+	if err := world.ChangePosition(player, PositionSleeping); err == nil {
+		t.Fatalf("expected error sleeping twice")
+	}
+	if _, err := world.MovePlayer(player, "north"); !errors.Is(err, ErrAsleep) {
+		t.Fatalf("expected sleeping player to be stuck, got %v", err)
+	}
+	if err := world.CanFight(player); !errors.Is(err, ErrAsleep) {
+		t.Fatalf("expected sleeping player unable to fight, got %v", err)
+	}
+
+	if err := world.ChangePosition(player, PositionStanding); err != nil {
+		t.Fatalf("stand: %v", err)
+	}
+	if !player.Output.(*bufferOutput).Contains("You wake and stand up.") {
+		t.Fatalf("expected wake message")
+	}
+	if _, err := world.MovePlayer(player, "north"); err != nil {
+		t.Fatalf("expected standing player to move: %v", err)
+	}
+}
+
+func TestWakePlayer(t *testing.T) {
+	world, alice := newRegenWorld(t)
+	bob := &Player{Name: "bob", Output: &bufferOutput{}, Position: PositionSleeping}
+	if err := world.AddPlayer(bob); err != nil {
+		t.Fatalf("add player: %v", err)
+	}
+
+	if err := world.WakePlayer(alice, bob); err != nil {
+		t.Fatalf("wake: %v", err)
+	}
+	if IsAsleep(bob) || !bob.Output.(*bufferOutput).Contains("Alice wakes you.") {
+		t.Fatalf("expected bob to be woken by alice")
+	}
+	if err := world.WakePlayer(alice, bob); err == nil {
+		t.Fatalf("expected error waking an awake player")
+	}
+}
@@ -0,0 +1,210 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// Room flags with gameplay rules attached.
+const (
+	RoomFlagSafe         = "safe"         // no combat
+	RoomFlagNoMob        = "nomob"        // mobiles may not wander or be summoned in
+	RoomFlagDeath        = "death"        // death trap: entering kills the player
+	RoomFlagSilence      = "silence"      // no speech or channels
+	RoomFlagNoMagic      = "nomagic"      // no spellcasting
+	RoomFlagNoRecall     = "norecall"     // recall and teleport magic fail
+	RoomFlagNoDrop       = "nodrop"       // items can't be dropped
+	RoomFlagPrivate      = "private"      // at most two players
+	RoomFlagSolitary     = "solitary"     // at most one player
+	RoomFlagTeleport     = "teleport"     // entering whisks the player elsewhere in the area
+	RoomFlagTeleShowDesc = "teleshowdesc" // show the teleport room before moving on
+)
+
+// Occupancy limits for private and solitary rooms.
+const (
+	privateRoomLimit  = 2
+	solitaryRoomLimit = 1
+)
+
+// Rule errors, worded for the player.
+var (
+	ErrSafeRoom = errors.New("A peaceful aura here keeps you from fighting.")
+	ErrNoMagic  = errors.New("Your magic fizzles; something here smothers it.")
+	ErrSilence  = errors.New("You can't seem to make a sound here.")
+	ErrNoDrop   = errors.New("Something keeps you from dropping things here.")
+	ErrNoRecall = errors.New("The magic here binds you to this place.")
+	ErrRoomFull = errors.New("That room is private right now.")
+	ErrNoMob    = errors.New("Creatures can't be brought into this room.")
+)
+
+// roomFlag reports whether the room at vnum carries flag. Callers hold w.mu.
+func (w *World) roomFlag(vnum int, flag string) bool {
+	room, ok := w.rooms[vnum]
+	return ok && room.Flags[flag]
+}
+
+// RoomHasFlag reports whether the room at vnum carries flag.
+func (w *World) RoomHasFlag(vnum int, flag string) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.roomFlag(vnum, flag)
+}
+
+// CanFight reports whether the player may start combat where they stand.
+// Sleepers can't fight at all.
+func (w *World) CanFight(p *Player) error {
+	if IsAsleep(p) {
+		return ErrAsleep
+	}
+	if w.RoomHasFlag(p.Location, RoomFlagSafe) {
+		return ErrSafeRoom
+	}
+	return nil
+}
+
+// CanCastHere reports whether the player may cast spells where they stand.
+func (w *World) CanCastHere(p *Player) error {
+	if IsAsleep(p) {
+		return ErrAsleep
+	}
+	if w.RoomHasFlag(p.Location, RoomFlagNoMagic) && !p.IsKeeper {
+		return ErrNoMagic
+	}
+	return nil
+}
+
+// CanSpeak reports whether the player may speak or use channels where they stand.
+func (w *World) CanSpeak(p *Player) error {
+	if w.RoomHasFlag(p.Location, RoomFlagSilence) && !p.IsKeeper {
+		return ErrSilence
+	}
+	return nil
+}
+
+// CanDrop reports whether the player may drop items where they stand.
+func (w *World) CanDrop(p *Player) error {
+	if w.RoomHasFlag(p.Location, RoomFlagNoDrop) && !p.IsKeeper {
+		return ErrNoDrop
+	}
+	return nil
+}
+
+// CanRecall reports whether recall or teleport magic may take the player away.
+func (w *World) CanRecall(p *Player) error {
+	if w.RoomHasFlag(p.Location, RoomFlagNoRecall) && !p.IsKeeper {
+		return ErrNoRecall
+	}
+	return nil
+}
+
+// CanEnter reports whether the player may enter the room at vnum, enforcing
+// private and solitary occupancy limits. Keepers are never turned away.
+func (w *World) CanEnter(p *Player, vnum int) error {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.canEnter(p, vnum)
+}
+
+func (w *World) canEnter(p *Player, vnum int) error {
+	if p.IsKeeper {
+		return nil
+	}
+
+	room, ok := w.rooms[vnum]
+	if !ok {
+		return fmt.Errorf("room not found")
+	}
+
+	limit := 0
+	switch {
+	case room.Flags[RoomFlagSolitary]:
+		limit = solitaryRoomLimit
+	case room.Flags[RoomFlagPrivate]:
+		limit = privateRoomLimit
+	default:
+		return nil
+	}
+
+	occupants := 0
+	for _, other := range w.players {
+		if other != p && other.Location == vnum {
+			occupants++
+		}
+	}
+	if occupants >= limit {
+		return ErrRoomFull
+	}
+	return nil
+}
+
+// MobCanEnter reports whether a wandering or summoned mobile may enter the room at vnum.
+func (w *World) MobCanEnter(vnum int) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	room, ok := w.rooms[vnum]
+	return ok && !room.Flags[RoomFlagNoMob]
+}
+
+// ApplyRoomEntry runs the effects of the room the player has just entered:
+// death traps kill them and teleport rooms send them elsewhere in the area.
+// It returns true if the player ended up in a different room.
+func (w *World) ApplyRoomEntry(p *Player) bool {
+	if p.IsKeeper {
+		return false
+	}
+
+	w.mu.RLock()
+	room, ok := w.rooms[p.Location]
+	w.mu.RUnlock()
+	if !ok {
+		return false
+	}
+
+	if room.Flags[RoomFlagDeath] {
+		p.Output.WriteLine("&ROh no... you have stumbled into a death trap!&w")
+		w.Act("$n stumbles into a death trap!", PlayerSubject(p), Subject{}, nil, ToRoom)
+		w.killPlayer(p)
+		return true
+	}
+
+	if room.Flags[RoomFlagTeleport] {
+		if dest, ok := w.teleportDestination(room); ok {
+			w.Act("$n vanishes!", PlayerSubject(p), Subject{}, nil, ToRoom)
+			w.mu.Lock()
+			notes := placePlayer(p, dest)
+			w.mu.Unlock()
+			p.Output.WriteLine("The world spins around you!")
+			writeNotes(p, notes)
+			w.Act("$n appears out of thin air.", PlayerSubject(p), Subject{}, nil, ToRoom)
+			return true
+		}
+	}
+
+	return false
+}
+
+// teleportDestination picks a random safe room in the same area as room.
+func (w *World) teleportDestination(room *Room) (int, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	candidates := make([]int, 0)
+	for vnum, other := range w.rooms {
+		if vnum == room.Vnum || other.AreaName != room.AreaName {
+			continue
+		}
+		if other.Flags[RoomFlagTeleport] || other.Flags[RoomFlagDeath] || other.Flags[RoomFlagPrivate] || other.Flags[RoomFlagSolitary] {
+			continue
+		}
+		candidates = append(candidates, vnum)
+	}
+	if len(candidates) == 0 {
+		return 0, false
+	}
+
+	sort.Ints(candidates)
+	return candidates[rand.Intn(len(candidates))], true
+}
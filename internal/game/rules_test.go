@@ -0,0 +1,84 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+func newRulesWorld(t *testing.T) (*World, *Player, *bufferOutput) {
+	t.Helper()
+
+	rooms := map[int]*Room{
+		1: {Vnum: 1, Name: "Hall", AreaName: "test", Flags: map[string]bool{}, Exits: map[string]int{"north": 2, "east": 3, "west": 4}},
+		2: {Vnum: 2, Name: "Shrine", AreaName: "test", Flags: map[string]bool{RoomFlagSafe: true, RoomFlagSilence: true, RoomFlagNoMagic: true, RoomFlagNoDrop: true, RoomFlagNoMob: true}, Exits: map[string]int{"south": 1}},
+		3: {Vnum: 3, Name: "Pit", AreaName: "test", Flags: map[string]bool{RoomFlagDeath: true}, Exits: map[string]int{}},
+		4: {Vnum: 4, Name: "Closet", AreaName: "test", Flags: map[string]bool{RoomFlagSolitary: true}, Exits: map[string]int{"east": 1}},
+	}
+	world := CreateWorldFromRooms(rooms, 1)
+
+	out := &bufferOutput{}
+	player := &Player{Name: "alice", HP: 20, MaxHP: 20, Output: out}
+	if err := world.AddPlayer(player); err != nil {
+		t.Fatalf("add player: %v", err)
+	}
+	return world, player, out
+}
+
+func TestRoomFlagRestrictions(t *testing.T) {
+	world, player, _ := newRulesWorld(t)
+	player.Location = 2
+
+	if err := world.CanFight(player); !errors.Is(err, ErrSafeRoom) {
+		t.Errorf("expected ErrSafeRoom, got %v", err)
+	}
+	if err := world.CanCastHere(player); !errors.Is(err, ErrNoMagic) {
+		t.Errorf("expected ErrNoMagic, got %v", err)
+	}
+	if err := world.BroadcastSay(player, "hello"); !errors.Is(err, ErrSilence) {
+		t.Errorf("expected ErrSilence, got %v", err)
+	}
+	if err := world.CanDrop(player); !errors.Is(err, ErrNoDrop) {
+		t.Errorf("expected ErrNoDrop, got %v", err)
+	}
+	if world.MobCanEnter(2) {
+		t.Errorf("expected nomob room to refuse mobiles")
+	}
+
+	player.Location = 1
+	if err := world.CanFight(player); err != nil {
+		t.Errorf("expected combat to be allowed, got %v", err)
+	}
+}
+
+func TestSolitaryRoomLimit(t *testing.T) {
+	world, player, _ := newRulesWorld(t)
+	other := &Player{Name: "bob", Location: 4, Output: &bufferOutput{}}
+	world.AddPlayer(other)
+	other.Location = 4
+
+	if _, err := world.MovePlayer(player, "west"); !errors.Is(err, ErrRoomFull) {
+		t.Fatalf("expected ErrRoomFull, got %v", err)
+	}
+
+	player.IsKeeper = true
+	if _, err := world.MovePlayer(player, "west"); err != nil {
+		t.Fatalf("expected keeper to enter, got %v", err)
+	}
+}
+
+func TestDeathTrapKillsAndMoves(t *testing.T) {
+	world, player, out := newRulesWorld(t)
+
+	if _, err := world.MovePlayer(player, "east"); err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	if !world.ApplyRoomEntry(player) {
+		t.Fatalf("expected death trap to move the player")
+	}
+	if player.Location != world.StartRoom() || player.HP != 1 {
+		t.Fatalf("expected player at start with 1 HP, got room %d hp %d", player.Location, player.HP)
+	}
+	if !out.Contains("death trap") {
+		t.Fatalf("expected death trap message")
+	}
+}
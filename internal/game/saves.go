@@ -0,0 +1,161 @@
+package game
+
+import (
+	"math/rand"
+	"strings"
+
+	"njata/internal/races"
+	"njata/internal/skills"
+)
+
+// Saving throw kinds, from a spell's "save_type".
+const (
+	SaveReflex    = "reflex"    // Dexterity
+	SaveWill      = "will"      // Wisdom
+	SaveFortitude = "fortitude" // Constitution
+)
+
+// What a successful save does, from a spell's "save_effect".
+const (
+	SaveHalf   = "half"   // half damage; the default
+	SaveNegate = "negate" // no effect at all
+)
+
+// How a target's race or nature stands up to a damage type.
+const (
+	DefenseImmune     = "immune"
+	DefenseResistant  = "resistant"
+	DefenseVulnerable = "vulnerable"
+)
+
+// HitResult is how an attack spell or maneuver lands on its target once
+// the target's saving throw and damage defenses have been applied.
+type HitResult struct {
+	Damage  int
+	Saved   bool   // the target made its saving throw
+	Negated bool   // the save or an immunity cancelled the attack entirely
+	Defense string // "", DefenseImmune, DefenseResistant or DefenseVulnerable
+}
+
+// saveStats returns the attribute a saving throw of saveType draws on, and
+// the subject's Luck.
+func saveStats(s Subject, saveType string) (stat, luck int, ok bool) {
+	letter := byte(0)
+	switch strings.ToLower(saveType) {
+	case SaveReflex:
+		letter = 'D'
+	case SaveWill:
+		letter = 'W'
+	case SaveFortitude:
+		letter = 'C'
+	default:
+		return 0, 0, false
+	}
+
+	switch {
+	case s.Player != nil:
+		p := s.Player
+		switch letter {
+		case 'D':
+			stat = p.Dexterity
+		case 'W':
+			stat = p.Wisdom
+		case 'C':
+			stat = p.Constitution
+		}
+		return stat, p.Luck, true
+	case s.Mobile != nil:
+		return mobStat(s.Mobile, letter), mobStat(s.Mobile, 'L'), true
+	}
+	return 0, 0, false
+}
+
+// SavingThrow rolls the subject's save of saveType (reflex, will or
+// fortitude) against dc: a d20 plus bonuses from the matching attribute and
+// Luck. Spells without a save never succeed.
+func SavingThrow(s Subject, saveType string, dc int) bool {
+	stat, luck, ok := saveStats(s, saveType)
+	if !ok {
+		return false
+	}
+	roll := rand.Intn(20) + 1 + (stat-10)/2 + (luck-10)/4
+	return roll >= dc
+}
+
+// DamageDefense reports whether the subject is immune, resistant or
+// vulnerable to damageType. Players take their defenses from their race;
+// mobiles from their race and their own area file entry.
+func DamageDefense(s Subject, damageType string) string {
+	if damageType == "" || damageType == "none" {
+		return ""
+	}
+
+	var immune, resistant, vulnerable []string
+	switch {
+	case s.Player != nil:
+		if race := races.GetByID(s.Player.Race); race != nil {
+			immune, resistant, vulnerable = race.Immunities, race.Resistances, race.Vulnerabilities
+		}
+	case s.Mobile != nil:
+		m := s.Mobile
+		immune, resistant, vulnerable = m.Immunities, m.Resistances, m.Vulnerabilities
+		if race := mobRace(m.Race); race != nil {
+			immune = append(append([]string(nil), immune...), race.Immunities...)
+			resistant = append(append([]string(nil), resistant...), race.Resistances...)
+			vulnerable = append(append([]string(nil), vulnerable...), race.Vulnerabilities...)
+		}
+	}
+
+	switch {
+	case hasDamageType(immune, damageType):
+		return DefenseImmune
+	case hasDamageType(resistant, damageType):
+		return DefenseResistant
+	case hasDamageType(vulnerable, damageType):
+		return DefenseVulnerable
+	}
+	return ""
+}
+
+func hasDamageType(types []string, damageType string) bool {
+	for _, t := range types {
+		if strings.EqualFold(t, damageType) {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveHit applies target's saving throw and damage defenses to damage
+// from an attack spell or maneuver. A save halves the damage, or negates it
+// for spells whose save_effect is "negate"; resistance halves it again,
+// vulnerability adds half, and immunity negates it.
+func ResolveHit(spell *skills.Spell, target Subject, damage int) HitResult {
+	result := HitResult{Damage: damage}
+	if SavingThrow(target, spell.Effects.SaveType, spell.Effects.SaveDC) {
+		result.Saved = true
+		if strings.EqualFold(spell.Effects.SaveEffect, SaveNegate) {
+			result.Negated = true
+			result.Damage = 0
+			return result
+		}
+		result.Damage /= 2
+	}
+
+	result.Defense = DamageDefense(target, spell.Effects.DamageType)
+	switch result.Defense {
+	case DefenseImmune:
+		result.Negated = true
+		result.Damage = 0
+		return result
+	case DefenseResistant:
+		result.Damage /= 2
+	case DefenseVulnerable:
+		result.Damage += result.Damage / 2
+	}
+
+	if damage > 0 && result.Damage < 1 {
+		result.Damage = 1
+	}
+	return result
+}
@@ -0,0 +1,82 @@
+package game
+
+import (
+	"testing"
+
+	"njata/internal/skills"
+)
+
+func TestSavingThrowBounds(t *testing.T) {
+	p := &Player{Dexterity: 10, Luck: 10}
+	mob := &Mobile{}
+	mob.Attributes[mobAttributeWis] = 10
+	mob.Attributes[mobAttributeLck] = 10
+
+	for i := 0; i < 50; i++ {
+		if SavingThrow(PlayerSubject(p), "none", 1) {
+			t.Fatalf("a spell without a save can't be saved against")
+		}
+		if !SavingThrow(PlayerSubject(p), SaveReflex, 1) || !SavingThrow(MobileSubject(mob), SaveWill, 1) {
+			t.Fatalf("a DC 1 save should always succeed")
+		}
+		if SavingThrow(PlayerSubject(p), SaveReflex, 21) || SavingThrow(MobileSubject(mob), SaveWill, 21) {
+			t.Fatalf("a DC 21 save should never succeed with average stats")
+		}
+	}
+}
+
+func TestDamageDefense(t *testing.T) {
+	loadRace(t, `{"name": "Firebird", "race_id": 8, "immunities": ["fire"], "vulnerabilities": ["cold"]}`)
+
+	player := PlayerSubject(&Player{Race: 8})
+	if got := DamageDefense(player, "fire"); got != DefenseImmune {
+		t.Fatalf("expected a firebird to be immune to fire, got %q", got)
+	}
+	if got := DamageDefense(player, "cold"); got != DefenseVulnerable {
+		t.Fatalf("expected a firebird to be vulnerable to cold, got %q", got)
+	}
+	if got := DamageDefense(player, "magic"); got != "" {
+		t.Fatalf("expected no defense against magic, got %q", got)
+	}
+
+	// Mobiles combine their race's defenses with their own
+	bird := MobileSubject(&Mobile{Race: "firebird", Resistances: []string{"magic"}})
+	if got := DamageDefense(bird, "fire"); got != DefenseImmune {
+		t.Fatalf("expected the mobile's race to count, got %q", got)
+	}
+	if got := DamageDefense(bird, "magic"); got != DefenseResistant {
+		t.Fatalf("expected the mobile's own resistance to count, got %q", got)
+	}
+}
+
+func TestResolveHit(t *testing.T) {
+	mob := &Mobile{Resistances: []string{"cold"}, Vulnerabilities: []string{"fire"}, Immunities: []string{"magic"}}
+	mob.Attributes[mobAttributeDex] = 10
+	mob.Attributes[mobAttributeLck] = 10
+	target := MobileSubject(mob)
+	spell := func(damageType, saveType string, dc int, effect string) *skills.Spell {
+		return &skills.Spell{Effects: skills.Effects{DamageType: damageType, SaveType: saveType, SaveDC: dc, SaveEffect: effect}}
+	}
+
+	if got := ResolveHit(spell("physical", "none", 0, ""), target, 10); got.Damage != 10 || got.Saved || got.Defense != "" {
+		t.Fatalf("expected full damage, got %+v", got)
+	}
+	if got := ResolveHit(spell("physical", SaveReflex, 1, ""), target, 10); got.Damage != 5 || !got.Saved {
+		t.Fatalf("expected a save to halve damage, got %+v", got)
+	}
+	if got := ResolveHit(spell("physical", SaveReflex, 1, SaveNegate), target, 10); got.Damage != 0 || !got.Negated {
+		t.Fatalf("expected a save to negate damage, got %+v", got)
+	}
+	if got := ResolveHit(spell("cold", "none", 0, ""), target, 10); got.Damage != 5 || got.Defense != DefenseResistant {
+		t.Fatalf("expected resistance to halve damage, got %+v", got)
+	}
+	if got := ResolveHit(spell("fire", "none", 0, ""), target, 10); got.Damage != 15 || got.Defense != DefenseVulnerable {
+		t.Fatalf("expected vulnerability to add half, got %+v", got)
+	}
+	if got := ResolveHit(spell("magic", "none", 0, ""), target, 10); got.Damage != 0 || !got.Negated {
+		t.Fatalf("expected immunity to negate damage, got %+v", got)
+	}
+	if got := ResolveHit(spell("cold", SaveReflex, 1, ""), target, 1); got.Damage != 1 {
+		t.Fatalf("expected a hit to deal at least 1 damage, got %+v", got)
+	}
+}
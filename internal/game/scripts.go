@@ -0,0 +1,510 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"njata/internal/script"
+)
+
+// maxScriptDepth stops scripts from setting each other off without end, such
+// as a greet script that transfers the player into another greet room.
+const maxScriptDepth = 5
+
+var errNoActor = errors.New("no player set this script off")
+
+// scriptOwner is the room, mobile or object a script belongs to.
+type scriptOwner struct {
+	room int
+	mob  *Mobile
+	obj  *Object
+}
+
+func (o scriptOwner) scripts(w *World) []script.Script {
+	switch {
+	case o.mob != nil:
+		return o.mob.Scripts
+	case o.obj != nil:
+		return o.obj.Scripts
+	}
+	if room, ok := w.rooms[o.room]; ok {
+		return room.Scripts
+	}
+	return nil
+}
+
+// name is what $i expands to.
+func (o scriptOwner) name(w *World) string {
+	switch {
+	case o.mob != nil:
+		return MobileSubject(o.mob).name()
+	case o.obj != nil:
+		return o.obj.Short
+	}
+	if room, ok := w.rooms[o.room]; ok {
+		return room.Name
+	}
+	return "somewhere"
+}
+
+// label identifies the owner in error reports.
+func (o scriptOwner) label() string {
+	switch {
+	case o.mob != nil:
+		return fmt.Sprintf("mobile %d (%s)", o.mob.Vnum, o.mob.Short)
+	case o.obj != nil:
+		return fmt.Sprintf("object %d (%s)", o.obj.Vnum, o.obj.Short)
+	}
+	return fmt.Sprintf("room %d", o.room)
+}
+
+// scriptsIn lists the script owners in a room: the room itself, then its
+// mobiles and objects. Callers hold w.mu.
+func (w *World) scriptsIn(vnum int) []scriptOwner {
+	room, ok := w.rooms[vnum]
+	if !ok {
+		return nil
+	}
+	owners := []scriptOwner{{room: vnum}}
+	for _, m := range room.Mobiles {
+		if len(m.Scripts) > 0 {
+			owners = append(owners, scriptOwner{room: vnum, mob: m})
+		}
+	}
+	for _, obj := range room.Objects {
+		if len(obj.Scripts) > 0 {
+			owners = append(owners, scriptOwner{room: vnum, obj: obj})
+		}
+	}
+	return owners
+}
+
+// pendingScript is a script due to run once the lock is released.
+type pendingScript struct {
+	owner  scriptOwner
+	script script.Script
+}
+
+// collectScripts picks the scripts on owners with the given trigger whose
+// argument accepts the event. Callers hold w.mu.
+func (w *World) collectScripts(owners []scriptOwner, trigger string, accept func(arg string) bool) []pendingScript {
+	var due []pendingScript
+	for _, owner := range owners {
+		for _, s := range owner.scripts(w) {
+			if script.NormalizeTrigger(s.Trigger) == trigger && accept(s.Arg) {
+				due = append(due, pendingScript{owner, s})
+			}
+		}
+	}
+	return due
+}
+
+// chance accepts a percent-chance trigger argument.
+func chance(arg string) bool {
+	return rand.Intn(percentMultiplier) < script.Percent(arg)
+}
+
+// runScripts runs each pending script in room, set off by actor (who may be
+// nil). text is what $t expands to.
+func (w *World) runScripts(due []pendingScript, room int, actor *Player, text string, depth int) {
+	for _, p := range due {
+		w.runScript(p.owner, p.script, room, actor, text, depth)
+	}
+}
+
+func (w *World) runScript(owner scriptOwner, s script.Script, room int, actor *Player, text string, depth int) {
+	if depth >= maxScriptDepth {
+		w.reportScriptError(owner, s, fmt.Errorf("scripts nested more than %d deep", maxScriptDepth))
+		return
+	}
+	prog, err := s.Program()
+	if err != nil {
+		w.reportScriptError(owner, s, err)
+		return
+	}
+
+	w.mu.RLock()
+	vars := script.Vars{"n": "someone", "i": owner.name(w), "t": text}
+	if actor != nil {
+		vars["n"] = CapitalizeName(actor.Name)
+	}
+	w.mu.RUnlock()
+
+	host := &scriptHost{w: w, owner: owner, room: room, actor: actor, depth: depth}
+	if err := prog.Run(host, vars); err != nil {
+		w.reportScriptError(owner, s, err)
+	}
+}
+
+// reportScriptError tells every keeper online that a script failed.
+func (w *World) reportScriptError(owner scriptOwner, s script.Script, err error) {
+	line := fmt.Sprintf("&R[Script] %s, %s trigger: %v&w", owner.label(), s.Trigger, err)
+	for _, p := range w.PlayersSnapshot() {
+		if p.IsKeeper {
+			p.Output.WriteLine(line)
+		}
+	}
+}
+
+// RunSpeechTriggers fires speech scripts in the speaker's room.
+func (w *World) RunSpeechTriggers(speaker *Player, message string) {
+	w.mu.RLock()
+	room := speaker.Location
+	due := w.collectScripts(w.scriptsIn(room), script.TriggerSpeech, func(arg string) bool {
+		return script.SpeechMatches(arg, message)
+	})
+	w.mu.RUnlock()
+
+	w.runScripts(due, room, speaker, message, 0)
+}
+
+// RunGreetTriggers fires greet scripts in the room the player has just entered.
+func (w *World) RunGreetTriggers(p *Player) {
+	w.runGreetTriggers(p, 0)
+}
+
+func (w *World) runGreetTriggers(p *Player, depth int) {
+	w.mu.RLock()
+	room := p.Location
+	due := w.collectScripts(w.scriptsIn(room), script.TriggerGreet, chance)
+	w.mu.RUnlock()
+
+	w.runScripts(due, room, p, "", depth)
+}
+
+// runExitTriggers fires exit scripts in the room the player has just left.
+func (w *World) runExitTriggers(p *Player, from int, direction string) {
+	w.mu.RLock()
+	due := w.collectScripts(w.scriptsIn(from), script.TriggerExit, func(arg string) bool {
+		arg = strings.TrimSpace(arg)
+		return arg == "" || strings.EqualFold(arg, direction)
+	})
+	w.mu.RUnlock()
+
+	w.runScripts(due, from, p, direction, 0)
+}
+
+// runMobileTriggers fires one mobile's scripts for trigger, with a percent
+// chance argument, in the room at vnum.
+func (w *World) runMobileTriggers(mob *Mobile, trigger string, vnum int, actor *Player) {
+	w.mu.RLock()
+	due := w.collectScripts([]scriptOwner{{room: vnum, mob: mob}}, trigger, chance)
+	w.mu.RUnlock()
+
+	w.runScripts(due, vnum, actor, "", 0)
+}
+
+// runGiveTriggers fires the mobile's give scripts that accept obj.
+func (w *World) runGiveTriggers(giver *Player, mob *Mobile, obj *Object) {
+	w.mu.RLock()
+	room := giver.Location
+	due := w.collectScripts([]scriptOwner{{room: room, mob: mob}}, script.TriggerGive, func(arg string) bool {
+		arg = strings.TrimSpace(arg)
+		return strings.EqualFold(arg, "all") || len(SelectObjects([]*Object{obj}, ParseTarget(arg))) > 0
+	})
+	w.mu.RUnlock()
+
+	w.runScripts(due, room, giver, obj.Short, 0)
+}
+
+// RunCommandTriggers runs command scripts for the word the player typed,
+// from their room and from anything they carry or wear. It returns true if a
+// script took the command, so the normal command should not run.
+func (w *World) RunCommandTriggers(p *Player, command string, args string) bool {
+	w.mu.RLock()
+	room := p.Location
+	owners := w.scriptsIn(room)
+	for _, obj := range p.Inventory {
+		if len(obj.Scripts) > 0 {
+			owners = append(owners, scriptOwner{room: room, obj: obj})
+		}
+	}
+	for _, slot := range EquipSlotOrder {
+		if obj := p.Equipment[slot]; obj != nil && len(obj.Scripts) > 0 {
+			owners = append(owners, scriptOwner{room: room, obj: obj})
+		}
+	}
+	due := w.collectScripts(owners, script.TriggerCommand, func(arg string) bool {
+		for _, word := range strings.Fields(arg) {
+			if strings.EqualFold(word, command) {
+				return true
+			}
+		}
+		return false
+	})
+	w.mu.RUnlock()
+
+	if len(due) == 0 {
+		return false
+	}
+	w.runScripts(due, room, p, args, 0)
+	return true
+}
+
+// scriptUpdate fires random scripts in areas with players in them, and timer
+// scripts everywhere, for world update number tick.
+func (w *World) scriptUpdate(tick int) {
+	type run struct {
+		due  []pendingScript
+		room int
+	}
+	var runs []run
+
+	w.mu.RLock()
+	occupied := map[string]bool{}
+	for _, p := range w.players {
+		if room, ok := w.rooms[p.Location]; ok {
+			occupied[room.AreaName] = true
+		}
+	}
+	for vnum, room := range w.rooms {
+		owners := w.scriptsIn(vnum)
+		var due []pendingScript
+		if occupied[room.AreaName] {
+			due = append(due, w.collectScripts(owners, script.TriggerRandom, chance)...)
+		}
+		due = append(due, w.collectScripts(owners, script.TriggerTimer, func(arg string) bool {
+			interval, err := script.Interval(arg)
+			return err == nil && tick%interval == 0
+		})...)
+		if len(due) > 0 {
+			runs = append(runs, run{due, vnum})
+		}
+	}
+	w.mu.RUnlock()
+
+	for _, r := range runs {
+		w.runScripts(r.due, r.room, nil, "", 0)
+	}
+}
+
+// scriptHost carries out a script's commands in the world.
+type scriptHost struct {
+	w     *World
+	owner scriptOwner
+	room  int // where the script is running
+	actor *Player
+	depth int
+}
+
+// toRoom sends template to the players in the script's room, except skip.
+// The owner, if a mobile, is $n; the script's text is $text.
+func (h *scriptHost) toRoom(template, text string, skip *Player) {
+	var actor Subject
+	if h.owner.mob != nil {
+		actor = MobileSubject(h.owner.mob)
+	}
+	vars := ActVars{"text": text}
+
+	type delivery struct {
+		player *Player
+		line   string
+	}
+	var deliveries []delivery
+	h.w.mu.RLock()
+	for _, p := range h.w.players {
+		if p.Location == h.room && p != skip {
+			deliveries = append(deliveries, delivery{p, h.w.formatAct(template, p, actor, Subject{}, vars)})
+		}
+	}
+	h.w.mu.RUnlock()
+
+	for _, d := range deliveries {
+		d.player.Output.WriteLine(d.line)
+	}
+}
+
+func (h *scriptHost) Say(text string) error {
+	if h.owner.mob == nil {
+		return errors.New("only a mobile can say things")
+	}
+	h.toRoom("$n says '$text'", text, nil)
+	return nil
+}
+
+func (h *scriptHost) Emote(text string) error {
+	if h.owner.mob == nil {
+		return errors.New("only a mobile can emote")
+	}
+	h.toRoom("$n $text", text, nil)
+	return nil
+}
+
+func (h *scriptHost) Echo(text string) error {
+	h.toRoom("$text", text, nil)
+	return nil
+}
+
+func (h *scriptHost) EchoAt(text string) error {
+	if h.actor == nil {
+		return errNoActor
+	}
+	h.actor.Output.WriteLine(text)
+	return nil
+}
+
+func (h *scriptHost) EchoAround(text string) error {
+	h.toRoom("$text", text, h.actor)
+	return nil
+}
+
+func (h *scriptHost) Transfer(vnum int) error {
+	if h.actor == nil {
+		return errNoActor
+	}
+	h.w.mu.Lock()
+	if _, ok := h.w.rooms[vnum]; !ok {
+		h.w.mu.Unlock()
+		return fmt.Errorf("no room %d", vnum)
+	}
+	h.actor.Location = vnum
+	h.w.mu.Unlock()
+
+	h.w.runGreetTriggers(h.actor, h.depth+1)
+	return nil
+}
+
+func (h *scriptHost) Goto(vnum int) error {
+	mob := h.owner.mob
+	if mob == nil {
+		return errors.New("only a mobile can go anywhere")
+	}
+
+	h.w.mu.Lock()
+	defer h.w.mu.Unlock()
+	from, ok := h.w.rooms[h.room]
+	to, toOK := h.w.rooms[vnum]
+	if !toOK {
+		return fmt.Errorf("no room %d", vnum)
+	}
+	if !ok || !roomHasMobile(from, mob) {
+		return errors.New("the mobile is no longer here")
+	}
+	kept := make([]*Mobile, 0, len(from.Mobiles))
+	for _, m := range from.Mobiles {
+		if m != mob {
+			kept = append(kept, m)
+		}
+	}
+	from.Mobiles = kept
+	to.Mobiles = append(to.Mobiles, mob)
+	h.room = vnum
+	return nil
+}
+
+func (h *scriptHost) SpawnMobile(vnum int) error {
+	h.w.mu.Lock()
+	defer h.w.mu.Unlock()
+	proto, ok := h.w.mobiles[vnum]
+	if !ok {
+		return fmt.Errorf("no mobile %d", vnum)
+	}
+	room, ok := h.w.rooms[h.room]
+	if !ok {
+		return fmt.Errorf("no room %d", h.room)
+	}
+	if room.Flags[RoomFlagNoMob] {
+		return ErrNoMob
+	}
+	mobCopy := *proto
+	room.Mobiles = append(room.Mobiles, &mobCopy)
+	return nil
+}
+
+// newObject copies the object prototype at vnum. Callers hold w.mu.
+func (h *scriptHost) newObject(vnum int) (*Object, error) {
+	proto, ok := h.w.objects[vnum]
+	if !ok {
+		return nil, fmt.Errorf("no object %d", vnum)
+	}
+	objCopy := *proto
+	return &objCopy, nil
+}
+
+func (h *scriptHost) SpawnObject(vnum int) error {
+	h.w.mu.Lock()
+	defer h.w.mu.Unlock()
+	obj, err := h.newObject(vnum)
+	if err != nil {
+		return err
+	}
+	room, ok := h.w.rooms[h.room]
+	if !ok {
+		return fmt.Errorf("no room %d", h.room)
+	}
+	room.Objects = append(room.Objects, obj)
+	return nil
+}
+
+func (h *scriptHost) GiveObject(vnum int) error {
+	if h.actor == nil {
+		return errNoActor
+	}
+	h.w.mu.Lock()
+	defer h.w.mu.Unlock()
+	obj, err := h.newObject(vnum)
+	if err != nil {
+		return err
+	}
+	h.actor.Inventory = append(h.actor.Inventory, obj)
+	return nil
+}
+
+func (h *scriptHost) SetRoomFlag(flag string, on bool) error {
+	flag = strings.ToLower(strings.TrimSpace(flag))
+	if flag == "" {
+		return errors.New("which flag?")
+	}
+	h.w.mu.Lock()
+	defer h.w.mu.Unlock()
+	room, ok := h.w.rooms[h.room]
+	if !ok {
+		return fmt.Errorf("no room %d", h.room)
+	}
+	if room.Flags == nil {
+		room.Flags = map[string]bool{}
+	}
+	if on {
+		room.Flags[flag] = true
+	} else {
+		delete(room.Flags, flag)
+	}
+	return nil
+}
+
+func (h *scriptHost) HasActor() bool {
+	return h.actor != nil
+}
+
+func (h *scriptHost) ActorIsKeeper() bool {
+	return h.actor != nil && h.actor.IsKeeper
+}
+
+func (h *scriptHost) ActorCarries(keyword string) bool {
+	if h.actor == nil {
+		return false
+	}
+	h.w.mu.RLock()
+	defer h.w.mu.RUnlock()
+	return len(SelectObjects(h.actor.Inventory, ParseTarget(keyword))) > 0
+}
+
+func (h *scriptHost) RoomFlag(flag string) bool {
+	return h.w.RoomHasFlag(h.room, strings.ToLower(flag))
+}
+
+func (h *scriptHost) HPPercent() int {
+	mob := h.owner.mob
+	if mob == nil || mob.MaxHP <= 0 {
+		return percentMultiplier
+	}
+	h.w.mu.RLock()
+	defer h.w.mu.RUnlock()
+	return mob.HP * percentMultiplier / mob.MaxHP
+}
+
+func (h *scriptHost) Roll(percent int) bool {
+	return rand.Intn(percentMultiplier) < percent
+}
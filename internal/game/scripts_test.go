@@ -0,0 +1,121 @@
+package game
+
+import (
+	"testing"
+
+	"njata/internal/script"
+)
+
+func TestSpeechTriggerLetsMobileAnswer(t *testing.T) {
+	world, player := newMobAIWorld(t)
+	placeMob(world, 1, &Mobile{
+		Short:   "the guard",
+		Scripts: []script.Script{{Trigger: "speech", Arg: "hello hi", Code: []string{"say Greetings, $n."}}},
+	})
+
+	if err := world.BroadcastSay(player, "Hello there"); err != nil {
+		t.Fatalf("say: %v", err)
+	}
+
+	if !player.Output.(*bufferOutput).Contains("The guard says 'Greetings, Alice.'") {
+		t.Fatalf("expected the guard to answer, got %v", player.Output.(*bufferOutput).lines)
+	}
+}
+
+func TestGreetTriggerAndTransfer(t *testing.T) {
+	world, player := newMobAIWorld(t)
+	world.rooms[1].Scripts = []script.Script{{Trigger: "greet", Code: []string{
+		"echoat The floor gives way!",
+		"transfer 2",
+	}}}
+	world.rooms[2].Scripts = []script.Script{{Trigger: "entry", Code: []string{"setflag dark"}}}
+
+	world.RunGreetTriggers(player)
+
+	if player.Location != 2 {
+		t.Fatalf("expected to be transferred to room 2, in %d", player.Location)
+	}
+	if !world.rooms[2].Flags["dark"] {
+		t.Fatalf("expected the second room's greet script to run after the transfer")
+	}
+}
+
+func TestCommandTriggerTakesOverCommand(t *testing.T) {
+	world, player := newMobAIWorld(t)
+	player.Inventory = []*Object{{
+		Vnum:     7,
+		Keywords: []string{"lamp"},
+		Short:    "a brass lamp",
+		Scripts:  []script.Script{{Trigger: "command", Arg: "rub", Code: []string{"echoat You rub $t and it glows."}}},
+	}}
+
+	if !world.RunCommandTriggers(player, "rub", "lamp") {
+		t.Fatalf("expected the lamp to take the command")
+	}
+	if world.RunCommandTriggers(player, "look", "") {
+		t.Fatalf("expected other commands to pass through")
+	}
+	if !player.Output.(*bufferOutput).Contains("You rub lamp and it glows.") {
+		t.Fatalf("expected the lamp's message, got %v", player.Output.(*bufferOutput).lines)
+	}
+}
+
+func TestGiveAndDeathTriggers(t *testing.T) {
+	world, player := newMobAIWorld(t)
+	world.SetPrototypes(map[int]*Mobile{9: {Vnum: 9, Short: "a fresh dummy", MaxHP: 10, HP: 10}}, map[int]*Object{
+		5: {Vnum: 5, Keywords: []string{"coin"}, Short: "a silver coin"},
+	})
+	dummy := placeMob(world, 1, &Mobile{Short: "a dummy", Scripts: []script.Script{
+		{Trigger: "give", Arg: "apple", Code: []string{"say Thanks!", "give 5"}},
+		{Trigger: "death", Code: []string{"mload 9", "echo Another dummy pops up."}},
+	}})
+	apple := &Object{Keywords: []string{"apple"}, Short: "an apple"}
+	player.Inventory = []*Object{apple}
+
+	if err := world.GiveObject(player, apple, MobileSubject(dummy)); err != nil {
+		t.Fatalf("give: %v", err)
+	}
+	if len(dummy.Inventory) != 1 || len(player.Inventory) != 1 || player.Inventory[0].Short != "a silver coin" {
+		t.Fatalf("expected the apple swapped for a coin, player has %+v", player.Inventory)
+	}
+
+	world.DamageMob(player, dummy, 100)
+	mobs := world.rooms[1].Mobiles
+	if len(mobs) != 1 || mobs[0].Short != "a fresh dummy" {
+		t.Fatalf("expected the death script to load a new dummy, got %+v", mobs)
+	}
+}
+
+func TestScriptErrorsReachKeepers(t *testing.T) {
+	world, player := newMobAIWorld(t)
+	keeper := &Player{Name: "kara", Output: &bufferOutput{}, Location: 3, IsKeeper: true}
+	if err := world.AddPlayer(keeper); err != nil {
+		t.Fatalf("add keeper: %v", err)
+	}
+	world.rooms[1].Scripts = []script.Script{{Trigger: "greet", Code: []string{"transfer 999"}}}
+
+	world.RunGreetTriggers(player)
+
+	if !keeper.Output.(*bufferOutput).Contains("[Script] room 1, greet trigger: line 1: transfer: no room 999") {
+		t.Fatalf("expected the keeper to hear about it, got %v", keeper.Output.(*bufferOutput).lines)
+	}
+	if player.Output.(*bufferOutput).Contains("[Script]") {
+		t.Fatalf("expected players not to see script errors")
+	}
+}
+
+func TestScriptsCannotLoopForever(t *testing.T) {
+	world, player := newMobAIWorld(t)
+	keeper := &Player{Name: "kara", Output: &bufferOutput{}, Location: 3, IsKeeper: true}
+	if err := world.AddPlayer(keeper); err != nil {
+		t.Fatalf("add keeper: %v", err)
+	}
+	world.rooms[1].Scripts = []script.Script{{Trigger: "greet", Code: []string{"transfer 2"}}}
+	world.rooms[2].Scripts = []script.Script{{Trigger: "greet", Code: []string{"transfer 1"}}}
+
+	world.RunGreetTriggers(player)
+
+	if !keeper.Output.(*bufferOutput).Contains("nested more than") {
+		t.Fatalf("expected the loop to be cut off, got %v", keeper.Output.(*bufferOutput).lines)
+	}
+}
@@ -0,0 +1,343 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Shop describes how a shopkeeper trades. Like SMAUG shops, prices start
+// from the object's cost and are marked up or down by the keeper's profit
+// percentages, then nudged by the customer's Charisma.
+type Shop struct {
+	BuyTypes   []string `json:"buys"`        // object types the keeper buys from players
+	ProfitBuy  int      `json:"profit_buy"`  // percent of cost a player pays to buy
+	ProfitSell int      `json:"profit_sell"` // percent of cost a player is paid to sell
+	OpenHour   int      `json:"open_hour"`   // first game hour the shop is open
+	CloseHour  int      `json:"close_hour"`  // last game hour the shop is open
+}
+
+// baseCharisma is the Charisma at which a customer pays the keeper's list
+// prices; each point above or below moves prices one percent.
+const baseCharisma = 10
+
+// ErrNoShop is returned when there is no shopkeeper in the room.
+var ErrNoShop = errors.New("You can't do that here.")
+
+// Buys reports whether the keeper buys objects of type itemType.
+func (s *Shop) Buys(itemType string) bool {
+	for _, t := range s.BuyTypes {
+		if strings.EqualFold(t, itemType) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOpen reports whether the shop is open at hour. Closing hours earlier
+// than the opening hour wrap past midnight.
+func (s *Shop) IsOpen(hour int) bool {
+	if s.OpenHour > s.CloseHour {
+		return hour >= s.OpenHour || hour <= s.CloseHour
+	}
+	return hour >= s.OpenHour && hour <= s.CloseHour
+}
+
+// BuyPrice is what a customer with the given Charisma pays for an object
+// costing cost. The keeper never sells for less than they would pay.
+func (s *Shop) BuyPrice(cost, charisma int) int {
+	percent := max(s.ProfitSell+1, s.ProfitBuy+baseCharisma-charisma)
+	return cost * percent / 100
+}
+
+// SellPrice is what the keeper pays a customer with the given Charisma for
+// an object costing cost. The keeper never pays more than they would charge.
+func (s *Shop) SellPrice(cost, charisma int) int {
+	percent := min(s.ProfitBuy-1, s.ProfitSell+charisma-baseCharisma)
+	return max(cost*percent/100, 0)
+}
+
+// hourName renders a game hour the way a shopkeeper says it.
+func hourName(hour int) string {
+	switch hour {
+	case 0:
+		return "midnight"
+	case 12:
+		return "noon"
+	}
+	if hour > 12 {
+		return fmt.Sprintf("%d pm", hour-12)
+	}
+	return fmt.Sprintf("%d am", hour)
+}
+
+// ShopItem is one line of a shopkeeper's list: identical objects are grouped.
+type ShopItem struct {
+	Object *Object
+	Price  int
+	Count  int
+}
+
+// ResetMobile makes a mobile from proto for a room reset, carrying copies
+// of the reset's items. This is how shopkeepers get their stock.
+func ResetMobile(proto *Mobile, reset Reset, objects map[int]*Object) *Mobile {
+	mobCopy := *proto
+	mobCopy.Inventory = nil
+	for _, item := range reset.Items {
+		objProto, ok := objects[item.Vnum]
+		if !ok {
+			continue
+		}
+		for i := 0; i < item.Count; i++ {
+			objCopy := *objProto
+			mobCopy.Inventory = append(mobCopy.Inventory, &objCopy)
+		}
+	}
+	return &mobCopy
+}
+
+// objectCost is what obj is worth, falling back to its prototype for
+// objects saved before they carried a cost. Callers hold w.mu.
+func (w *World) objectCost(obj *Object) int {
+	if obj.Cost > 0 {
+		return obj.Cost
+	}
+	if proto, ok := w.objects[obj.Vnum]; ok {
+		return proto.Cost
+	}
+	return 0
+}
+
+// keeperSays formats something a shopkeeper tells the player, for returning
+// as an error. Callers hold w.mu.
+func (w *World) keeperSays(p *Player, keeper *Mobile, message string) error {
+	text := w.formatAct("$n tells you '$message'", p, MobileSubject(keeper), PlayerSubject(p), ActVars{"message": message})
+	return errors.New(text)
+}
+
+// findKeeper returns the shopkeeper in the player's room, if they are
+// willing to trade right now. Callers hold w.mu.
+func (w *World) findKeeper(p *Player) (*Mobile, error) {
+	if IsAsleep(p) {
+		return nil, ErrAsleep
+	}
+	room, ok := w.rooms[p.Location]
+	if !ok {
+		return nil, ErrNoShop
+	}
+
+	var keeper *Mobile
+	for _, mob := range room.Mobiles {
+		if mob.Shop != nil {
+			keeper = mob
+			break
+		}
+	}
+	if keeper == nil {
+		return nil, ErrNoShop
+	}
+
+	self, them := PlayerSubject(p), MobileSubject(keeper)
+	switch {
+	case !mobAwake(keeper):
+		return nil, errors.New(w.formatAct("$N is in no condition to trade.", p, self, them, nil))
+	case mobInCombat(keeper):
+		return nil, errors.New(w.formatAct("$N is too busy for that!", p, self, them, nil))
+	case InCombat(p):
+		return nil, errors.New(w.formatAct("$N doesn't seem to want to get involved.", p, self, them, nil))
+	}
+
+	if hour := w.clock.Hour; !keeper.Shop.IsOpen(hour) {
+		if hour > keeper.Shop.CloseHour && keeper.Shop.OpenHour <= keeper.Shop.CloseHour {
+			return nil, w.keeperSays(p, keeper, fmt.Sprintf("Sorry, come back tomorrow at %s.", hourName(keeper.Shop.OpenHour)))
+		}
+		return nil, w.keeperSays(p, keeper, fmt.Sprintf("Sorry, come back at %s.", hourName(keeper.Shop.OpenHour)))
+	}
+	return keeper, nil
+}
+
+// ShopList returns the shopkeeper in the player's room and what they have
+// for sale matching arg (everything when arg is empty).
+func (w *World) ShopList(p *Player, arg string) (*Mobile, []ShopItem, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	keeper, err := w.findKeeper(p)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stock := keeper.Inventory
+	if strings.TrimSpace(arg) != "" {
+		target := ParseTarget(arg)
+		target.All, target.Index = true, 0
+		stock = SelectObjects(stock, target)
+	}
+
+	var items []ShopItem
+	seen := map[string]int{}
+	for _, obj := range stock {
+		price := keeper.Shop.BuyPrice(w.objectCost(obj), p.Charisma)
+		if price <= 0 {
+			continue
+		}
+		key := fmt.Sprintf("%d:%s", obj.Vnum, obj.Short)
+		if i, ok := seen[key]; ok {
+			items[i].Count++
+			continue
+		}
+		seen[key] = len(items)
+		items = append(items, ShopItem{Object: obj, Price: price, Count: 1})
+	}
+	return keeper, items, nil
+}
+
+// BuyObject buys quantity of the item matching arg from the
+// shopkeeper in the player's room.
+func (w *World) BuyObject(p *Player, arg string, quantity int) error {
+	if quantity < 1 {
+		return errors.New("Buy how many?")
+	}
+
+	w.mu.Lock()
+	keeper, err := w.findKeeper(p)
+	if err != nil {
+		w.mu.Unlock()
+		return err
+	}
+
+	found := SelectObjects(keeper.Inventory, ParseTarget(arg))
+	if len(found) == 0 {
+		w.mu.Unlock()
+		return w.keeperSays(p, keeper, "I don't sell that -- try 'list'.")
+	}
+	item := found[0]
+	price := keeper.Shop.BuyPrice(w.objectCost(item), p.Charisma)
+	if price <= 0 {
+		w.mu.Unlock()
+		return w.keeperSays(p, keeper, "That isn't for sale.")
+	}
+
+	// Pick out identical items, starting with the one asked for
+	var bought []*Object
+	for _, obj := range keeper.Inventory {
+		if len(bought) < quantity && (obj == item || len(bought) > 0 && obj.Vnum == item.Vnum && obj.Short == item.Short) {
+			bought = append(bought, obj)
+		}
+	}
+	if len(bought) < quantity {
+		w.mu.Unlock()
+		return w.keeperSays(p, keeper, "I don't have that many of those.")
+	}
+	total := price * quantity
+	if p.Gold < total {
+		w.mu.Unlock()
+		return w.keeperSays(p, keeper, fmt.Sprintf("You can't afford to buy %s.", item.Short))
+	}
+
+	remaining := make([]*Object, 0, len(keeper.Inventory))
+	for _, obj := range keeper.Inventory {
+		keep := true
+		for _, b := range bought {
+			if obj == b {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			remaining = append(remaining, obj)
+		}
+	}
+	keeper.Inventory = remaining
+	p.Gold -= total
+	w.burnGold(LedgerShopBuys, total)
+	p.Inventory = append(p.Inventory, bought...)
+	w.mu.Unlock()
+
+	what := item.Short
+	if quantity > 1 {
+		what = fmt.Sprintf("%d * %s", quantity, item.Short)
+	}
+	self := PlayerSubject(p)
+	vars := ActVars{"object": what, "price": fmt.Sprint(total)}
+	w.Act("You buy $object for $price gold.", self, MobileSubject(keeper), vars, ToActor)
+	w.Act("$n buys $object.", self, MobileSubject(keeper), vars, ToRoom)
+	return nil
+}
+
+// Appraise returns the shopkeeper in the player's room and what they would
+// pay for obj.
+func (w *World) Appraise(p *Player, obj *Object) (*Mobile, int, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	keeper, err := w.findKeeper(p)
+	if err != nil {
+		return nil, 0, err
+	}
+	price, err := w.offer(p, keeper, obj)
+	return keeper, price, err
+}
+
+// offer is what keeper will pay the player for obj, or why they won't buy
+// it. Like SMAUG keepers, they won't buy what they already have in stock.
+// Callers hold w.mu.
+func (w *World) offer(p *Player, keeper *Mobile, obj *Object) (int, error) {
+	if len(obj.Contents) > 0 {
+		return 0, fmt.Errorf("You'll have to empty %s first.", obj.Short)
+	}
+	uninterested := errors.New(w.formatAct("$N looks uninterested in $object.", p, PlayerSubject(p), MobileSubject(keeper), ActVars{"object": obj.Short}))
+	if !keeper.Shop.Buys(obj.Type) {
+		return 0, uninterested
+	}
+	for _, item := range keeper.Inventory {
+		if item.Vnum == obj.Vnum {
+			return 0, uninterested
+		}
+	}
+	price := keeper.Shop.SellPrice(w.objectCost(obj), p.Charisma)
+	if price <= 0 {
+		return 0, uninterested
+	}
+	return price, nil
+}
+
+// SellObject sells obj from the player's inventory to the shopkeeper in
+// their room.
+func (w *World) SellObject(p *Player, obj *Object) error {
+	w.mu.Lock()
+	keeper, err := w.findKeeper(p)
+	if err != nil {
+		w.mu.Unlock()
+		return err
+	}
+
+	index := -1
+	for i, item := range p.Inventory {
+		if item == obj {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		w.mu.Unlock()
+		return fmt.Errorf("You aren't carrying that.")
+	}
+	price, err := w.offer(p, keeper, obj)
+	if err != nil {
+		w.mu.Unlock()
+		return err
+	}
+
+	p.Inventory = append(p.Inventory[:index], p.Inventory[index+1:]...)
+	keeper.Inventory = append(keeper.Inventory, obj)
+	p.Gold += price
+	w.mintGold(LedgerShopSales, price)
+	w.mu.Unlock()
+
+	self := PlayerSubject(p)
+	vars := ActVars{"object": obj.Short, "price": fmt.Sprint(price)}
+	w.Act("You sell $object for $price gold.", self, MobileSubject(keeper), vars, ToActor)
+	w.Act("$n sells $object.", self, MobileSubject(keeper), vars, ToRoom)
+	return nil
+}
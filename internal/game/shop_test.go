@@ -0,0 +1,108 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func newShopWorld(t *testing.T) (*World, *Player, *Mobile) {
+	t.Helper()
+
+	world, player := newMobAIWorld(t)
+	player.Gold = 500
+	player.Charisma = 10
+	sword := &Object{Vnum: 20, Keywords: []string{"sword"}, Type: "weapon", Short: "a short sword", Cost: 100}
+	keeper := placeMob(world, 1, &Mobile{
+		Short:    "the smith",
+		Position: PositionStanding,
+		Shop:     &Shop{BuyTypes: []string{"weapon"}, ProfitBuy: 120, ProfitSell: 90, OpenHour: 0, CloseHour: 23},
+	})
+	keeper.Inventory = ResetMobile(&Mobile{}, Reset{Items: []LootEntry{{Vnum: 20, Count: 2}}}, map[int]*Object{20: sword}).Inventory
+	return world, player, keeper
+}
+
+func TestShopListGroupsStock(t *testing.T) {
+	world, player, _ := newShopWorld(t)
+
+	_, items, err := world.ShopList(player, "")
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(items) != 1 || items[0].Count != 2 || items[0].Price != 120 {
+		t.Fatalf("expected two swords at 120, got %+v", items)
+	}
+}
+
+func TestBuyAndSell(t *testing.T) {
+	world, player, keeper := newShopWorld(t)
+
+	if err := world.BuyObject(player, "sword", 2); err != nil {
+		t.Fatalf("buy: %v", err)
+	}
+	if player.Gold != 260 || len(player.Inventory) != 2 || len(keeper.Inventory) != 0 {
+		t.Fatalf("expected two swords for 240 gold, gold %d inventory %d", player.Gold, len(player.Inventory))
+	}
+
+	if err := world.SellObject(player, player.Inventory[0]); err != nil {
+		t.Fatalf("sell: %v", err)
+	}
+	if player.Gold != 350 || len(keeper.Inventory) != 1 {
+		t.Fatalf("expected the sword sold for 90 gold, gold %d", player.Gold)
+	}
+
+	// Keepers won't buy what they already stock, or goods they don't deal in
+	if err := world.SellObject(player, player.Inventory[0]); err == nil || !strings.Contains(err.Error(), "uninterested") {
+		t.Fatalf("expected the keeper to refuse a second sword, got %v", err)
+	}
+	bread := &Object{Vnum: 21, Keywords: []string{"bread"}, Type: "food", Short: "a loaf of bread", Cost: 10}
+	player.Inventory = append(player.Inventory, bread)
+	if _, _, err := world.Appraise(player, bread); err == nil {
+		t.Fatalf("expected the smith to turn down bread")
+	}
+}
+
+func TestBuyRefusals(t *testing.T) {
+	world, player, _ := newShopWorld(t)
+	player.Gold = 50
+
+	err := world.BuyObject(player, "sword", 1)
+	if err == nil || err.Error() != "The smith tells you 'You can't afford to buy a short sword.'" {
+		t.Fatalf("expected the smith to refuse, got %v", err)
+	}
+	if err := world.BuyObject(player, "axe", 1); err == nil || !strings.Contains(err.Error(), "try 'list'") {
+		t.Fatalf("expected no axes for sale, got %v", err)
+	}
+	player.Location = 2
+	if err := world.BuyObject(player, "sword", 1); err != ErrNoShop {
+		t.Fatalf("expected no shop here, got %v", err)
+	}
+}
+
+func TestCharismaAdjustsPrices(t *testing.T) {
+	shop := &Shop{ProfitBuy: 120, ProfitSell: 90}
+
+	if got := shop.BuyPrice(100, 20); got != 110 {
+		t.Fatalf("expected a charming buyer to pay 110, got %d", got)
+	}
+	if got := shop.BuyPrice(100, 0); got != 130 {
+		t.Fatalf("expected a rude buyer to pay 130, got %d", got)
+	}
+	if got := shop.SellPrice(100, 40); got != 119 {
+		t.Fatalf("expected sale prices to stay below buy prices, got %d", got)
+	}
+}
+
+func TestShopHours(t *testing.T) {
+	world, player, keeper := newShopWorld(t)
+	keeper.Shop.OpenHour, keeper.Shop.CloseHour = 6, 18
+	world.SetClock(GameTime{Hour: 20})
+
+	if _, _, err := world.ShopList(player, ""); err == nil || !strings.Contains(err.Error(), "come back tomorrow at 6 am") {
+		t.Fatalf("expected the shop to be closed, got %v", err)
+	}
+
+	night := &Shop{OpenHour: 20, CloseHour: 4}
+	if !night.IsOpen(2) || night.IsOpen(12) {
+		t.Fatalf("expected opening hours to wrap past midnight")
+	}
+}
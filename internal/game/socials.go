@@ -0,0 +1,50 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"njata/internal/socials"
+)
+
+// PerformSocial acts out a social in the actor's room. arg optionally names a
+// player or mobile in the room as the victim.
+func (w *World) PerformSocial(actor *Player, social *socials.Social, arg string) error {
+	if social == nil {
+		return fmt.Errorf("Huh?")
+	}
+
+	arg = strings.TrimSpace(arg)
+	self := PlayerSubject(actor)
+
+	if arg == "" {
+		w.Act(social.CharNoArg, self, Subject{}, nil, ToActor)
+		w.Act(social.OthersNoArg, self, Subject{}, nil, ToRoom)
+		return nil
+	}
+
+	if target, ok := w.FindPlayerInRoom(actor, arg); ok {
+		if target == actor {
+			w.Act(social.CharAuto, self, self, nil, ToActor)
+			w.Act(social.OthersAuto, self, self, nil, ToRoom)
+			return nil
+		}
+
+		victim := PlayerSubject(target)
+		w.Act(social.CharFound, self, victim, nil, ToActor)
+		if !w.IsIgnoring(target, actor) {
+			w.Act(social.VictFound, self, victim, nil, ToTarget)
+		}
+		w.Act(social.OthersFound, self, victim, nil, ToNotTarget)
+		return nil
+	}
+
+	if mobs := w.ResolveRoomMobiles(actor, arg); len(mobs) > 0 {
+		victim := MobileSubject(mobs[0])
+		w.Act(social.CharFound, self, victim, nil, ToActor)
+		w.Act(social.OthersFound, self, victim, nil, ToRoom)
+		return nil
+	}
+
+	return fmt.Errorf("They aren't here.")
+}
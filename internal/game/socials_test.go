@@ -0,0 +1,45 @@
+package game
+
+import (
+	"testing"
+
+	"njata/internal/socials"
+)
+
+func TestPerformSocialPerspectives(t *testing.T) {
+	world := CreateDefaultWorld()
+
+	aliceOut := &bufferOutput{}
+	bobOut := &bufferOutput{}
+	carolOut := &bufferOutput{}
+	alice := &Player{Name: "alice", Sex: 2, Output: aliceOut}
+	for _, p := range []*Player{alice, {Name: "bob", Sex: 1, Output: bobOut}, {Name: "carol", Output: carolOut}} {
+		if err := world.AddPlayer(p); err != nil {
+			t.Fatalf("add player: %v", err)
+		}
+	}
+
+	social := &socials.Social{
+		Name:        "poke",
+		CharFound:   "You poke $M.",
+		OthersFound: "$n pokes $N in $S ribs.",
+		VictFound:   "$n pokes you.",
+	}
+
+	if err := world.PerformSocial(alice, social, "bob"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !aliceOut.Contains("You poke him.") {
+		t.Fatalf("expected actor message")
+	}
+	if !bobOut.Contains("Alice pokes you.") {
+		t.Fatalf("expected victim message")
+	}
+	if !carolOut.Contains("Alice pokes Bob in his ribs.") {
+		t.Fatalf("expected bystander message")
+	}
+
+	if err := world.PerformSocial(alice, social, "nobody"); err == nil {
+		t.Fatalf("expected missing target error")
+	}
+}
@@ -0,0 +1,280 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// areaFalloff is the share of its damage, in percent, that an area spell
+// keeps for each room it spreads beyond the caster's.
+const areaFalloff = 50
+
+// SpellTarget is one victim of an area spell or maneuver.
+type SpellTarget struct {
+	Mobile   *Mobile
+	Player   *Player
+	Distance int // rooms away from the caster; 0 is the caster's room
+}
+
+// Subject returns the target for use in Act.
+func (t SpellTarget) Subject() Subject {
+	if t.Player != nil {
+		return PlayerSubject(t.Player)
+	}
+	return MobileSubject(t.Mobile)
+}
+
+// AreaDamage scales an area spell's damage for a target distance rooms away.
+func AreaDamage(damage, distance int) int {
+	for i := 0; i < distance && damage > 1; i++ {
+		damage = damage * areaFalloff / 100
+	}
+	return max(damage, 1)
+}
+
+// isBystander reports whether an area attack should spare the mobile:
+// shopkeepers, bankers and trainers are never caught in the blast.
+func isBystander(m *Mobile) bool {
+	return m.Shop != nil || m.IsBanker || m.IsTrainer
+}
+
+// roomStep is a room found spreading out from another, with the first exit
+// taken on the way there.
+type roomStep struct {
+	vnum      int
+	distance  int
+	direction string
+}
+
+// roomsWithin lists the rooms up to radius exits from the room at vnum,
+// nearest first, starting with vnum itself. Rooms for which blocked (if
+// given) returns true are left out, and nothing beyond them is reached
+// through them. Callers hold w.mu.
+func (w *World) roomsWithin(vnum, radius int, blocked func(*Room) bool) []roomStep {
+	steps := []roomStep{{vnum: vnum}}
+	seen := map[int]bool{vnum: true}
+	for i := 0; i < len(steps); i++ {
+		step := steps[i]
+		room, ok := w.rooms[step.vnum]
+		if !ok || step.distance >= radius {
+			continue
+		}
+
+		directions := make([]string, 0, len(room.Exits))
+		for dir := range room.Exits {
+			directions = append(directions, dir)
+		}
+		sort.Strings(directions)
+		for _, dir := range directions {
+			to := room.Exits[dir]
+			if seen[to] {
+				continue
+			}
+			seen[to] = true
+			if next, ok := w.rooms[to]; !ok || blocked != nil && blocked(next) {
+				continue
+			}
+			first := step.direction
+			if first == "" {
+				first = dir
+			}
+			steps = append(steps, roomStep{vnum: to, distance: step.distance + 1, direction: first})
+		}
+	}
+	return steps
+}
+
+// AreaTargets gathers everyone an area spell cast by p would hit: the
+// mobiles and willing players in p's room, then the mobiles in rooms up to
+// radius exits away. The blast doesn't reach into safe rooms, and it spares
+// shopkeepers, bankers and trainers.
+func (w *World) AreaTargets(p *Player, radius int) []SpellTarget {
+	w.mu.RLock()
+	var targets []SpellTarget
+	var players []*Player
+	safe := func(room *Room) bool { return room.Flags[RoomFlagSafe] }
+	for _, step := range w.roomsWithin(p.Location, radius, safe) {
+		room, ok := w.rooms[step.vnum]
+		if !ok {
+			continue
+		}
+		for _, mob := range room.Mobiles {
+			if mob.HP > 0 && !isBystander(mob) && w.canSee(p, MobileSubject(mob)) {
+				targets = append(targets, SpellTarget{Mobile: mob, Distance: step.distance})
+			}
+		}
+	}
+	for _, other := range w.players {
+		if other != p && other.Location == p.Location {
+			players = append(players, other)
+		}
+	}
+	w.mu.RUnlock()
+
+	sort.Slice(players, func(i, j int) bool { return players[i].Name < players[j].Name })
+	for _, other := range players {
+		if w.CanAttackPlayer(p, other) == nil {
+			targets = append(targets, SpellTarget{Player: other})
+		}
+	}
+	return targets
+}
+
+// ErrNoSummon is returned when a player can't be summoned.
+var ErrNoSummon = errors.New("You can only summon members of your group.")
+
+// ErrNoTeleport is returned when a player can't be sent away by magic.
+var ErrNoTeleport = errors.New("You can only send yourself or members of your group.")
+
+// Sensed is a creature found by a detect spell.
+type Sensed struct {
+	Name      string
+	Direction string // first exit towards it; "" when it is in the caster's room
+	Distance  int
+}
+
+// SenseCreatures lists the creatures up to radius rooms from p, nearest
+// first, for detect spells. Darkness doesn't hide them from the magic.
+func (w *World) SenseCreatures(p *Player, radius int) []Sensed {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	var sensed []Sensed
+	for _, step := range w.roomsWithin(p.Location, radius, nil) {
+		room := w.rooms[step.vnum]
+		for _, mob := range room.Mobiles {
+			sensed = append(sensed, Sensed{MobileSubject(mob).name(), step.direction, step.distance})
+		}
+		var names []string
+		for _, other := range w.players {
+			if other != p && other.Location == step.vnum {
+				names = append(names, CapitalizeName(other.Name))
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			sensed = append(sensed, Sensed{name, step.direction, step.distance})
+		}
+	}
+	return sensed
+}
+
+// RecallRoom returns where recall magic takes players: the room the dead
+// wake up in, or failing that the start room.
+func (w *World) RecallRoom() int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if _, ok := w.rooms[w.death.RecallVnum]; ok {
+		return w.death.RecallVnum
+	}
+	return w.start
+}
+
+// BlinkDestination picks a random room in p's area for a short-range
+// teleport, as teleport rooms do.
+func (w *World) BlinkDestination(p *Player) (int, bool) {
+	w.mu.RLock()
+	room, ok := w.rooms[p.Location]
+	w.mu.RUnlock()
+	if !ok {
+		return 0, false
+	}
+	return w.teleportDestination(room)
+}
+
+// TeleportPlayer whisks p by magic to the room at vnum, unless the room
+// they are in binds them there or the destination is full.
+func (w *World) TeleportPlayer(p *Player, vnum int) error {
+	if err := w.CanRecall(p); err != nil {
+		return err
+	}
+	if err := w.CanEnter(p, vnum); err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	if _, ok := w.rooms[vnum]; !ok {
+		w.mu.Unlock()
+		return fmt.Errorf("room not found")
+	}
+	notes := placePlayer(p, vnum)
+	w.mu.Unlock()
+
+	writeNotes(p, notes)
+	return nil
+}
+
+// CanTeleport reports whether p may send target away by magic: only
+// themselves or a member of their group.
+func (w *World) CanTeleport(p, target *Player) error {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if target != p && !SameGroup(p, target) {
+		return ErrNoTeleport
+	}
+	return nil
+}
+
+// CanSummon reports whether p may summon target to their side: only a
+// fellow group member elsewhere in the world can be called.
+func (w *World) CanSummon(p, target *Player) error {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	switch {
+	case target == p:
+		return errors.New("You are already here.")
+	case !SameGroup(p, target):
+		return ErrNoSummon
+	case target.Location == p.Location:
+		return fmt.Errorf("%s is already here.", CapitalizeName(target.Name))
+	}
+	return nil
+}
+
+// StrikeMob deals damage to a mobile out of the player's reach, such as one
+// caught by an area spell in a neighbouring room: it can die, but it doesn't
+// strike back. Nor does a kill out of reach pay: the corpse keeps only what
+// the mobile carried, with no loot, gold or quest credit.
+func (w *World) StrikeMob(player *Player, mob *Mobile, damage int) (died bool, loot []string) {
+	return w.strikeMob(player, mob, damage)
+}
+
+// HealPlayer restores up to amount hit points to p and returns how many
+// were restored.
+func (w *World) HealPlayer(p *Player, amount int) int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	before := p.HP
+	restore(&p.HP, p.MaxHP, amount)
+	return p.HP - before
+}
+
+// playerStat returns the attribute named by a formula letter, as mobStat
+// does for mobiles. Players have no level, so l counts for nothing.
+func playerStat(p *Player, letter byte) int {
+	switch letter {
+	case 'S':
+		return p.Strength
+	case 'I':
+		return p.Intelligence
+	case 'W':
+		return p.Wisdom
+	case 'D':
+		return p.Dexterity
+	case 'C':
+		return p.Constitution
+	case 'L':
+		return p.Luck
+	case 'A':
+		return p.Charisma
+	}
+	return 0
+}
+
+// RollFormula evaluates a skills.json formula such as "2d8 + 5 + W*1.5"
+// for a player.
+func RollFormula(formula string, p *Player) int {
+	return rollStats(formula, func(letter byte) int { return playerStat(p, letter) })
+}
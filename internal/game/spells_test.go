@@ -0,0 +1,152 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestAreaTargets(t *testing.T) {
+	world, alice, bob := newPvPWorld(t)
+	wolf := placeMob(world, 1, &Mobile{Short: "a wolf", Keywords: []string{"wolf"}})
+	placeMob(world, 1, &Mobile{Short: "a merchant", Keywords: []string{"merchant"}, Shop: &Shop{}})
+	bear := placeMob(world, 2, &Mobile{Short: "a bear", Keywords: []string{"bear"}})
+	placeMob(world, 3, &Mobile{Short: "a hawk", Keywords: []string{"hawk"}})
+
+	targets := world.AreaTargets(alice, 0)
+	if len(targets) != 2 || targets[0].Mobile != wolf || targets[1].Player != bob {
+		t.Fatalf("expected the wolf and bob in the room, got %+v", targets)
+	}
+
+	targets = world.AreaTargets(alice, 1)
+	if len(targets) != 3 || targets[1].Mobile != bear || targets[1].Distance != 1 {
+		t.Fatalf("expected the bear next door, got %+v", targets)
+	}
+
+	// The blast doesn't reach into safe rooms, and spares group members
+	world.rooms[2].Flags[RoomFlagSafe] = true
+	groupUp(t, world, alice, bob)
+	if targets = world.AreaTargets(alice, 2); len(targets) != 1 || targets[0].Mobile != wolf {
+		t.Fatalf("expected only the wolf, got %+v", targets)
+	}
+}
+
+func TestAreaDamage(t *testing.T) {
+	cases := []struct{ damage, distance, want int }{
+		{20, 0, 20},
+		{20, 1, 10},
+		{20, 2, 5},
+		{3, 5, 1},
+	}
+	for _, c := range cases {
+		if got := AreaDamage(c.damage, c.distance); got != c.want {
+			t.Fatalf("AreaDamage(%d, %d) = %d, want %d", c.damage, c.distance, got, c.want)
+		}
+	}
+}
+
+func TestStrikeMobNextDoor(t *testing.T) {
+	world, alice := newMobAIWorld(t)
+	world.objects[50] = &Object{Vnum: 50, Short: "a bear pelt"}
+	bear := placeMob(world, 2, &Mobile{Short: "a bear", Keywords: []string{"bear"}, GoldMin: 10, GoldMax: 10, Loot: []LootEntry{{Vnum: 50, Count: 1}}})
+	bear.Inventory = []*Object{{Short: "a gnawed bone"}}
+
+	if died, _ := world.StrikeMob(alice, bear, 100); !died {
+		t.Fatalf("expected the bear to die")
+	}
+	if len(world.rooms[2].Mobiles) != 0 {
+		t.Fatalf("expected the bear to be removed from its own room")
+	}
+	if objs := world.rooms[2].Objects; len(objs) != 1 || !IsCorpse(objs[0]) {
+		t.Fatalf("expected the corpse where the bear fell, got %+v", objs)
+	}
+	if len(world.rooms[1].Objects) != 0 {
+		t.Fatalf("expected nothing left in the caster's room")
+	}
+
+	// A kill out of reach leaves only what the bear carried
+	if contents := world.rooms[2].Objects[0].Contents; len(contents) != 1 || contents[0].Short != "a gnawed bone" {
+		t.Fatalf("expected no loot or gold in the corpse, got %+v", contents)
+	}
+	if alice.Gold != 0 {
+		t.Fatalf("expected no share of gold, got %d", alice.Gold)
+	}
+}
+
+func TestHealPlayer(t *testing.T) {
+	world, alice := newMobAIWorld(t)
+	alice.HP, alice.Wisdom = 90, 10
+
+	if got := RollFormula("5 + W*1.5", alice); got != 20 {
+		t.Fatalf("expected 20, got %d", got)
+	}
+	if healed := world.HealPlayer(alice, 20); healed != 10 || alice.HP != 100 {
+		t.Fatalf("expected healing to stop at max, healed %d to %d", healed, alice.HP)
+	}
+}
+
+func TestSenseCreatures(t *testing.T) {
+	world, alice, bob := newGroupWorld(t)
+	placeMob(world, 3, &Mobile{Short: "a hawk", Keywords: []string{"hawk"}})
+	world.rooms[1].Flags["dark"] = true
+
+	sensed := world.SenseCreatures(alice, 2)
+	if len(sensed) != 2 {
+		t.Fatalf("expected bob and the hawk, got %+v", sensed)
+	}
+	if sensed[0].Name != "Bob" || sensed[0].Distance != 0 {
+		t.Fatalf("expected bob here, got %+v", sensed[0])
+	}
+	if sensed[1].Name != "a hawk" || sensed[1].Direction != "east" || sensed[1].Distance != 2 {
+		t.Fatalf("expected the hawk two rooms east, got %+v", sensed[1])
+	}
+
+	bob.Location = 4
+	if sensed = world.SenseCreatures(alice, 1); len(sensed) != 0 {
+		t.Fatalf("expected nothing within one room, got %+v", sensed)
+	}
+}
+
+func TestTeleportPlayer(t *testing.T) {
+	world, alice := newMobAIWorld(t)
+
+	if err := world.TeleportPlayer(alice, 3); err != nil || alice.Location != 3 {
+		t.Fatalf("expected to arrive in room 3, got %v at %d", err, alice.Location)
+	}
+	world.rooms[3].Flags[RoomFlagNoRecall] = true
+	if err := world.TeleportPlayer(alice, 1); err != ErrNoRecall || alice.Location != 3 {
+		t.Fatalf("expected the room to hold alice, got %v at %d", err, alice.Location)
+	}
+	if got := world.RecallRoom(); got != 1 {
+		t.Fatalf("expected to recall to the start room, got %d", got)
+	}
+}
+
+func TestCanSummon(t *testing.T) {
+	world, alice, bob := newGroupWorld(t)
+	bob.Location = 3
+
+	if err := world.CanSummon(alice, bob); err != ErrNoSummon {
+		t.Fatalf("expected only group members to be summoned, got %v", err)
+	}
+	groupUp(t, world, alice, bob)
+	if err := world.CanSummon(alice, bob); err != nil {
+		t.Fatalf("expected to summon a group member, got %v", err)
+	}
+	if err := world.CanSummon(alice, alice); err == nil {
+		t.Fatalf("expected not to summon yourself")
+	}
+}
+
+func TestCanTeleport(t *testing.T) {
+	world, alice, bob := newGroupWorld(t)
+
+	if err := world.CanTeleport(alice, alice); err != nil {
+		t.Fatalf("expected to send yourself, got %v", err)
+	}
+	if err := world.CanTeleport(alice, bob); err != ErrNoTeleport {
+		t.Fatalf("expected strangers to be out of bounds, got %v", err)
+	}
+	groupUp(t, world, alice, bob)
+	if err := world.CanTeleport(alice, bob); err != nil {
+		t.Fatalf("expected to send a group member, got %v", err)
+	}
+}
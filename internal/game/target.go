@@ -0,0 +1,239 @@
+package game
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Target is a parsed target argument such as "goblin", "2.goblin",
+// "all", "all.sword" or a quoted multi-word keyword like 'big goblin'.
+type Target struct {
+	Words []string // keyword words; all must match (empty with All means everything)
+	Index int      // 1-based ordinal from "2.goblin"; 0 means the first match
+	All   bool     // "all" or "all.<keyword>"
+}
+
+// ParseTarget parses a single target argument.
+func ParseTarget(arg string) Target {
+	arg = strings.ToLower(strings.TrimSpace(unquote(arg)))
+	if arg == "" {
+		return Target{}
+	}
+
+	if arg == "all" {
+		return Target{All: true}
+	}
+
+	if strings.HasPrefix(arg, "all.") {
+		return Target{All: true, Words: strings.Fields(unquote(arg[4:]))}
+	}
+
+	if dot := strings.Index(arg, "."); dot > 0 {
+		if index, err := strconv.Atoi(arg[:dot]); err == nil && index > 0 {
+			return Target{Index: index, Words: strings.Fields(unquote(arg[dot+1:]))}
+		}
+	}
+
+	return Target{Words: strings.Fields(arg)}
+}
+
+// Empty reports whether the target names nothing.
+func (t Target) Empty() bool {
+	return !t.All && len(t.Words) == 0
+}
+
+// Matches reports whether keywords/short satisfy every word of the target.
+// A word matches when it equals a keyword or appears in the short description.
+func (t Target) Matches(keywords []string, short string) bool {
+	if len(t.Words) == 0 {
+		return t.All
+	}
+
+	shortLower := strings.ToLower(short)
+	for _, word := range t.Words {
+		found := false
+		for _, keyword := range keywords {
+			if strings.ToLower(keyword) == word {
+				found = true
+				break
+			}
+		}
+		if !found && !strings.Contains(shortLower, word) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SplitArgs splits command arguments on whitespace, keeping quoted phrases
+// ('big goblin' or "big goblin") together as a single argument.
+func SplitArgs(args string) []string {
+	var result []string
+	var current strings.Builder
+	var quote rune
+	inWord := false
+
+	flush := func() {
+		if inWord {
+			result = append(result, current.String())
+			current.Reset()
+			inWord = false
+		}
+	}
+
+	for _, r := range args {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+				continue
+			}
+			current.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t':
+			flush()
+		default:
+			current.WriteRune(r)
+			inWord = true
+		}
+	}
+	flush()
+
+	return result
+}
+
+// FirstArg splits off the first (possibly quoted) argument and returns the rest.
+func FirstArg(args string) (string, string) {
+	args = strings.TrimSpace(args)
+	if args == "" {
+		return "", ""
+	}
+
+	if quote := args[0]; quote == '\'' || quote == '"' {
+		if end := strings.IndexByte(args[1:], quote); end != -1 {
+			return args[1 : end+1], strings.TrimSpace(args[end+2:])
+		}
+		return args[1:], ""
+	}
+
+	if space := strings.IndexAny(args, " \t"); space != -1 {
+		return args[:space], strings.TrimSpace(args[space+1:])
+	}
+	return args, ""
+}
+
+func unquote(arg string) string {
+	arg = strings.TrimSpace(arg)
+	if len(arg) >= 2 && (arg[0] == '\'' || arg[0] == '"') && arg[len(arg)-1] == arg[0] {
+		return arg[1 : len(arg)-1]
+	}
+	return arg
+}
+
+// SelectObjects returns the objects matching target. Single targets yield at most one object.
+func SelectObjects(objects []*Object, target Target) []*Object {
+	if target.Empty() {
+		return nil
+	}
+
+	matched := make([]*Object, 0)
+	count := 0
+	for _, obj := range objects {
+		if obj == nil || !target.Matches(obj.Keywords, obj.Short) {
+			continue
+		}
+		if target.All {
+			matched = append(matched, obj)
+			continue
+		}
+		count++
+		if target.Index == 0 || count == target.Index {
+			return append(matched, obj)
+		}
+	}
+
+	return matched
+}
+
+// SelectMobiles returns the mobiles matching target. Single targets yield at most one mobile.
+func SelectMobiles(mobiles []*Mobile, target Target) []*Mobile {
+	if target.Empty() {
+		return nil
+	}
+
+	matched := make([]*Mobile, 0)
+	count := 0
+	for _, mob := range mobiles {
+		if mob == nil || !target.Matches(mob.Keywords, mob.Short) {
+			continue
+		}
+		if target.All {
+			matched = append(matched, mob)
+			continue
+		}
+		count++
+		if target.Index == 0 || count == target.Index {
+			return append(matched, mob)
+		}
+	}
+
+	return matched
+}
+
+// ResolveRoomObjects resolves a target against the objects in the player's room.
+// Nothing is found when the room is too dark to see.
+func (w *World) ResolveRoomObjects(player *Player, arg string) []*Object {
+	if !w.CanSeeRoom(player) {
+		return nil
+	}
+	return SelectObjects(w.RoomObjectsSnapshot(player), ParseTarget(arg))
+}
+
+// ResolveInventory resolves a target against the player's inventory.
+func (w *World) ResolveInventory(player *Player, arg string) []*Object {
+	if player == nil {
+		return nil
+	}
+
+	w.mu.RLock()
+	items := append([]*Object(nil), player.Inventory...)
+	w.mu.RUnlock()
+
+	return SelectObjects(items, ParseTarget(arg))
+}
+
+// ResolveEquipment resolves a target against the player's worn equipment, in slot order.
+func (w *World) ResolveEquipment(player *Player, arg string) []*Object {
+	equipment := w.EquipmentSnapshot(player)
+	items := make([]*Object, 0, len(equipment))
+	for _, slot := range EquipSlotOrder {
+		if obj := equipment[slot]; obj != nil {
+			items = append(items, obj)
+		}
+	}
+
+	return SelectObjects(items, ParseTarget(arg))
+}
+
+// ResolveRoomMobiles resolves a target against the mobiles in the player's room
+// that the player can see.
+func (w *World) ResolveRoomMobiles(player *Player, arg string) []*Mobile {
+	w.mu.RLock()
+	room, ok := w.rooms[player.Location]
+	if !ok {
+		w.mu.RUnlock()
+		return nil
+	}
+	mobiles := make([]*Mobile, 0, len(room.Mobiles))
+	for _, mob := range room.Mobiles {
+		if w.canSee(player, MobileSubject(mob)) {
+			mobiles = append(mobiles, mob)
+		}
+	}
+	w.mu.RUnlock()
+
+	return SelectMobiles(mobiles, ParseTarget(arg))
+}
@@ -0,0 +1,82 @@
+package game
+
+import "testing"
+
+func TestParseTarget(t *testing.T) {
+	tests := []struct {
+		arg   string
+		words int
+		index int
+		all   bool
+	}{
+		{"goblin", 1, 0, false},
+		{"2.goblin", 1, 2, false},
+		{"all", 0, 0, true},
+		{"all.sword", 1, 0, true},
+		{"'big goblin'", 2, 0, false},
+		{"3.'big goblin'", 2, 3, false},
+	}
+
+	for _, tt := range tests {
+		got := ParseTarget(tt.arg)
+		if len(got.Words) != tt.words || got.Index != tt.index || got.All != tt.all || (tt.words > 0 && got.Words[0][0] == '\'') {
+			t.Errorf("ParseTarget(%q) = %+v", tt.arg, got)
+		}
+	}
+}
+
+func TestSplitArgs(t *testing.T) {
+	got := SplitArgs(`'arcane bolt' 2.goblin "red potion"`)
+	want := []string{"arcane bolt", "2.goblin", "red potion"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestFindMobInRoomOrdinal(t *testing.T) {
+	world := CreateDefaultWorld()
+	first := &Mobile{Keywords: []string{"goblin"}, Short: "a goblin scout"}
+	second := &Mobile{Keywords: []string{"goblin"}, Short: "a goblin mage"}
+	world.rooms[1].Mobiles = []*Mobile{first, second}
+
+	player := &Player{Name: "Alice", Output: &bufferOutput{}}
+	if err := world.AddPlayer(player); err != nil {
+		t.Fatalf("add player: %v", err)
+	}
+
+	if mob, ok := world.FindMobInRoom(player, "2.goblin"); !ok || mob != second {
+		t.Fatalf("expected 2.goblin to find the second goblin")
+	}
+	if mob, ok := world.FindMobInRoom(player, "'goblin mage'"); !ok || mob != second {
+		t.Fatalf("expected quoted keyword to find the goblin mage")
+	}
+	if _, ok := world.FindMobInRoom(player, "3.goblin"); ok {
+		t.Fatalf("expected 3.goblin to find nothing")
+	}
+}
+
+func TestResolveRoomObjectsAll(t *testing.T) {
+	world := CreateDefaultWorld()
+	world.rooms[1].Objects = []*Object{
+		{Keywords: []string{"sword"}, Short: "a short sword"},
+		{Keywords: []string{"shield"}, Short: "a round shield"},
+		{Keywords: []string{"sword"}, Short: "a long sword"},
+	}
+
+	player := &Player{Name: "Alice", Output: &bufferOutput{}}
+	if err := world.AddPlayer(player); err != nil {
+		t.Fatalf("add player: %v", err)
+	}
+
+	if got := world.ResolveRoomObjects(player, "all"); len(got) != 3 {
+		t.Fatalf("expected all to match 3 objects, got %d", len(got))
+	}
+	if got := world.ResolveRoomObjects(player, "all.sword"); len(got) != 2 {
+		t.Fatalf("expected all.sword to match 2 objects, got %d", len(got))
+	}
+}
@@ -0,0 +1,219 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"njata/internal/races"
+)
+
+// DefaultMaxMove is the movement pool of a new character.
+const DefaultMaxMove = 100
+
+// Sectors with movement requirements.
+const (
+	SectorWaterSwim   = "water_swim"
+	SectorWaterNoSwim = "water_noswim"
+	SectorOcean       = "ocean"
+	SectorUnderwater  = "underwater"
+	SectorAir         = "air"
+)
+
+// ObjectTypeBoat lets its carrier cross water sectors.
+const ObjectTypeBoat = "boat"
+
+// sectorMoveCost is the movement spent leaving or entering each sector; a step
+// costs the average of the two rooms. Unknown sectors cost defaultMoveCost.
+var sectorMoveCost = map[string]int{
+	"inside":          1,
+	"city":            2,
+	"field":           2,
+	"forest":          3,
+	"hills":           4,
+	"mountain":        6,
+	SectorWaterSwim:   4,
+	SectorWaterNoSwim: 1,
+	SectorOcean:       1,
+	SectorUnderwater:  6,
+	SectorAir:         10,
+	"desert":          6,
+	"underground":     3,
+}
+
+const defaultMoveCost = 2
+
+// Drowning damage, applied on each world update.
+const (
+	drownDamageDivisor = 8 // lose an eighth of MaxHP per update
+	minDrownDamage     = 5
+)
+
+// Movement errors, worded for the player.
+var (
+	ErrExhausted  = errors.New("You are too exhausted.")
+	ErrNeedBoat   = errors.New("You need a boat to go there.")
+	ErrNeedFlight = errors.New("You would need to fly to go there.")
+)
+
+// moveCost returns the movement points needed to walk from one room to another.
+func moveCost(from, to *Room) int {
+	cost := func(sector string) int {
+		if c, ok := sectorMoveCost[sector]; ok {
+			return c
+		}
+		return defaultMoveCost
+	}
+	total := (cost(from.Sector) + cost(to.Sector)) / 2
+	if total < 1 {
+		total = 1
+	}
+	return total
+}
+
+// isWaterSector reports whether crossing the sector needs a boat or swimming.
+func isWaterSector(sector string) bool {
+	return sector == SectorWaterSwim || sector == SectorOcean
+}
+
+// hasRacialAbility reports whether the player's race grants ability.
+func hasRacialAbility(p *Player, ability string) bool {
+	race := races.GetByID(p.Race)
+	return race != nil && race.HasAbility(ability)
+}
+
+// carriesType reports whether the player holds or wears an object of objType.
+func carriesType(p *Player, objType string) bool {
+	for _, obj := range p.Inventory {
+		if obj != nil && obj.Type == objType {
+			return true
+		}
+	}
+	for _, obj := range p.Equipment {
+		if obj != nil && obj.Type == objType {
+			return true
+		}
+	}
+	return false
+}
+
+// CanFly reports whether the player can fly.
+func CanFly(p *Player) bool {
+	return hasRacialAbility(p, races.AbilityFlight)
+}
+
+// CanBreatheWater reports whether the player can breathe underwater.
+func CanBreatheWater(p *Player) bool {
+	return hasRacialAbility(p, races.AbilityAquaBreath)
+}
+
+// canCrossWater reports whether the player can travel over deep water.
+func canCrossWater(p *Player) bool {
+	return canSail(p) || hasRacialAbility(p, races.AbilitySwimming)
+}
+
+// canSail reports whether the player can cross water too rough to swim.
+func canSail(p *Player) bool {
+	return carriesType(p, ObjectTypeBoat) || CanFly(p)
+}
+
+// checkTerrain applies the sector requirements for stepping from one room
+// into another and returns the movement cost. Keepers travel freely.
+func checkTerrain(p *Player, from, to *Room) (int, error) {
+	if p.IsKeeper {
+		return 0, nil
+	}
+
+	switch {
+	case to.Sector == SectorAir && !CanFly(p):
+		return 0, ErrNeedFlight
+	case to.Sector == SectorWaterNoSwim && !canSail(p):
+		return 0, ErrNeedBoat
+	case isWaterSector(to.Sector) && !canCrossWater(p):
+		return 0, ErrNeedBoat
+	}
+
+	cost := moveCost(from, to)
+	if CanFly(p) {
+		cost = 1
+	}
+	if p.Move < cost {
+		return 0, ErrExhausted
+	}
+	return cost, nil
+}
+
+// UpdateTick runs the periodic world update: players and mobiles regenerate,
+// affects wear off, anyone underwater without water-breathing drowns,
+// mobiles act on their behaviors, random and timer scripts run, and every few
+// pulses the clock advances an hour.
+func (w *World) UpdateTick() {
+	type wornOff struct {
+		player *Player
+		names  []string
+	}
+	var drowning []*Player
+	var expired []wornOff
+
+	now := time.Now()
+
+	w.mu.Lock()
+	w.pulse++
+	w.ticks++
+	tick := w.ticks
+	newHour := w.pulse >= pulsesPerHour
+	if newHour {
+		w.pulse = 0
+	}
+	for _, p := range w.players {
+		if names := expireAffects(p, now); len(names) > 0 {
+			expired = append(expired, wornOff{p, names})
+		}
+		regenPlayer(p)
+
+		room, ok := w.rooms[p.Location]
+		if ok && room.Sector == SectorUnderwater && !p.IsKeeper && !CanBreatheWater(p) {
+			drowning = append(drowning, p)
+		}
+	}
+	for _, room := range w.rooms {
+		for _, m := range room.Mobiles {
+			regenMobile(m)
+		}
+	}
+	w.mu.Unlock()
+
+	for _, e := range expired {
+		for _, name := range e.names {
+			e.player.Output.WriteLine(fmt.Sprintf("Your %s wears off.", name))
+		}
+	}
+	for _, p := range drowning {
+		w.drown(p)
+	}
+	w.decayCorpses(now)
+	w.mobileUpdate()
+	w.scriptUpdate(tick)
+	if newHour {
+		w.advanceHour()
+	}
+}
+
+// drown deals drowning damage, killing the player if it runs out their HP.
+func (w *World) drown(p *Player) {
+	damage := p.MaxHP / drownDamageDivisor
+	if damage < minDrownDamage {
+		damage = minDrownDamage
+	}
+
+	w.mu.Lock()
+	p.HP -= damage
+	dead := p.HP <= 0
+	w.mu.Unlock()
+
+	p.Output.WriteLine("&BYou can't breathe! You are drowning!&w")
+	w.Act("$n thrashes about, struggling for air.", PlayerSubject(p), Subject{}, nil, ToRoom)
+	if dead {
+		w.killPlayer(p)
+	}
+}
@@ -0,0 +1,176 @@
+package game
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"njata/internal/races"
+)
+
+func newTerrainWorld(t *testing.T) (*World, *Player) {
+	t.Helper()
+
+	rooms := map[int]*Room{
+		1: {Vnum: 1, Name: "Beach", Sector: "field", Flags: map[string]bool{}, Exits: map[string]int{"east": 2, "west": 3, "up": 4, "north": 5, "south": 7}},
+		2: {Vnum: 2, Name: "Dunes", Sector: "desert", Flags: map[string]bool{}, Exits: map[string]int{"west": 1}},
+		3: {Vnum: 3, Name: "Surf", Sector: SectorOcean, Flags: map[string]bool{}, Exits: map[string]int{"east": 1, "down": 6}},
+		4: {Vnum: 4, Name: "Sky", Sector: SectorAir, Flags: map[string]bool{}, Exits: map[string]int{"down": 1}},
+		5: {Vnum: 5, Name: "Tavern", Sector: "inside", Flags: map[string]bool{}, Exits: map[string]int{"south": 1}},
+		6: {Vnum: 6, Name: "Reef", Sector: SectorUnderwater, Flags: map[string]bool{}, Exits: map[string]int{"up": 3}},
+		7: {Vnum: 7, Name: "Rapids", Sector: SectorWaterNoSwim, Flags: map[string]bool{}, Exits: map[string]int{"north": 1}},
+	}
+	world := CreateWorldFromRooms(rooms, 1)
+
+	player := &Player{Name: "alice", Output: &bufferOutput{}, HP: 40, MaxHP: 40}
+	if err := world.AddPlayer(player); err != nil {
+		t.Fatalf("add player: %v", err)
+	}
+	return world, player
+}
+
+func loadTerrainRaces(t *testing.T) {
+	t.Helper()
+
+	dir := t.TempDir()
+	t.Cleanup(races.Preserve())
+	files := map[string]string{
+		"Merfolk.json": `{"name": "Merfolk", "race_id": 10, "abilities": ["aqua_breath", "swimming"]}`,
+		"Fairy.json":   `{"name": "Fairy", "race_id": 16, "abilities": ["flight"]}`,
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatalf("write race: %v", err)
+		}
+	}
+	if err := races.Load(dir); err != nil {
+		t.Fatalf("load races: %v", err)
+	}
+}
+
+func TestMovementSpendsPointsBySector(t *testing.T) {
+	world, player := newTerrainWorld(t)
+
+	if player.Move != DefaultMaxMove {
+		t.Fatalf("expected new player to start with %d move, got %d", DefaultMaxMove, player.Move)
+	}
+
+	if _, err := world.MovePlayer(player, "east"); err != nil {
+		t.Fatalf("move east: %v", err)
+	}
+	// field (2) to desert (6) averages 4.
+	if want := DefaultMaxMove - 4; player.Move != want {
+		t.Fatalf("expected %d move after crossing into the desert, got %d", want, player.Move)
+	}
+
+	player.Move = 1
+	if _, err := world.MovePlayer(player, "west"); !errors.Is(err, ErrExhausted) {
+		t.Fatalf("expected exhaustion, got %v", err)
+	}
+	if player.Location != 2 {
+		t.Fatalf("expected exhausted player to stay put, got room %d", player.Location)
+	}
+}
+
+func TestWaterNeedsBoatOrSwimming(t *testing.T) {
+	loadTerrainRaces(t)
+	world, player := newTerrainWorld(t)
+
+	if _, err := world.MovePlayer(player, "west"); !errors.Is(err, ErrNeedBoat) {
+		t.Fatalf("expected boat requirement, got %v", err)
+	}
+
+	player.Inventory = append(player.Inventory, &Object{Type: ObjectTypeBoat, Keywords: []string{"canoe"}, Short: "a canoe"})
+	if _, err := world.MovePlayer(player, "west"); err != nil {
+		t.Fatalf("expected boat to carry player over water: %v", err)
+	}
+
+	swimmer := &Player{Name: "bob", Output: &bufferOutput{}, Race: 10}
+	if err := world.AddPlayer(swimmer); err != nil {
+		t.Fatalf("add player: %v", err)
+	}
+	if _, err := world.MovePlayer(swimmer, "west"); err != nil {
+		t.Fatalf("expected merfolk to swim: %v", err)
+	}
+}
+
+func TestNoSwimWaterNeedsBoatOrFlight(t *testing.T) {
+	loadTerrainRaces(t)
+	world, player := newTerrainWorld(t)
+	player.Race = 10
+
+	if _, err := world.MovePlayer(player, "south"); !errors.Is(err, ErrNeedBoat) {
+		t.Fatalf("expected swimmers to need a boat, got %v", err)
+	}
+
+	player.Inventory = append(player.Inventory, &Object{Type: ObjectTypeBoat, Keywords: []string{"canoe"}, Short: "a canoe"})
+	if _, err := world.MovePlayer(player, "south"); err != nil {
+		t.Fatalf("expected boat to carry player over the rapids: %v", err)
+	}
+}
+
+func TestAirNeedsFlight(t *testing.T) {
+	loadTerrainRaces(t)
+	world, player := newTerrainWorld(t)
+
+	if _, err := world.MovePlayer(player, "up"); !errors.Is(err, ErrNeedFlight) {
+		t.Fatalf("expected flight requirement, got %v", err)
+	}
+
+	player.Race = 16
+	if _, err := world.MovePlayer(player, "up"); err != nil {
+		t.Fatalf("expected fairy to fly: %v", err)
+	}
+}
+
+func TestKeeperIgnoresTerrain(t *testing.T) {
+	world, player := newTerrainWorld(t)
+	player.IsKeeper = true
+	player.Move = 0
+
+	if _, err := world.MovePlayer(player, "up"); err != nil {
+		t.Fatalf("expected keeper to move freely: %v", err)
+	}
+}
+
+func TestUpdateTickRegeneratesMovement(t *testing.T) {
+	world, player := newTerrainWorld(t)
+	player.Move = 0
+
+	world.UpdateTick()
+
+	if want := DefaultMaxMove / moveRegenDivisor; player.Move != want {
+		t.Fatalf("expected %d move after one tick, got %d", want, player.Move)
+	}
+}
+
+func TestUnderwaterDrowning(t *testing.T) {
+	loadTerrainRaces(t)
+	world, player := newTerrainWorld(t)
+	player.Location = 6
+
+	world.UpdateTick()
+
+	if player.HP >= player.MaxHP {
+		t.Fatalf("expected drowning damage, HP %d/%d", player.HP, player.MaxHP)
+	}
+	if !player.Output.(*bufferOutput).Contains("drowning") {
+		t.Fatalf("expected drowning message")
+	}
+
+	player.HP = 1
+	world.UpdateTick()
+	if player.Location != 1 || player.HP != 1 {
+		t.Fatalf("expected drowned player to be returned to start, room %d HP %d", player.Location, player.HP)
+	}
+
+	merfolk := &Player{Name: "bob", Output: &bufferOutput{}, Race: 10, HP: 40, MaxHP: 40, Location: 6}
+	if err := world.AddPlayer(merfolk); err != nil {
+		t.Fatalf("add player: %v", err)
+	}
+	world.UpdateTick()
+	if merfolk.HP != merfolk.MaxHP {
+		t.Fatalf("expected merfolk to breathe underwater, HP %d", merfolk.HP)
+	}
+}
@@ -0,0 +1,202 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Sky is the state of the sky over an area.
+type Sky int
+
+const (
+	SkyCloudless Sky = iota
+	SkyCloudy
+	SkyRaining
+	SkyLightning
+)
+
+var skyNames = [...]string{"cloudless", "cloudy", "rainy", "lit by flashes of lightning"}
+
+func (s Sky) String() string {
+	if s < 0 || int(s) >= len(skyNames) {
+		return "strange"
+	}
+	return skyNames[s]
+}
+
+// Barometric limits for the classic weather model, in mmHg.
+const (
+	minPressure = 960
+	maxPressure = 1040
+	maxChange   = 12
+)
+
+// Weather is the evolving weather over one area.
+type Weather struct {
+	Sky      Sky `json:"sky"`
+	Pressure int `json:"pressure"` // mmHg; low pressure brings rain
+	Change   int `json:"change"`   // pressure trend per hour
+}
+
+// Description renders the weather the way the weather command shows it.
+func (wx Weather) Description() string {
+	wind := "a warm southerly breeze blows"
+	if wx.Change < 0 {
+		wind = "a cold northern gust blows"
+	}
+	return fmt.Sprintf("The sky is %s and %s.", wx.Sky, wind)
+}
+
+// IsRaining reports whether rain is falling.
+func (wx Weather) IsRaining() bool {
+	return wx.Sky >= SkyRaining
+}
+
+// randomWeather seeds an area's weather from a random pressure.
+func randomWeather() *Weather {
+	wx := &Weather{Pressure: minPressure + rand.Intn(maxPressure-minPressure+1)}
+	switch {
+	case wx.Pressure <= 980:
+		wx.Sky = SkyLightning
+	case wx.Pressure <= 1000:
+		wx.Sky = SkyRaining
+	case wx.Pressure <= 1020:
+		wx.Sky = SkyCloudy
+	default:
+		wx.Sky = SkyCloudless
+	}
+	return wx
+}
+
+// newWeatherMap seeds weather for every area that has rooms.
+func newWeatherMap(rooms map[int]*Room) map[string]*Weather {
+	weather := map[string]*Weather{}
+	for _, room := range rooms {
+		if _, ok := weather[room.AreaName]; !ok {
+			weather[room.AreaName] = randomWeather()
+		}
+	}
+	return weather
+}
+
+// dice rolls count dice of the given size.
+func dice(count, size int) int {
+	total := 0
+	for i := 0; i < count; i++ {
+		total += rand.Intn(size) + 1
+	}
+	return total
+}
+
+// evolve advances the weather by an hour and returns the message outdoor
+// players see if the sky changed. Pressure drifts toward a seasonal norm:
+// lower in the warm months, higher in winter.
+func (wx *Weather) evolve(month int) string {
+	diff := 2
+	if month >= 9 && month <= 16 {
+		if wx.Pressure > 985 {
+			diff = -2
+		}
+	} else if wx.Pressure > 1015 {
+		diff = -2
+	}
+
+	wx.Change += diff*dice(1, 4) + dice(2, 6) - dice(2, 6)
+	wx.Change = clamp(wx.Change, -maxChange, maxChange)
+	wx.Pressure = clamp(wx.Pressure+wx.Change, minPressure, maxPressure)
+
+	chance := rand.Intn(3) == 0
+	switch wx.Sky {
+	case SkyCloudless:
+		if wx.Pressure < 990 || (wx.Pressure < 1010 && chance) {
+			wx.Sky = SkyCloudy
+			return "The sky is getting cloudy."
+		}
+	case SkyCloudy:
+		if wx.Pressure < 970 || (wx.Pressure < 990 && chance) {
+			wx.Sky = SkyRaining
+			return "&BIt starts to rain.&w"
+		}
+		if wx.Pressure > 1030 && chance {
+			wx.Sky = SkyCloudless
+			return "The clouds disappear."
+		}
+	case SkyRaining:
+		if wx.Pressure < 970 && chance {
+			wx.Sky = SkyLightning
+			return "&WLightning flashes in the sky.&w"
+		}
+		if wx.Pressure > 1030 || (wx.Pressure > 1010 && chance) {
+			wx.Sky = SkyCloudy
+			return "The rain stopped."
+		}
+	case SkyLightning:
+		if wx.Pressure > 1010 || (wx.Pressure > 990 && chance) {
+			wx.Sky = SkyRaining
+			return "The lightning has stopped."
+		}
+	}
+	return ""
+}
+
+func clamp(value, low, high int) int {
+	if value < low {
+		return low
+	}
+	if value > high {
+		return high
+	}
+	return value
+}
+
+// AreaWeather returns the weather over the named area.
+func (w *World) AreaWeather(area string) (Weather, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	wx, ok := w.weather[area]
+	if !ok {
+		return Weather{}, false
+	}
+	return *wx, true
+}
+
+// RoomWeather returns the weather a player standing in the room at vnum
+// would see. Indoor rooms have no weather.
+func (w *World) RoomWeather(vnum int) (Weather, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	room, ok := w.rooms[vnum]
+	if !ok || !isOutdoors(room) {
+		return Weather{}, false
+	}
+	wx, ok := w.weather[room.AreaName]
+	if !ok {
+		return Weather{}, false
+	}
+	return *wx, true
+}
+
+// WeatherSnapshot copies the weather of every area, for saving.
+func (w *World) WeatherSnapshot() map[string]Weather {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	snapshot := make(map[string]Weather, len(w.weather))
+	for area, wx := range w.weather {
+		snapshot[area] = *wx
+	}
+	return snapshot
+}
+
+// SetWeather restores saved weather. Areas not mentioned keep their current weather.
+func (w *World) SetWeather(weather map[string]Weather) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for area, wx := range weather {
+		copied := wx
+		w.weather[area] = &copied
+	}
+}
@@ -4,11 +4,14 @@ import (
 	"fmt"
 	"math/rand"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 	"unicode"
 
+	"njata/internal/quests"
+	"njata/internal/script"
 	"njata/internal/skills"
 )
 
@@ -32,7 +35,10 @@ type Player struct {
 	MaxHP   int
 	Mana    int
 	MaxMana int
+	Move    int // movement points, spent walking between rooms
+	MaxMove int
 	Gold    int
+	Bank    int // gold deposited with a banker
 
 	// Attribute scores
 	Strength     int
@@ -46,9 +52,27 @@ type Player struct {
 	// Combat stats (modified by equipment)
 	Armor int
 
+	// Position and recovery
+	Position   string    // standing, sitting, resting or sleeping ("" = standing)
+	LastCombat time.Time // last time the player hit or was hit; blocks regen for a while
+	Affects    []Affect  // timed effects, e.g. from spells
+
 	// Skills tracking
 	Skills map[int]*skills.PlayerSkillProgress // spell_id -> proficiency progress
 
+	// Quest tracking (see quests.go)
+	Quests map[int]*quests.PlayerQuestProgress // quest_id -> progress, kept once completed
+
+	// Following and grouping (see group.go)
+	Master   *Player // player being followed
+	Leader   *Player // leader of the player's group (the leader points at themselves); nil if ungrouped
+	LootRule string  // how a group leader's group shares loot ("" = free)
+	LootTurn int     // next member in line for round-robin loot
+
+	// Player-versus-player combat (see pvp.go)
+	PvP        bool      // willing to fight other players
+	PvPToggled time.Time // last time PvP was turned on or off
+
 	// Inventory tracking
 	Inventory []*Object
 
@@ -57,6 +81,21 @@ type Player struct {
 
 	// Keeper flag - player who maintains the world
 	IsKeeper bool
+
+	// Communication
+	ChannelsOff map[string]bool // channels the player has turned off
+	Ignoring    map[string]bool // normalized names the player ignores
+	ReplyTo     string          // last player to send a tell
+
+	// Input conveniences
+	Aliases     map[string]string // alias name -> command template
+	LastCommand string            // last raw input line, repeated by "!"
+
+	// Connection state
+	LinkDead      bool      // connection dropped; character lingers in the world
+	LinkDeadSince time.Time // when the connection dropped
+	LastInput     time.Time // last command received from the player
+	IdleFrom      int       // room vnum to return to after being moved to limbo (0 = not idle)
 }
 
 const (
@@ -93,6 +132,28 @@ type Mobile struct {
 	MaxMana    int
 	Attributes [7]int // STR, INT, WIS, DEX, CON, LCK, CHA
 	Loot       []LootEntry
+	GoldMin    int // gold left on the corpse is rolled from GoldMin..GoldMax
+	GoldMax    int
+	LastCombat time.Time // last time the mobile fought; blocks regen for a while
+
+	// Damage defenses, on top of its race's (see saves.go)
+	Resistances     []string // damage types that deal half damage
+	Immunities      []string // damage types that deal none
+	Vulnerabilities []string // damage types that deal half again
+
+	// Behavior (see mobai.go)
+	Behaviors map[string]bool // sentinel, aggressive, scavenger, wimpy, assist_race, assist_area
+	AreaName  string          // area the mobile belongs to
+	Inventory []*Object       // items picked up by scavenging
+
+	// Combat abilities (see mobcast.go)
+	Skills    []MobSkill        // spells and maneuvers the mobile can use
+	Cooldowns map[int]time.Time // spell ID -> when it can be used again
+
+	Scripts []script.Script // triggers (see scripts.go)
+
+	Shop     *Shop // non-nil for shopkeepers (see shop.go)
+	IsBanker bool  // keeps players' gold (see economy.go)
 
 	// Trainer metadata (if this mobile is a trainer)
 	IsTrainer         bool   // true if this mob teaches maneuvers
@@ -115,6 +176,7 @@ type Object struct {
 	Long      string
 	Weight    int
 	Value     [4]int // [0]=quantity [1]=unused [2]=unused [3]=spell_id for magical items
+	Cost      int    // base price in gold when bought or sold in a shop
 	Flags     map[string]bool
 	EquipSlot string // "head", "body", "neck", "back", "waist", or empty for non-equippable
 	ArmorVal  int    // bonus to player.Armor when worn
@@ -123,6 +185,13 @@ type Object struct {
 	TeachesSpellID int  // spell ID taught by this item
 	TeachesAmount  int  // proficiency % to grant (0 = use default 30%)
 	Consumable     bool // true if item is destroyed after studying
+
+	// Containers and corpses
+	Contents []*Object `json:",omitempty"` // items inside
+	Owner    string    `json:",omitempty"` // normalized name of a dead player, for their corpse
+	DecayAt  time.Time `json:"-"`          // corpses rot away at this time (zero = never)
+
+	Scripts []script.Script `json:",omitempty"` // triggers (see scripts.go)
 }
 
 type Room struct {
@@ -140,15 +209,18 @@ type Room struct {
 	Objects          []*Object
 	MobileResets     []Reset
 	ObjectResets     []Reset
+	Scripts          []script.Script // triggers (see scripts.go)
 }
 
 type Reset struct {
-	MobVnum int // for Mobile resets
-	ObjVnum int // for Object resets
-	Count   int // how many to load
+	MobVnum int         // for Mobile resets
+	ObjVnum int         // for Object resets
+	Count   int         // how many to load
+	Items   []LootEntry // objects each loaded mobile carries, e.g. a shopkeeper's stock
 }
 
 type RoomView struct {
+	Dark        bool // too dark to see the room; only visible characters are listed
 	Name        string
 	Description string
 	Exits       []string
@@ -157,6 +229,7 @@ type RoomView struct {
 	Objects     []string // Object descriptions
 	AreaName    string
 	AreaAuthor  string
+	Followers   []*Player // followers who came along when the player moved
 }
 
 type World struct {
@@ -167,6 +240,15 @@ type World struct {
 	mobiles         map[int]*Mobile      // Prototypes for respawning
 	objects         map[int]*Object      // Prototypes for respawning
 	areaLastRespawn map[string]time.Time // Track when each area last respawned
+	channels        map[string]*Channel  // Global communication channels
+	night           bool                 // sun is down; outdoor rooms are dark
+	clock           GameTime             // world calendar
+	weather         map[string]*Weather  // area name -> weather
+	pulse           int                  // world updates since the last game hour
+	ticks           int                  // world updates since boot, for timer scripts
+	death           DeathPolicy          // death penalties and corpse decay
+	ledger          Ledger               // gold created and destroyed
+	pkills          []PKill              // recent player kills, oldest first
 }
 
 func CreateDefaultWorld() *World {
@@ -180,13 +262,19 @@ func CreateDefaultWorld() *World {
 		ExDescs:     map[string]string{},
 	}
 
+	rooms := map[int]*Room{defaultRoom.Vnum: defaultRoom}
 	return &World{
-		rooms:           map[int]*Room{defaultRoom.Vnum: defaultRoom},
+		rooms:           rooms,
 		start:           defaultRoom.Vnum,
 		players:         map[string]*Player{},
 		mobiles:         map[int]*Mobile{},
 		objects:         map[int]*Object{},
 		areaLastRespawn: map[string]time.Time{},
+		channels:        defaultChannels(),
+		clock:           defaultClock,
+		night:           defaultClock.IsNight(),
+		weather:         newWeatherMap(rooms),
+		death:           DefaultDeathPolicy,
 	}
 }
 
@@ -210,6 +298,11 @@ func CreateWorldFromRooms(rooms map[int]*Room, start int) *World {
 		mobiles:         map[int]*Mobile{},
 		objects:         map[int]*Object{},
 		areaLastRespawn: map[string]time.Time{},
+		channels:        defaultChannels(),
+		clock:           defaultClock,
+		night:           defaultClock.IsNight(),
+		weather:         newWeatherMap(rooms),
+		death:           DefaultDeathPolicy,
 	}
 }
 
@@ -277,6 +370,10 @@ func (w *World) AddPlayer(player *Player) error {
 	if player.Location == 0 {
 		player.Location = w.start
 	}
+	if player.MaxMove <= 0 {
+		player.MaxMove = DefaultMaxMove
+		player.Move = player.MaxMove
+	}
 
 	w.players[key] = player
 	return nil
@@ -285,8 +382,13 @@ func (w *World) AddPlayer(player *Player) error {
 func (w *World) RemovePlayer(name string) {
 	key := normalizeName(name)
 	w.mu.Lock()
-	defer w.mu.Unlock()
+	var notices []notice
+	if player, ok := w.players[key]; ok {
+		notices = w.dropGroupLinks(player)
+	}
 	delete(w.players, key)
+	w.mu.Unlock()
+	sendNotices(notices)
 }
 
 func (w *World) PlayersSnapshot() []*Player {
@@ -305,7 +407,11 @@ func (w *World) ListPlayers() []string {
 	players := w.PlayersSnapshot()
 	names := make([]string, 0, len(players))
 	for _, player := range players {
-		names = append(names, CapitalizeName(player.Name))
+		name := CapitalizeName(player.Name)
+		if player.LinkDead {
+			name += " (linkdead)"
+		}
+		names = append(names, name)
 	}
 
 	sort.Strings(names)
@@ -349,13 +455,13 @@ func (w *World) FindPlayerInRoom(player *Player, keyword string) (*Player, bool)
 		return nil, false
 	}
 
-	key := strings.ToLower(strings.TrimSpace(keyword))
+	key := strings.ToLower(strings.TrimSpace(unquote(keyword)))
 	if key == "" {
 		return nil, false
 	}
 
 	for _, other := range w.players {
-		if other.Location != room.Vnum {
+		if other.Location != room.Vnum || !w.canSee(player, PlayerSubject(other)) {
 			continue
 		}
 
@@ -379,22 +485,16 @@ func (w *World) DescribeRoom(player *Player) (RoomView, error) {
 
 	others := make([]string, 0, len(w.players))
 	for _, other := range w.players {
-		if other.Location == room.Vnum && !strings.EqualFold(other.Name, player.Name) {
+		if other.Location == room.Vnum && !strings.EqualFold(other.Name, player.Name) && w.canSee(player, PlayerSubject(other)) {
 			others = append(others, CapitalizeName(other.Name))
 		}
 	}
 	sort.Strings(others)
 
-	exits := make([]string, 0, len(room.Exits))
-	for exit := range room.Exits {
-		exits = append(exits, exit)
-	}
-	sort.Strings(exits)
-
 	// Format mobiles
 	mobiles := make([]string, 0, len(room.Mobiles))
 	for _, mob := range room.Mobiles {
-		if mob.Short != "" {
+		if mob.Short != "" && w.canSee(player, MobileSubject(mob)) {
 			position := strings.TrimSpace(mob.Position)
 			if position == "" {
 				position = "standing"
@@ -404,6 +504,24 @@ func (w *World) DescribeRoom(player *Player) (RoomView, error) {
 	}
 	sort.Strings(mobiles)
 
+	if !w.canSeeRoom(player) {
+		return RoomView{
+			Dark:        true,
+			Name:        "Darkness",
+			Description: "It is pitch black ...",
+			Others:      others,
+			Mobiles:     mobiles,
+			AreaName:    room.AreaName,
+			AreaAuthor:  room.AreaAuthor,
+		}, nil
+	}
+
+	exits := make([]string, 0, len(room.Exits))
+	for exit := range room.Exits {
+		exits = append(exits, exit)
+	}
+	sort.Strings(exits)
+
 	// Format objects
 	objects := make([]string, 0, len(room.Objects))
 	for _, obj := range room.Objects {
@@ -453,11 +571,11 @@ func (w *World) FindRoomExDesc(player *Player, keyword string) (string, bool) {
 		return "", false
 	}
 
-	if room.ExDescs == nil {
+	if room.ExDescs == nil || !w.canSeeRoom(player) {
 		return "", false
 	}
 
-	key := strings.ToLower(strings.TrimSpace(keyword))
+	key := strings.ToLower(strings.TrimSpace(unquote(keyword)))
 	if key == "" {
 		return "", false
 	}
@@ -486,63 +604,58 @@ func (w *World) MovePlayer(player *Player, direction string) (RoomView, error) {
 		return RoomView{}, fmt.Errorf("exit leads nowhere")
 	}
 
-	player.Location = targetRoom.Vnum
-	w.mu.Unlock()
-
-	return w.DescribeRoom(player)
-}
+	if err := checkCanMove(player); err != nil {
+		w.mu.Unlock()
+		return RoomView{}, err
+	}
 
-func (w *World) BroadcastSay(speaker *Player, message string) {
-	w.mu.RLock()
-	location := speaker.Location
-	players := make([]*Player, 0, len(w.players))
-	for _, player := range w.players {
-		if player.Location == location {
-			players = append(players, player)
-		}
+	if err := w.canEnter(player, targetRoom.Vnum); err != nil {
+		w.mu.Unlock()
+		return RoomView{}, err
 	}
-	w.mu.RUnlock()
 
-	for _, player := range players {
-		if strings.EqualFold(player.Name, speaker.Name) {
-			player.Output.WriteLine(fmt.Sprintf("You say '%s'", message))
-			continue
-		}
-		player.Output.WriteLine(fmt.Sprintf("%s says '%s'", CapitalizeName(speaker.Name), message))
+	cost, err := checkTerrain(player, room, targetRoom)
+	if err != nil {
+		w.mu.Unlock()
+		return RoomView{}, err
 	}
+
+	player.Move -= cost
+	notes := placePlayer(player, targetRoom.Vnum)
+	w.mu.Unlock()
+
+	writeNotes(player, notes)
+	w.runExitTriggers(player, room.Vnum, direction)
+	followers := w.followLeader(player, room.Vnum, direction)
+	view, err := w.DescribeRoom(player)
+	view.Followers = followers
+	return view, err
 }
 
-func (w *World) BroadcastChat(speaker *Player, message string) {
-	w.mu.RLock()
-	players := make([]*Player, 0, len(w.players))
-	for _, player := range w.players {
-		players = append(players, player)
+func (w *World) BroadcastSay(speaker *Player, message string) error {
+	if err := w.CanSpeak(speaker); err != nil {
+		return err
 	}
-	w.mu.RUnlock()
-
-	for _, player := range players {
-		if strings.EqualFold(player.Name, speaker.Name) {
-			player.Output.WriteLine(fmt.Sprintf("&Y[Chat] You: %s&w", message))
-			continue
-		}
-		player.Output.WriteLine(fmt.Sprintf("&Y[Chat] %s: %s&w", CapitalizeName(speaker.Name), message))
+	if err := w.checkChannelRules("say", message); err != nil {
+		return err
 	}
+
+	vars := ActVars{"message": message}
+	w.Act("You say '$message'", PlayerSubject(speaker), Subject{}, vars, ToActor)
+	w.Act("$n says '$message'", PlayerSubject(speaker), Subject{}, vars, ToRoom)
+	w.RunSpeechTriggers(speaker, message)
+	return nil
 }
 
-func (w *World) BroadcastSystemToRoomExcept(except *Player, message string) {
-	w.mu.RLock()
-	location := except.Location
-	players := make([]*Player, 0, len(w.players))
-	for _, player := range w.players {
-		if player.Location == location && !strings.EqualFold(player.Name, except.Name) {
-			players = append(players, player)
-		}
-	}
-	w.mu.RUnlock()
+// BroadcastChat sends a message on the global chat channel.
+func (w *World) BroadcastChat(speaker *Player, message string) error {
+	return w.BroadcastChannel(speaker, "chat", message)
+}
 
-	for _, player := range players {
-		player.Output.WriteLine(message)
-	}
+// BroadcastSystemToRoomExcept sends an act() template to everyone in except's
+// room but except, who is the template's actor ($n).
+func (w *World) BroadcastSystemToRoomExcept(except *Player, message string) {
+	w.Act(message, PlayerSubject(except), Subject{}, nil, ToRoom)
 }
 
 func normalizeName(name string) string {
@@ -596,16 +709,28 @@ func (w *World) RespawnTick(defaultMinutes int, logger func(string)) {
 		if respawnDue {
 			// Respawn this area
 			for _, room := range rooms {
-				// Clear existing mobs and objects
+				// Clear existing mobs and objects, sparing player corpses
+				// so their owners can still recover them. Gold the mobs
+				// scavenged leaves the world with them.
+				for _, mob := range room.Mobiles {
+					w.burnGold(LedgerAreaResets, looseGold(mob.Inventory))
+				}
 				room.Mobiles = make([]*Mobile, 0)
-				room.Objects = make([]*Object, 0)
+				kept := make([]*Object, 0)
+				for _, obj := range room.Objects {
+					if IsCorpse(obj) && obj.Owner != "" {
+						kept = append(kept, obj)
+						continue
+					}
+					w.burnGold(LedgerAreaResets, looseGold([]*Object{obj}))
+				}
+				room.Objects = kept
 
 				// Re-instantiate from resets
 				for _, reset := range room.MobileResets {
 					if proto, ok := w.mobiles[reset.MobVnum]; ok {
 						for i := 0; i < reset.Count; i++ {
-							mobCopy := *proto
-							room.Mobiles = append(room.Mobiles, &mobCopy)
+							room.Mobiles = append(room.Mobiles, ResetMobile(proto, reset, w.objects))
 						}
 					}
 				}
@@ -637,89 +762,56 @@ func (w *World) RespawnTick(defaultMinutes int, logger func(string)) {
 	logger(fmt.Sprintf("Respawn tick: %d/%d areas respawned - %s", areasRespawned, len(areaRooms), strings.Join(respawnLog, ", ")))
 }
 
-// FindMobInRoom searches for a mobile in the player's current room by keyword
+// FindMobInRoom searches for a mobile in the player's current room by keyword.
+// Keywords may use the "2.goblin" ordinal form or be quoted multi-word phrases.
 func (w *World) FindMobInRoom(player *Player, keyword string) (*Mobile, bool) {
-	w.mu.RLock()
-	defer w.mu.RUnlock()
-
-	room, ok := w.rooms[player.Location]
-	if !ok {
+	if ParseTarget(keyword).All {
 		return nil, false
 	}
 
-	keyword = strings.ToLower(keyword)
-	for _, mob := range room.Mobiles {
-		// Check if keyword matches any of the mob's keywords
-		for _, mobKeyword := range mob.Keywords {
-			if strings.ToLower(mobKeyword) == keyword {
-				return mob, true
-			}
-		}
-		// Also check if keyword appears in mob's short description
-		if strings.Contains(strings.ToLower(mob.Short), keyword) {
-			return mob, true
-		}
+	matches := w.ResolveRoomMobiles(player, keyword)
+	if len(matches) == 0 {
+		return nil, false
 	}
+	return matches[0], true
+}
 
-	return nil, false
+// CanTake reports whether obj can be picked up off the floor. Fountains,
+// furniture, corpses and items flagged notake stay put.
+func CanTake(obj *Object) bool {
+	if obj == nil {
+		return false
+	}
+	if obj.Flags != nil && (obj.Flags["notake"] || obj.Flags["no_take"]) {
+		return false
+	}
+	return obj.Type != "fountain" && obj.Type != "furniture" && obj.Type != ObjectTypeCorpse
 }
 
 // FindObjectInRoom searches for an object in the player's current room by keyword
 func (w *World) FindObjectInRoom(player *Player, keyword string) (*Object, bool) {
-	w.mu.RLock()
-	defer w.mu.RUnlock()
-
-	room, ok := w.rooms[player.Location]
-	if !ok {
+	if ParseTarget(keyword).All {
 		return nil, false
 	}
 
-	keyword = strings.ToLower(keyword)
-	for _, obj := range room.Objects {
-		// Check if keyword matches any of the object's keywords
-		for _, objKeyword := range obj.Keywords {
-			if strings.ToLower(objKeyword) == keyword {
-				return obj, true
-			}
-		}
-		// Also check if keyword appears in object's short description
-		if strings.Contains(strings.ToLower(obj.Short), keyword) {
-			return obj, true
-		}
+	matches := w.ResolveRoomObjects(player, keyword)
+	if len(matches) == 0 {
+		return nil, false
 	}
-
-	return nil, false
+	return matches[0], true
 }
 
 // FindObjectInInventory searches for an object in the player's inventory by keyword
 func (w *World) FindObjectInInventory(player *Player, keyword string) (*Object, bool) {
-	w.mu.RLock()
-	defer w.mu.RUnlock()
-
-	if player == nil {
+	if ParseTarget(keyword).All {
 		return nil, false
 	}
 
-	key := strings.ToLower(strings.TrimSpace(keyword))
-	if key == "" {
+	matches := w.ResolveInventory(player, keyword)
+	if len(matches) == 0 {
 		return nil, false
 	}
-
-	for _, obj := range player.Inventory {
-		if obj == nil {
-			continue
-		}
-		for _, objKeyword := range obj.Keywords {
-			if strings.ToLower(objKeyword) == key {
-				return obj, true
-			}
-		}
-		if strings.Contains(strings.ToLower(obj.Short), key) {
-			return obj, true
-		}
-	}
-
-	return nil, false
+	return matches[0], true
 }
 
 // RemoveObjectFromRoom removes an object from the player's current room
@@ -768,6 +860,7 @@ func (w *World) AddObjectToInventory(player *Player, obj *Object) bool {
 	}
 
 	player.Inventory = append(player.Inventory, obj)
+	pocketMoney(player)
 	return true
 }
 
@@ -790,35 +883,64 @@ func (w *World) RemoveObjectFromInventory(player *Player, obj *Object) bool {
 	return false
 }
 
-// FindObjectInEquipment searches for an object in the player's equipment by keyword
-func (w *World) FindObjectInEquipment(player *Player, keyword string) (*Object, bool) {
-	w.mu.RLock()
-	defer w.mu.RUnlock()
-
-	if player == nil || player.Equipment == nil {
-		return nil, false
+// GiveObject hands obj from the giver's inventory to a player or mobile in
+// the same room. A mobile's give scripts run once it has the item.
+func (w *World) GiveObject(giver *Player, obj *Object, to Subject) error {
+	if IsAsleep(giver) {
+		return ErrAsleep
 	}
 
-	key := strings.ToLower(strings.TrimSpace(keyword))
-	if key == "" {
-		return nil, false
+	w.mu.Lock()
+	room, ok := w.rooms[giver.Location]
+	present := ok && (to.Player != nil && to.Player.Location == giver.Location ||
+		to.Mobile != nil && roomHasMobile(room, to.Mobile))
+	if !present {
+		w.mu.Unlock()
+		return fmt.Errorf("They aren't here.")
 	}
 
-	for _, obj := range player.Equipment {
-		if obj == nil {
-			continue
-		}
-		for _, objKeyword := range obj.Keywords {
-			if strings.ToLower(objKeyword) == key {
-				return obj, true
-			}
-		}
-		if strings.Contains(strings.ToLower(obj.Short), key) {
-			return obj, true
+	index := -1
+	for i, item := range giver.Inventory {
+		if item == obj {
+			index = i
+			break
 		}
 	}
+	if index < 0 {
+		w.mu.Unlock()
+		return fmt.Errorf("You aren't carrying that.")
+	}
+	giver.Inventory = append(giver.Inventory[:index], giver.Inventory[index+1:]...)
+	if to.Player != nil {
+		to.Player.Inventory = append(to.Player.Inventory, obj)
+	} else {
+		to.Mobile.Inventory = append(to.Mobile.Inventory, obj)
+	}
+	w.mu.Unlock()
 
-	return nil, false
+	self := PlayerSubject(giver)
+	vars := ActVars{"object": obj.Short}
+	w.Act("You give $object to $N.", self, to, vars, ToActor)
+	w.Act("$n gives you $object.", self, to, vars, ToTarget)
+	w.Act("$n gives $object to $N.", self, to, vars, ToNotTarget)
+
+	if to.Mobile != nil {
+		w.runGiveTriggers(giver, to.Mobile, obj)
+	}
+	return nil
+}
+
+// FindObjectInEquipment searches for an object in the player's equipment by keyword
+func (w *World) FindObjectInEquipment(player *Player, keyword string) (*Object, bool) {
+	if ParseTarget(keyword).All {
+		return nil, false
+	}
+
+	matches := w.ResolveEquipment(player, keyword)
+	if len(matches) == 0 {
+		return nil, false
+	}
+	return matches[0], true
 }
 
 // EquipmentSnapshot returns a copy of the player's equipment map.
@@ -903,128 +1025,221 @@ func (w *World) UnequipObject(player *Player, slot string) (*Object, bool) {
 	return obj, true
 }
 
-// DamageMob deals damage to a mobile and handles death.
-// Returns whether the mob died and any loot labels that were granted.
+// DamageMob deals damage to a mobile and handles death. If the attacker
+// leads a group, the members with them join in before the mobile strikes
+// back. Returns whether the mob died and any loot labels that were granted.
 func (w *World) DamageMob(player *Player, mob *Mobile, damage int) (died bool, loot []string) {
+	if died, loot := w.strikeMob(player, mob, damage); died {
+		return true, loot
+	}
+	if died, loot := w.groupAssist(player, mob); died {
+		return true, loot
+	}
+
+	w.mu.RLock()
+	flee := shouldFlee(mob)
+	from := player.Location
+	w.mu.RUnlock()
+
+	if !flee || !w.mobFlee(mob, from) {
+		w.mobCounterAttack(player, mob)
+	}
+	w.mobsAssist(player, mob)
+	return false, nil
+}
+
+// strikeMob deals damage to a mobile on the player's behalf and handles its
+// death; unlike DamageMob, the mobile doesn't strike back. Returns whether
+// the mob died and the labels of what its corpse holds.
+func (w *World) strikeMob(player *Player, mob *Mobile, damage int) (died bool, loot []string) {
 	w.mu.Lock()
 	mob.HP -= damage
+	mob.LastCombat = time.Now()
+	if player != nil {
+		player.LastCombat = mob.LastCombat
+	}
 
-	if mob.HP <= 0 {
-		mob.HP = 0
-		// Remove mob from room
-		room, ok := w.rooms[player.Location]
-		if ok {
-			newMobiles := make([]*Mobile, 0, len(room.Mobiles)-1)
-			for _, m := range room.Mobiles {
-				if m != mob {
-					newMobiles = append(newMobiles, m)
-				}
+	if mob.HP > 0 {
+		w.mu.Unlock()
+		return false, nil
+	}
+
+	mob.HP = 0
+	// Remove mob from room; an area spell may have caught it next door, out
+	// of reach of the spoils
+	room, ok := w.rooms[player.Location]
+	remote := ok && !roomHasMobile(room, mob)
+	if remote {
+		room, ok = w.rooms[w.subjectLocation(MobileSubject(mob))]
+	}
+	if ok {
+		newMobiles := make([]*Mobile, 0, len(room.Mobiles)-1)
+		for _, m := range room.Mobiles {
+			if m != mob {
+				newMobiles = append(newMobiles, m)
 			}
-			room.Mobiles = newMobiles
 		}
+		room.Mobiles = newMobiles
+	}
 
-		lootLabels := make([]string, 0)
-		if player != nil && len(mob.Loot) > 0 {
-			for _, entry := range mob.Loot {
-				if entry.Vnum <= 0 || entry.Count <= 0 {
-					continue
-				}
-				proto, ok := w.objects[entry.Vnum]
-				if !ok || proto == nil {
-					continue
-				}
-				for i := 0; i < entry.Count; i++ {
-					objCopy := *proto
-					player.Inventory = append(player.Inventory, &objCopy)
-					label := objCopy.Short
-					if label == "" {
-						label = "something"
-					}
-					lootLabels = append(lootLabels, label)
-				}
-			}
+	contents := append([]*Object{}, mob.Inventory...)
+	mob.Inventory = nil
+	var notices []notice
+	if ok && !remote {
+		contents = append(contents, w.rollLoot(mob)...)
+		gold := rollGold(mob)
+		w.mintGold(LedgerMobDrops, gold)
+		contents, notices = w.shareLoot(player, contents, gold)
+	}
+
+	lootLabels := make([]string, 0, len(contents))
+	for _, obj := range contents {
+		label := obj.Short
+		if label == "" {
+			label = "something"
 		}
+		lootLabels = append(lootLabels, label)
+	}
 
-		w.mu.Unlock()
-		return true, lootLabels
+	// The mobile leaves a corpse holding its loot
+	if ok {
+		name := mob.Short
+		if strings.TrimSpace(name) == "" {
+			name = "a creature"
+		}
+		room.Objects = append(room.Objects, newCorpse(name, mob.Keywords, "", contents, w.death.MobCorpseDecay))
+	}
+
+	// Everyone in the group who was there shares the credit
+	if !remote {
+		for _, member := range w.groupHere(player) {
+			for _, line := range questKill(member, mob) {
+				notices = append(notices, notice{member, line})
+			}
+		}
 	}
 
+	vnum := player.Location
+	if ok {
+		vnum = room.Vnum
+	}
 	w.mu.Unlock()
-	w.mobCounterAttack(player, mob)
-	return false, nil
+	sendNotices(notices)
+	w.runMobileTriggers(mob, script.TriggerDeath, vnum, player)
+	if vnum == player.Location {
+		w.mobsAssist(player, mob)
+	}
+	return true, lootLabels
+}
+
+// rollLoot creates the objects mob's loot table drops. Callers hold w.mu.
+func (w *World) rollLoot(mob *Mobile) []*Object {
+	var loot []*Object
+	for _, entry := range mob.Loot {
+		if entry.Vnum <= 0 || entry.Count <= 0 {
+			continue
+		}
+		proto, ok := w.objects[entry.Vnum]
+		if !ok || proto == nil {
+			continue
+		}
+		for i := 0; i < entry.Count; i++ {
+			objCopy := *proto
+			loot = append(loot, &objCopy)
+		}
+	}
+	return loot
 }
 
+// mobCounterAttack has the mobile take its turn against target: a spell or
+// maneuver if its combat AI picks one, otherwise a plain melee strike.
 func (w *World) mobCounterAttack(target *Player, mob *Mobile) {
 	if target == nil || mob == nil {
 		return
 	}
 
-	// Simple melee damage: small roll + level and strength bonus
-	roll := rand.Intn(4) + 1
-	levelBonus := mob.Level / 2
-	strBonus := mob.Attributes[0] / 4
-	damage := roll + levelBonus + strBonus
-	if damage < 1 {
-		damage = 1
-	}
-
-	var died bool
-	var mobName string
-
 	w.mu.Lock()
 	room, ok := w.rooms[target.Location]
-	if !ok {
+	if !ok || !roomHasMobile(room, mob) {
 		w.mu.Unlock()
 		return
 	}
 
-	mobStillHere := false
-	for _, m := range room.Mobiles {
-		if m == mob {
-			mobStillHere = true
-			break
-		}
-	}
-	if !mobStillHere {
+	now := time.Now()
+	mob.LastCombat = now
+	ability, chosen := chooseMobAbility(mob, now)
+	if chosen && isHealing(ability.spell) {
 		w.mu.Unlock()
+		w.mobHeal(mob, ability)
 		return
 	}
 
-	target.HP -= damage
-	if target.HP <= 0 {
-		target.HP = 1
-		died = true
+	var damage int
+	var result HitResult
+	if chosen {
+		result = ResolveHit(ability.spell, PlayerSubject(target), abilityAmount(ability.spell.Effects.Damage, mob, ability.proficiency))
+		damage = result.Damage
+		spendAbility(mob, ability.spell, now)
+	} else {
+		// Simple melee damage: small roll + level and strength bonus
+		damage = rand.Intn(4) + 1 + mob.Level/2 + mob.Attributes[mobAttributeStr]/4
+		if damage < 1 {
+			damage = 1
+		}
 	}
 
-	mobName = mob.Short
+	target.HP -= damage
+	target.LastCombat = now
+	if IsAsleep(target) {
+		target.Position = PositionStanding
+	}
+	died := target.HP <= 0
 	w.mu.Unlock()
 
-	if strings.TrimSpace(mobName) == "" {
-		mobName = "The creature"
+	attacker := MobileSubject(mob)
+	victim := PlayerSubject(target)
+	if chosen {
+		vars := mobVars(ability.spell, damage)
+		if result.Negated {
+			w.Act(missMessage(ability.spell), attacker, victim, vars, ToTarget)
+		} else {
+			w.Act(hitMessage(ability.spell), attacker, victim, vars, ToTarget)
+		}
+		w.Act(ability.spell.Messages.CastRoom, attacker, victim, vars, ToNotTarget)
+		if result.Saved {
+			w.Act(ability.spell.Messages.Save, attacker, victim, vars, ToTarget)
+			w.Act(ability.spell.Messages.Save, attacker, victim, vars, ToNotTarget)
+		}
+	} else {
+		vars := ActVars{"damage": strconv.Itoa(damage)}
+		w.Act("&R$n strikes you for $damage damage!&w", attacker, victim, vars, ToTarget)
+		w.Act("&R$n strikes $N!&w", attacker, victim, nil, ToNotTarget)
 	}
-
-	target.Output.WriteLine(fmt.Sprintf("&R%s strikes you for %d damage!&w", mobName, damage))
 	if died {
-		target.Output.WriteLine("&RYou are defeated and left barely standing!&w")
+		w.killPlayer(target)
+		return
 	}
+	w.runMobileTriggers(mob, script.TriggerFight, room.Vnum, target)
+}
 
-	roomMsg := fmt.Sprintf("&R%s strikes %s!&w", mobName, CapitalizeName(target.Name))
-	w.BroadcastCombatMessage(target, roomMsg)
-	if died {
-		w.BroadcastCombatMessage(target, fmt.Sprintf("&R%s is defeated by %s!&w", CapitalizeName(target.Name), mobName))
-	}
+// mobHeal has the mobile cast a healing spell on itself.
+func (w *World) mobHeal(mob *Mobile, ability mobAbility) {
+	w.mu.Lock()
+	amount := abilityAmount(ability.spell.Effects.Healing, mob, ability.proficiency)
+	spendAbility(mob, ability.spell, time.Now())
+	restore(&mob.HP, mob.MaxHP, amount)
+	w.mu.Unlock()
+
+	self := MobileSubject(mob)
+	vars := mobVars(ability.spell, amount)
+	w.Act(ability.spell.Messages.CastRoom, self, self, vars, ToRoom)
+	w.Act(ability.spell.Messages.Hit, self, self, vars, ToRoom)
 }
 
-// BroadcastCombatMessage sends a combat message to the player's room
+// BroadcastCombatMessage sends an act() template to the player's room, with
+// the player as its actor ($n).
 func (w *World) BroadcastCombatMessage(player *Player, message string) {
-	w.mu.RLock()
-	defer w.mu.RUnlock()
-
-	for _, other := range w.players {
-		if other.Location == player.Location && !strings.EqualFold(other.Name, player.Name) {
-			other.Output.WriteLine(message)
-		}
-	}
+	w.Act(message, PlayerSubject(player), Subject{}, nil, ToRoom)
 }
 
 // SpawnMob creates a mob instance from a prototype and adds it to the player's current room
@@ -1043,6 +1258,9 @@ func (w *World) SpawnMob(player *Player, vnum int) (*Mobile, error) {
 	if !ok {
 		return nil, fmt.Errorf("player is not in a valid room")
 	}
+	if room.Flags[RoomFlagNoMob] {
+		return nil, ErrNoMob
+	}
 
 	// Create a copy of the prototype
 	mobCopy := *proto
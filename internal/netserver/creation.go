@@ -264,6 +264,8 @@ func (cc *CharacterCreation) applyKitModifiers() {
 	cc.player.MaxHP = cc.selectedKit.HP
 	cc.player.Mana = cc.selectedKit.Mana
 	cc.player.MaxMana = cc.selectedKit.Mana
+	cc.player.MaxMove = game.DefaultMaxMove
+	cc.player.Move = cc.player.MaxMove
 
 	// Grant starting skills/spells
 	if cc.player.Skills == nil {
@@ -512,6 +514,7 @@ func (cc *CharacterCreation) displayFinalStats() {
 	cc.session.WriteLine("")
 	cc.session.WriteLine(fmt.Sprintf("HP:   %d", cc.player.MaxHP))
 	cc.session.WriteLine(fmt.Sprintf("Mana: %d", cc.player.MaxMana))
+	cc.session.WriteLine(fmt.Sprintf("Move: %d", cc.player.MaxMove))
 	cc.session.WriteLine("")
 	cc.session.WriteLine("Welcome to Njata, adventurer.")
 	cc.session.WriteLine("")
@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"net"
 	"strings"
+	"sync"
 	"time"
 
+	"njata/internal/bans"
 	"njata/internal/commands"
+	"njata/internal/config"
 	"njata/internal/game"
 	"njata/internal/parser"
 	"njata/internal/persist"
@@ -16,19 +19,59 @@ import (
 
 const playerDataDir = "players"
 
+// worldStatePath holds the game calendar and weather between reboots.
+const worldStatePath = "system/time.json"
+
 type Server struct {
 	world    *game.World
 	registry *commands.Registry
 	port     int
 	logger   func(string)
+	linkDead game.LinkDeadPolicy
+
+	// Connection security
+	maxPerIP          int
+	maxLineLength     int
+	commandsPerSecond int
+	commandBurst      int
+	spamRepeatLimit   int
+	separator         string
+	connMu            sync.Mutex
+	connsByIP         map[string]int
+
+	// Optional TLS listener
+	tlsPort  int
+	certFile string
+	keyFile  string
 }
 
-func NewServer(world *game.World, registry *commands.Registry, port int, logger func(string)) *Server {
+func NewServer(world *game.World, registry *commands.Registry, cfg config.Config, port int, logger func(string)) *Server {
+	limbo := cfg.LimboRoomVnum
+	if limbo == 0 {
+		limbo = game.DefaultLimboVnum
+	}
+
 	return &Server{
 		world:    world,
 		registry: registry,
 		port:     port,
 		logger:   logger,
+		linkDead: game.LinkDeadPolicy{
+			Grace:      time.Duration(cfg.LinkDeadGraceMinutes) * time.Minute,
+			IdleLimbo:  time.Duration(cfg.IdleLimboMinutes) * time.Minute,
+			IdleLogout: time.Duration(cfg.IdleLogoutMinutes) * time.Minute,
+			LimboVnum:  limbo,
+		},
+		maxPerIP:          cfg.MaxConnectionsPerIP,
+		maxLineLength:     cfg.MaxLineLength,
+		commandsPerSecond: cfg.CommandsPerSecond,
+		commandBurst:      cfg.CommandBurst,
+		spamRepeatLimit:   cfg.SpamRepeatLimit,
+		separator:         cfg.CommandSeparator,
+		connsByIP:         map[string]int{},
+		tlsPort:           cfg.TLSPort,
+		certFile:          cfg.TLSCertFile,
+		keyFile:           cfg.TLSKeyFile,
 	}
 }
 
@@ -44,14 +87,54 @@ func (s *Server) Run(ctx context.Context) error {
 		s.logger(fmt.Sprintf("Listening on %s", address))
 	}
 
+	var tlsListener net.Listener
+	if s.tlsPort != 0 {
+		tlsListener, err = s.listenTLS()
+		if err != nil {
+			return err
+		}
+		defer tlsListener.Close()
+	}
+
 	go func() {
 		<-ctx.Done()
 		_ = listener.Close()
+		if tlsListener != nil {
+			_ = tlsListener.Close()
+		}
 	}()
 
 	// Start autosave ticker - saves all players every 5 minutes
 	go s.startAutosaveTimer(ctx)
 
+	// Start link-dead/idle ticker
+	if s.linkDead.IdleLimbo > 0 {
+		s.world.EnsureLimbo(s.linkDead.LimboVnum)
+	}
+	go s.startLinkDeadTimer(ctx)
+
+	// Restore the calendar and weather, then start the world update ticker
+	// (movement regeneration, drowning, game time and weather)
+	s.loadWorldState()
+	go s.startUpdateTimer(ctx)
+
+	if tlsListener == nil {
+		return s.acceptLoop(ctx, listener)
+	}
+
+	errs := make(chan error, 2)
+	go func() { errs <- s.acceptLoop(ctx, listener) }()
+	go func() { errs <- s.acceptLoop(ctx, tlsListener) }()
+
+	// Either listener failing brings the server down
+	err = <-errs
+	_ = listener.Close()
+	_ = tlsListener.Close()
+	<-errs
+	return err
+}
+
+func (s *Server) acceptLoop(ctx context.Context, listener net.Listener) error {
 	for {
 		conn, err := listener.Accept()
 		if err != nil {
@@ -81,6 +164,8 @@ func (s *Server) startAutosaveTimer(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
+			s.saveWorldState()
+
 			// Save all players
 			players := s.world.PlayersSnapshot()
 			for _, player := range players {
@@ -97,10 +182,141 @@ func (s *Server) startAutosaveTimer(ctx context.Context) {
 	}
 }
 
+func (s *Server) startLinkDeadTimer(ctx context.Context) {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.world.LinkDeadTick(s.linkDead, s.savePlayer, s.logger)
+		}
+	}
+}
+
+func (s *Server) startUpdateTimer(ctx context.Context) {
+	ticker := time.NewTicker(15 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.saveWorldState()
+			return
+		case <-ticker.C:
+			s.world.UpdateTick()
+		}
+	}
+}
+
+func (s *Server) loadWorldState() {
+	record, ok, err := persist.LoadWorldState(worldStatePath)
+	if err != nil {
+		if s.logger != nil {
+			s.logger(fmt.Sprintf("world state load error: %v", err))
+		}
+		return
+	}
+	if ok {
+		persist.ApplyWorldState(s.world, record)
+	}
+}
+
+func (s *Server) saveWorldState() {
+	record := persist.WorldStateToRecord(s.world)
+	if err := persist.SaveWorldState(worldStatePath, record); err != nil && s.logger != nil {
+		s.logger(fmt.Sprintf("world state save error: %v", err))
+	}
+}
+
+func (s *Server) savePlayer(player *game.Player) {
+	record := persist.PlayerToRecord(player)
+	if err := persist.SavePlayer(playerDataDir, record); err != nil && s.logger != nil {
+		s.logger(fmt.Sprintf("save error for %s: %v", player.Name, err))
+	}
+}
+
+// releasePlayer runs when a session ends. Quitting removes the character; a
+// dropped connection leaves it link-dead for the configured grace period.
+func (s *Server) releasePlayer(session *Session, player *game.Player) {
+	if player == nil {
+		return
+	}
+
+	if session.DisconnectReason() == "quit" || s.linkDead.Grace <= 0 {
+		if !s.world.OwnsPlayer(player, session) {
+			return
+		}
+		s.savePlayer(player)
+		s.world.RemovePlayer(player.Name)
+		s.world.BroadcastSystemToRoomExcept(player, "$n has left the game.")
+		return
+	}
+
+	if !s.world.DetachPlayer(player, session) {
+		return
+	}
+
+	s.savePlayer(player)
+	s.world.BroadcastSystemToRoomExcept(player, "$n has lost $s link.")
+	if s.logger != nil {
+		s.logger(fmt.Sprintf("%s has gone link-dead", player.Name))
+	}
+}
+
+// admit checks the ban list and per-IP limit for a new connection.
+// On success the connection is counted against its IP until release is called.
+func (s *Server) admit(session *Session) bool {
+	host := session.RemoteHost()
+
+	if ban, banned := bans.Check(host, false); banned {
+		session.WriteLine("Your site has been banned from this game.")
+		if s.logger != nil {
+			s.logger(fmt.Sprintf("Denied connection from %s: banned site %s", host, ban.Site))
+		}
+		return false
+	}
+
+	s.connMu.Lock()
+	defer s.connMu.Unlock()
+
+	if s.maxPerIP > 0 && s.connsByIP[host] >= s.maxPerIP {
+		session.WriteLine("Too many connections from your site. Please try again later.")
+		if s.logger != nil {
+			s.logger(fmt.Sprintf("Denied connection from %s: %d connections already open", host, s.connsByIP[host]))
+		}
+		return false
+	}
+
+	s.connsByIP[host]++
+	return true
+}
+
+func (s *Server) release(session *Session) {
+	host := session.RemoteHost()
+
+	s.connMu.Lock()
+	defer s.connMu.Unlock()
+
+	s.connsByIP[host]--
+	if s.connsByIP[host] <= 0 {
+		delete(s.connsByIP, host)
+	}
+}
+
 func (s *Server) handleConn(conn net.Conn) {
 	session := NewSession(conn)
 	defer session.Close()
 
+	if !s.admit(session) {
+		return
+	}
+	defer s.release(session)
+
+	session.SetMaxLineLength(s.maxLineLength)
+
 	WriteBanner(session)
 	session.WriteLine("")
 	session.WriteLine("")
@@ -121,6 +337,24 @@ func (s *Server) handleConn(conn net.Conn) {
 			continue
 		}
 
+		// Take over a character that is still in the world (link-dead or lingering)
+		if existing, ok := s.world.Reconnect(name, session, session.RequestDisconnect); ok {
+			player = existing
+			defer func() { s.releasePlayer(session, player) }()
+
+			session.WriteLine("Reconnecting.")
+			s.world.BroadcastSystemToRoomExcept(player, "$n has reconnected.")
+			if s.logger != nil {
+				s.logger(fmt.Sprintf("%s has reconnected", player.Name))
+			}
+
+			view, err := s.world.DescribeRoom(player)
+			if err == nil {
+				commands.DisplayRoomView(session, view, player.AutoExits)
+			}
+			break
+		}
+
 		// Try to load existing player
 		record, exists, err := persist.LoadPlayer(playerDataDir, name)
 		if err != nil && exists {
@@ -130,6 +364,16 @@ func (s *Server) handleConn(conn net.Conn) {
 
 		isNewPlayer = !exists
 
+		if isNewPlayer {
+			if ban, banned := bans.Check(session.RemoteHost(), true); banned {
+				session.WriteLine("New characters may not be created from your site.")
+				if s.logger != nil {
+					s.logger(fmt.Sprintf("Denied new character %s from %s: newbie ban %s", name, session.RemoteHost(), ban.Site))
+				}
+				return
+			}
+		}
+
 		// Create new player struct
 		player = &game.Player{
 			Name:       name,
@@ -139,6 +383,7 @@ func (s *Server) handleConn(conn net.Conn) {
 			Skills:     make(map[int]*skills.PlayerSkillProgress),
 			Inventory:  []*game.Object{},
 			Equipment:  make(map[string]*game.Object),
+			LastInput:  time.Now(),
 		}
 
 		// If existing player, load their stats
@@ -162,18 +407,9 @@ func (s *Server) handleConn(conn net.Conn) {
 			continue
 		}
 
-		defer func() {
-			if player != nil {
-				record := persist.PlayerToRecord(player)
-				if err := persist.SavePlayer(playerDataDir, record); err != nil && s.logger != nil {
-					s.logger(fmt.Sprintf("save error for %s: %v", player.Name, err))
-				}
-				s.world.RemovePlayer(player.Name)
-				s.world.BroadcastSystemToRoomExcept(player, fmt.Sprintf("%s has left the game.", game.CapitalizeName(player.Name)))
-			}
-		}()
+		defer func() { s.releasePlayer(session, player) }()
 
-		s.world.BroadcastSystemToRoomExcept(player, fmt.Sprintf("%s has entered the game.", game.CapitalizeName(player.Name)))
+		s.world.BroadcastSystemToRoomExcept(player, "$n has entered the game.")
 		if isNewPlayer {
 			session.WriteLine(fmt.Sprintf("Welcome to the world, %s!", game.CapitalizeName(player.Name)))
 		} else {
@@ -187,6 +423,8 @@ func (s *Server) handleConn(conn net.Conn) {
 		break
 	}
 
+	throttle := newCommandThrottle(s.commandsPerSecond, s.commandBurst)
+
 	for {
 		if session.IsDisconnectRequested() {
 			return
@@ -198,20 +436,70 @@ func (s *Server) handleConn(conn net.Conn) {
 			return
 		}
 
-		command, args := parser.ParseInput(line)
-		if command == "" {
+		if s.world.TouchPlayer(player) {
+			session.WriteLine("You return from the void.")
+			if view, err := s.world.DescribeRoom(player); err == nil {
+				commands.DisplayRoomView(session, view, player.AutoExits)
+			}
+		}
+
+		lines, err := s.registry.Expand(player, line, s.separator)
+		if err != nil {
+			session.WriteLine(err.Error())
 			continue
 		}
 
-		ctx := commands.Context{
-			World:      s.world,
-			Player:     player,
-			Output:     session,
-			Disconnect: session.RequestDisconnect,
+		// Each command a line expands into is throttled on its own, so
+		// separators and aliases can't slip extra commands past the limits.
+		// Movement rides on the line's token: speedwalks are paced by
+		// movement points instead.
+		now := time.Now()
+		if len(lines) > 0 && !throttle.Allow(now) {
+			session.WriteLine("You are sending commands too quickly. Slow down.")
+			continue
 		}
 
-		if !s.registry.Execute(ctx, command, args) {
-			session.WriteLine("Huh? Type 'help' for commands.")
+		for i, input := range lines {
+			if session.IsDisconnectRequested() {
+				return
+			}
+
+			command, args := parser.ParseInput(input)
+			if command == "" {
+				continue
+			}
+
+			if s.spamRepeatLimit > 0 && countsAsRepeat(input) {
+				repeats := throttle.Repeat(input)
+				if repeats >= s.spamRepeatLimit*2 {
+					session.WriteLine("*** PUT A LID ON IT!!! ***")
+					if s.logger != nil {
+						s.logger(fmt.Sprintf("%s (%s) disconnected for spamming", player.Name, session.RemoteHost()))
+					}
+					session.RequestDisconnect("spam")
+					return
+				}
+				if repeats >= s.spamRepeatLimit {
+					session.WriteLine("You keep repeating yourself. Please stop spamming.")
+					break
+				}
+			}
+
+			if i > 0 && !commands.IsMovement(command) && !throttle.Allow(now) {
+				session.WriteLine("You are sending commands too quickly. Slow down.")
+				break
+			}
+
+			ctx := commands.Context{
+				World:      s.world,
+				Player:     player,
+				Output:     session,
+				Disconnect: session.RequestDisconnect,
+			}
+
+			if !s.registry.Execute(ctx, command, args) {
+				session.WriteLine("Huh? Type 'help' for commands.")
+			}
 		}
 	}
 }
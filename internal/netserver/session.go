@@ -16,8 +16,10 @@ type Session struct {
     writeMu        sync.Mutex
     stateMu        sync.Mutex
     closed         bool
+    reason         string
     disconnectOnce sync.Once
     disconnected   chan struct{}
+    maxLine        int
 }
 
 func NewSession(conn net.Conn) *Session {
@@ -29,6 +31,21 @@ func NewSession(conn net.Conn) *Session {
     }
 }
 
+// SetMaxLineLength caps how many bytes ReadLine keeps per line. Zero means unlimited.
+func (s *Session) SetMaxLineLength(limit int) {
+    s.maxLine = limit
+}
+
+// RemoteHost returns the peer address without the port.
+func (s *Session) RemoteHost() string {
+    addr := s.conn.RemoteAddr().String()
+    host, _, err := net.SplitHostPort(addr)
+    if err != nil {
+        return addr
+    }
+    return host
+}
+
 func (s *Session) Write(message string) {
     if s.IsDisconnectRequested() {
         return
@@ -51,12 +68,44 @@ func (s *Session) WriteLine(text string) {
 }
 
 func (s *Session) ReadLine() (string, error) {
-    line, err := s.reader.ReadString('\n')
-    if err != nil && len(line) == 0 {
-        return "", err
+    if s.maxLine <= 0 {
+        line, err := s.reader.ReadString('\n')
+        if err != nil && len(line) == 0 {
+            return "", err
+        }
+
+        line = strings.TrimRight(line, "\r\n")
+        return line, err
     }
 
-    line = strings.TrimRight(line, "\r\n")
+    // Read byte by byte so an endless line can't grow the buffer without bound
+    buf := make([]byte, 0, 128)
+    truncated := false
+    var err error
+    for {
+        var b byte
+        b, err = s.reader.ReadByte()
+        if err != nil {
+            if len(buf) == 0 {
+                return "", err
+            }
+            break
+        }
+        if b == '\n' {
+            break
+        }
+        if len(buf) < s.maxLine {
+            buf = append(buf, b)
+        } else {
+            truncated = true
+        }
+    }
+
+    if truncated {
+        s.WriteLine("Line too long, truncated.")
+    }
+
+    line := strings.TrimRight(string(buf), "\r\n")
     return line, err
 }
 
@@ -64,6 +113,7 @@ func (s *Session) RequestDisconnect(reason string) {
     s.disconnectOnce.Do(func() {
         s.stateMu.Lock()
         s.closed = true
+        s.reason = reason
         s.stateMu.Unlock()
 
         close(s.disconnected)
@@ -80,6 +130,13 @@ func (s *Session) IsDisconnectRequested() bool {
     }
 }
 
+// DisconnectReason returns the reason given when the disconnect was requested.
+func (s *Session) DisconnectReason() string {
+    s.stateMu.Lock()
+    defer s.stateMu.Unlock()
+    return s.reason
+}
+
 func (s *Session) Close() {
     s.RequestDisconnect("closed")
 }
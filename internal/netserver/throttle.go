@@ -0,0 +1,73 @@
+package netserver
+
+import (
+	"strings"
+	"time"
+
+	"njata/internal/commands"
+)
+
+// minRepeatLength is the shortest command that counts towards the repeat
+// limit; terse commands like "l" or "sc" are routinely sent over and over.
+const minRepeatLength = 4
+
+// commandThrottle is a per-session token bucket with a repeated-input counter.
+type commandThrottle struct {
+	rate     float64 // tokens added per second (0 = unlimited)
+	burst    float64 // bucket capacity
+	tokens   float64
+	last     time.Time
+	lastLine string
+	repeats  int
+}
+
+func newCommandThrottle(perSecond int, burst int) *commandThrottle {
+	if burst < perSecond {
+		burst = perSecond
+	}
+	return &commandThrottle{
+		rate:   float64(perSecond),
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Allow consumes a token and reports whether a command may run now.
+func (t *commandThrottle) Allow(now time.Time) bool {
+	if t.rate <= 0 {
+		return true
+	}
+
+	elapsed := now.Sub(t.last).Seconds()
+	t.last = now
+	t.tokens += elapsed * t.rate
+	if t.tokens > t.burst {
+		t.tokens = t.burst
+	}
+
+	if t.tokens < 1 {
+		return false
+	}
+
+	t.tokens--
+	return true
+}
+
+// Repeat records a line of input and returns how many times in a row it has been sent.
+func (t *commandThrottle) Repeat(line string) int {
+	if line == t.lastLine {
+		t.repeats++
+	} else {
+		t.lastLine = line
+		t.repeats = 1
+	}
+	return t.repeats
+}
+
+// countsAsRepeat reports whether a command should count towards the repeat
+// limit. Movement and short commands never do.
+func countsAsRepeat(input string) bool {
+	command := strings.Fields(input)[0]
+	return len(input) >= minRepeatLength && !commands.IsMovement(command)
+}
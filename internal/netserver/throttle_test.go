@@ -0,0 +1,50 @@
+package netserver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCommandThrottleBurstAndRefill(t *testing.T) {
+	throttle := newCommandThrottle(2, 3)
+	now := time.Now()
+	throttle.last = now
+
+	for i := 0; i < 3; i++ {
+		if !throttle.Allow(now) {
+			t.Fatalf("expected command %d within burst to be allowed", i+1)
+		}
+	}
+	if throttle.Allow(now) {
+		t.Fatalf("expected command beyond burst to be throttled")
+	}
+
+	if !throttle.Allow(now.Add(time.Second)) {
+		t.Fatalf("expected tokens to refill after a second")
+	}
+}
+
+func TestCommandThrottleRepeat(t *testing.T) {
+	throttle := newCommandThrottle(0, 0)
+
+	if n := throttle.Repeat("chat hi"); n != 1 {
+		t.Fatalf("expected first repeat count 1, got %d", n)
+	}
+	if n := throttle.Repeat("chat hi"); n != 2 {
+		t.Fatalf("expected second repeat count 2, got %d", n)
+	}
+	if n := throttle.Repeat("look"); n != 1 {
+		t.Fatalf("expected counter reset on new input, got %d", n)
+	}
+}
+
+func TestCountsAsRepeat(t *testing.T) {
+	for _, input := range []string{"n", "north", "sw", "sc"} {
+		if countsAsRepeat(input) {
+			t.Fatalf("expected %q to be exempt from the repeat limit", input)
+		}
+	}
+	if !countsAsRepeat("chat buy my sword") {
+		t.Fatalf("expected chat to count towards the repeat limit")
+	}
+}
@@ -0,0 +1,133 @@
+package netserver
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// certReloadInterval is how often the certificate files are checked for changes.
+const certReloadInterval = 30 * time.Second
+
+// certReloader serves a certificate pair from disk and reloads it when the
+// files change, so renewed certificates are picked up without a restart.
+type certReloader struct {
+	certFile string
+	keyFile  string
+	logger   func(string)
+
+	mu          sync.Mutex
+	cert        *tls.Certificate
+	certModTime time.Time
+	keyModTime  time.Time
+	lastCheck   time.Time
+}
+
+func newCertReloader(certFile string, keyFile string, logger func(string)) (*certReloader, error) {
+	reloader := &certReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		logger:   logger,
+	}
+
+	if err := reloader.reload(); err != nil {
+		return nil, err
+	}
+
+	return reloader, nil
+}
+
+// reload loads the certificate pair unconditionally. Callers must not hold mu.
+func (r *certReloader) reload() error {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return fmt.Errorf("tls cert: %w", err)
+	}
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return fmt.Errorf("tls key: %w", err)
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("tls keypair: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.certModTime = certInfo.ModTime()
+	r.keyModTime = keyInfo.ModTime()
+	r.lastCheck = time.Now()
+	return nil
+}
+
+// changed reports whether either file has a newer modification time than the loaded pair.
+func (r *certReloader) changed() bool {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return false
+	}
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return !certInfo.ModTime().Equal(r.certModTime) || !keyInfo.ModTime().Equal(r.keyModTime)
+}
+
+// GetCertificate implements tls.Config.GetCertificate.
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	due := time.Since(r.lastCheck) >= certReloadInterval
+	if due {
+		r.lastCheck = time.Now()
+	}
+	r.mu.Unlock()
+
+	if due && r.changed() {
+		if err := r.reload(); err != nil {
+			// Keep serving the previous certificate until the new pair is valid
+			if r.logger != nil {
+				r.logger(fmt.Sprintf("TLS certificate reload failed: %v", err))
+			}
+		} else if r.logger != nil {
+			r.logger("TLS certificate reloaded")
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cert, nil
+}
+
+func (s *Server) listenTLS() (net.Listener, error) {
+	if s.certFile == "" || s.keyFile == "" {
+		return nil, fmt.Errorf("tls_port is set but tls_cert_file or tls_key_file is missing")
+	}
+
+	reloader, err := newCertReloader(s.certFile, s.keyFile, s.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	address := fmt.Sprintf(":%d", s.tlsPort)
+	listener, err := tls.Listen("tcp", address, &tls.Config{
+		GetCertificate: reloader.GetCertificate,
+		MinVersion:     tls.VersionTLS12,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if s.logger != nil {
+		s.logger(fmt.Sprintf("Listening for TLS on %s", address))
+	}
+
+	return listener, nil
+}
@@ -0,0 +1,130 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+)
+
+// DefaultSeparator splits several commands typed on one line.
+const DefaultSeparator = ";"
+
+// SplitCommands splits a line on sep, dropping empty pieces.
+func SplitCommands(line string, sep string) []string {
+	if sep == "" {
+		sep = DefaultSeparator
+	}
+
+	parts := strings.Split(line, sep)
+	commands := make([]string, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part != "" {
+			commands = append(commands, part)
+		}
+	}
+	return commands
+}
+
+var speedwalkDirections = map[byte]string{
+	'n': "north",
+	's': "south",
+	'e': "east",
+	'w': "west",
+	'u': "up",
+	'd': "down",
+}
+
+// SpeedwalkPrefix marks a speedwalk with no step counts, such as ".nne".
+const SpeedwalkPrefix = "."
+
+// ExpandSpeedwalk expands strings like "3n2e" or ".nne" into movement commands.
+// A speedwalk must contain a step count or start with SpeedwalkPrefix, so that
+// ordinary words made of direction letters ("end", "news") are left alone.
+// It returns false if word is not a valid speedwalk string or exceeds limit steps.
+func ExpandSpeedwalk(word string, limit int) ([]string, bool) {
+	word = strings.ToLower(strings.TrimSpace(word))
+	if rest, ok := strings.CutPrefix(word, SpeedwalkPrefix); ok {
+		word = rest
+	} else if !strings.ContainsAny(word, "0123456789") {
+		return nil, false
+	}
+	if word == "" {
+		return nil, false
+	}
+
+	steps := make([]string, 0, len(word))
+	count := 0
+	for i := 0; i < len(word); i++ {
+		c := word[i]
+		if c >= '0' && c <= '9' {
+			count = count*10 + int(c-'0')
+			if limit > 0 && count > limit {
+				return nil, false
+			}
+			continue
+		}
+
+		direction, ok := speedwalkDirections[c]
+		if !ok {
+			return nil, false
+		}
+		if count == 0 {
+			count = 1
+		}
+		for j := 0; j < count; j++ {
+			steps = append(steps, direction)
+		}
+		if limit > 0 && len(steps) > limit {
+			return nil, false
+		}
+		count = 0
+	}
+
+	// A trailing number with no direction is not a speedwalk
+	if count != 0 {
+		return nil, false
+	}
+
+	return steps, true
+}
+
+// ExpandAlias substitutes arguments into an alias template.
+// $* is replaced by all arguments and $1-$9 by individual words. If the
+// template references no arguments, they are appended to the end.
+func ExpandAlias(template string, args string) string {
+	args = strings.TrimSpace(args)
+	words := strings.Fields(args)
+
+	if !strings.Contains(template, "$") {
+		if args == "" {
+			return template
+		}
+		return template + " " + args
+	}
+
+	var builder strings.Builder
+	for i := 0; i < len(template); i++ {
+		c := template[i]
+		if c != '$' || i+1 >= len(template) {
+			builder.WriteByte(c)
+			continue
+		}
+
+		next := template[i+1]
+		switch {
+		case next == '*':
+			builder.WriteString(args)
+			i++
+		case next >= '1' && next <= '9':
+			index, _ := strconv.Atoi(string(next))
+			if index <= len(words) {
+				builder.WriteString(words[index-1])
+			}
+			i++
+		default:
+			builder.WriteByte(c)
+		}
+	}
+
+	return strings.TrimSpace(builder.String())
+}
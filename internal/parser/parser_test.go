@@ -1,6 +1,9 @@
 package parser
 
-import "testing"
+import (
+    "strings"
+    "testing"
+)
 
 func TestParseInput(t *testing.T) {
     command, args := ParseInput("  SAY   hello world  ")
@@ -18,3 +21,52 @@ func TestParseInputEmpty(t *testing.T) {
         t.Fatalf("expected empty command and args, got '%s' and '%s'", command, args)
     }
 }
+
+func TestSplitCommands(t *testing.T) {
+    got := SplitCommands("get all; wear all ;; look", ";")
+    want := []string{"get all", "wear all", "look"}
+    if len(got) != len(want) {
+        t.Fatalf("expected %v, got %v", want, got)
+    }
+    for i := range want {
+        if got[i] != want[i] {
+            t.Fatalf("expected %v, got %v", want, got)
+        }
+    }
+}
+
+func TestExpandSpeedwalk(t *testing.T) {
+    steps, ok := ExpandSpeedwalk("3n2eu", 20)
+    if !ok {
+        t.Fatalf("expected valid speedwalk")
+    }
+    want := []string{"north", "north", "north", "east", "east", "up"}
+    if strings.Join(steps, ",") != strings.Join(want, ",") {
+        t.Fatalf("expected %v, got %v", want, steps)
+    }
+
+    if _, ok := ExpandSpeedwalk("look", 20); ok {
+        t.Fatalf("expected 'look' not to be a speedwalk")
+    }
+    if _, ok := ExpandSpeedwalk("99n", 20); ok {
+        t.Fatalf("expected speedwalk over the limit to be rejected")
+    }
+    if _, ok := ExpandSpeedwalk("end", 20); ok {
+        t.Fatalf("expected 'end' not to be a speedwalk")
+    }
+    if steps, ok := ExpandSpeedwalk(".nne", 20); !ok || strings.Join(steps, ",") != "north,north,east" {
+        t.Fatalf("expected prefixed speedwalk to expand, got %v", steps)
+    }
+}
+
+func TestExpandAlias(t *testing.T) {
+    if got := ExpandAlias("kill $*", "big goblin"); got != "kill big goblin" {
+        t.Fatalf("unexpected $* expansion: %q", got)
+    }
+    if got := ExpandAlias("cast 'arcane bolt' $1", "goblin extra"); got != "cast 'arcane bolt' goblin" {
+        t.Fatalf("unexpected $1 expansion: %q", got)
+    }
+    if got := ExpandAlias("look", "sign"); got != "look sign" {
+        t.Fatalf("expected args appended, got %q", got)
+    }
+}
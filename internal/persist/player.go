@@ -5,9 +5,12 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"njata/internal/game"
+	"njata/internal/quests"
 	"njata/internal/skills"
 )
 
@@ -22,7 +25,10 @@ type PlayerRecord struct {
 	MaxHP        int                                 `json:"max_hp"`
 	Mana         int                                 `json:"mana"`
 	MaxMana      int                                 `json:"max_mana"`
+	Move         int                                 `json:"move"`
+	MaxMove      int                                 `json:"max_move"`
 	Gold         int                                 `json:"gold"`
+	Bank         int                                 `json:"bank"`
 	Strength     int                                 `json:"strength"`
 	Dexterity    int                                 `json:"dexterity"`
 	Constitution int                                 `json:"constitution"`
@@ -32,9 +38,15 @@ type PlayerRecord struct {
 	Luck         int                                 `json:"luck"`
 	Armor        int                                 `json:"armor"`
 	Skills       map[int]*skills.PlayerSkillProgress `json:"skills"`
+	Quests       map[int]*quests.PlayerQuestProgress `json:"quests,omitempty"`
 	IsKeeper     bool                                `json:"is_keeper"`
+	PvP          bool                                `json:"pvp,omitempty"`
+	PvPToggled   time.Time                           `json:"pvp_toggled"`
 	Inventory    []game.Object                       `json:"inventory"`
 	Equipment    map[string]game.Object              `json:"equipment"`
+	Aliases      map[string]string                   `json:"aliases,omitempty"`
+	ChannelsOff  []string                            `json:"channels_off,omitempty"`
+	Ignoring     []string                            `json:"ignoring,omitempty"`
 }
 
 func LoadPlayer(dir string, name string) (*PlayerRecord, bool, error) {
@@ -80,6 +92,18 @@ func playerPath(dir string, name string) string {
 	return filepath.Join(dir, normalized+".json")
 }
 
+// sortedKeys returns the set members of a bool map in sorted order.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key, on := range set {
+		if on {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // PlayerToRecord converts a game.Player to a PlayerRecord for saving
 func PlayerToRecord(p *game.Player) PlayerRecord {
 	// Deep copy the Skills map to ensure proper serialization
@@ -90,6 +114,13 @@ func PlayerToRecord(p *game.Player) PlayerRecord {
 		}
 	}
 
+	questsCopy := make(map[int]*quests.PlayerQuestProgress, len(p.Quests))
+	for id, progress := range p.Quests {
+		progressCopy := *progress
+		progressCopy.Counts = append([]int(nil), progress.Counts...)
+		questsCopy[id] = &progressCopy
+	}
+
 	inventoryCopy := make([]game.Object, 0, len(p.Inventory))
 	for _, item := range p.Inventory {
 		if item != nil {
@@ -104,9 +135,20 @@ func PlayerToRecord(p *game.Player) PlayerRecord {
 		}
 	}
 
+	aliasesCopy := map[string]string{}
+	for name, template := range p.Aliases {
+		aliasesCopy[name] = template
+	}
+
+	// Idle players parked in limbo are saved at the room they left
+	location := p.Location
+	if p.IdleFrom != 0 {
+		location = p.IdleFrom
+	}
+
 	return PlayerRecord{
 		Name:         p.Name,
-		Location:     p.Location,
+		Location:     location,
 		Race:         p.Race,
 		Sex:          p.Sex,
 		Hair:         p.Hair,
@@ -115,7 +157,10 @@ func PlayerToRecord(p *game.Player) PlayerRecord {
 		MaxHP:        p.MaxHP,
 		Mana:         p.Mana,
 		MaxMana:      p.MaxMana,
+		Move:         p.Move,
+		MaxMove:      p.MaxMove,
 		Gold:         p.Gold,
+		Bank:         p.Bank,
 		Strength:     p.Strength,
 		Dexterity:    p.Dexterity,
 		Constitution: p.Constitution,
@@ -125,9 +170,15 @@ func PlayerToRecord(p *game.Player) PlayerRecord {
 		Luck:         p.Luck,
 		Armor:        p.Armor,
 		Skills:       skillsCopy,
+		Quests:       questsCopy,
 		IsKeeper:     p.IsKeeper,
+		PvP:          p.PvP,
+		PvPToggled:   p.PvPToggled,
 		Inventory:    inventoryCopy,
 		Equipment:    equipmentCopy,
+		Aliases:      aliasesCopy,
+		ChannelsOff:  sortedKeys(p.ChannelsOff),
+		Ignoring:     sortedKeys(p.Ignoring),
 	}
 }
 
@@ -142,7 +193,10 @@ func RecordToPlayer(p *game.Player, r *PlayerRecord) {
 	p.MaxHP = r.MaxHP
 	p.Mana = r.Mana
 	p.MaxMana = r.MaxMana
+	p.Move = r.Move
+	p.MaxMove = r.MaxMove
 	p.Gold = r.Gold
+	p.Bank = r.Bank
 	p.Strength = r.Strength
 	p.Dexterity = r.Dexterity
 	p.Constitution = r.Constitution
@@ -152,7 +206,10 @@ func RecordToPlayer(p *game.Player, r *PlayerRecord) {
 	p.Luck = r.Luck
 	p.Armor = r.Armor
 	p.Skills = r.Skills
+	p.Quests = r.Quests
 	p.IsKeeper = r.IsKeeper
+	p.PvP = r.PvP
+	p.PvPToggled = r.PvPToggled
 	if len(r.Inventory) > 0 {
 		p.Inventory = make([]*game.Object, 0, len(r.Inventory))
 		for _, item := range r.Inventory {
@@ -160,6 +217,24 @@ func RecordToPlayer(p *game.Player, r *PlayerRecord) {
 			p.Inventory = append(p.Inventory, &obj)
 		}
 	}
+	if len(r.ChannelsOff) > 0 {
+		p.ChannelsOff = make(map[string]bool, len(r.ChannelsOff))
+		for _, name := range r.ChannelsOff {
+			p.ChannelsOff[name] = true
+		}
+	}
+	if len(r.Ignoring) > 0 {
+		p.Ignoring = make(map[string]bool, len(r.Ignoring))
+		for _, name := range r.Ignoring {
+			p.Ignoring[name] = true
+		}
+	}
+	if len(r.Aliases) > 0 {
+		p.Aliases = make(map[string]string, len(r.Aliases))
+		for name, template := range r.Aliases {
+			p.Aliases[name] = template
+		}
+	}
 	if len(r.Equipment) > 0 {
 		p.Equipment = make(map[string]*game.Object)
 		for slot, item := range r.Equipment {
@@ -4,6 +4,7 @@ import (
     "testing"
 
     "njata/internal/game"
+    "njata/internal/quests"
 )
 
 func TestSaveAndLoadPlayer(t *testing.T) {
@@ -60,3 +61,25 @@ func TestLoadPlayerMissing(t *testing.T) {
         t.Fatalf("expected missing player result")
     }
 }
+
+func TestQuestProgressRoundTrip(t *testing.T) {
+    dir := t.TempDir()
+
+    player := &game.Player{Name: "Bob", Quests: map[int]*quests.PlayerQuestProgress{
+        1: {QuestID: 1, Completed: true},
+        2: {QuestID: 2, Counts: []int{3, 0}},
+    }}
+    if err := SavePlayer(dir, PlayerToRecord(player)); err != nil {
+        t.Fatalf("save player: %v", err)
+    }
+
+    loaded, _, err := LoadPlayer(dir, "Bob")
+    if err != nil {
+        t.Fatalf("load player: %v", err)
+    }
+    restored := &game.Player{}
+    RecordToPlayer(restored, loaded)
+    if len(restored.Quests) != 2 || !restored.Quests[1].Completed || restored.Quests[2].Counts[0] != 3 {
+        t.Fatalf("unexpected quests: %+v", restored.Quests)
+    }
+}
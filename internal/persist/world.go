@@ -0,0 +1,74 @@
+package persist
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"njata/internal/game"
+)
+
+// WorldStateRecord holds world state that survives a reboot: the calendar,
+// each area's weather, the economy ledger, the player-kill log and the
+// corpses of fallen players.
+type WorldStateRecord struct {
+	Time     game.GameTime           `json:"time"`
+	Weather  map[string]game.Weather `json:"weather"`
+	Economy  game.Ledger             `json:"economy"`
+	PvPKills []game.PKill            `json:"pvp_kills,omitempty"`
+	Corpses  []game.PlayerCorpse     `json:"corpses,omitempty"`
+}
+
+// LoadWorldState reads saved world state. The bool is false if nothing was saved yet.
+func LoadWorldState(path string) (*WorldStateRecord, bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	var record WorldStateRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, false, err
+	}
+
+	return &record, true, nil
+}
+
+func SaveWorldState(path string, record WorldStateRecord) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+// WorldStateToRecord captures the world's calendar, weather, ledger,
+// player-kill log and player corpses for saving.
+func WorldStateToRecord(w *game.World) WorldStateRecord {
+	return WorldStateRecord{
+		Time:     w.Clock(),
+		Weather:  w.WeatherSnapshot(),
+		Economy:  w.Ledger(),
+		PvPKills: w.PKills(),
+		Corpses:  w.PlayerCorpses(),
+	}
+}
+
+// ApplyWorldState restores a saved calendar, weather, ledger, player-kill
+// log and player corpses into the world.
+func ApplyWorldState(w *game.World, record *WorldStateRecord) {
+	w.SetClock(record.Time)
+	w.SetWeather(record.Weather)
+	w.SetLedger(record.Economy)
+	w.SetPKills(record.PvPKills)
+	w.RestorePlayerCorpses(record.Corpses)
+}
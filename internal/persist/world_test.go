@@ -0,0 +1,50 @@
+package persist
+
+import (
+	"path/filepath"
+	"testing"
+
+	"njata/internal/game"
+)
+
+func TestWorldStateRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "time.json")
+
+	if _, ok, err := LoadWorldState(path); err != nil || ok {
+		t.Fatalf("expected no saved state, got ok=%v err=%v", ok, err)
+	}
+
+	world := game.CreateDefaultWorld()
+	world.SetClock(game.GameTime{Hour: 21, Day: 3, Month: 9, Year: 640})
+	world.SetWeather(map[string]game.Weather{"": {Sky: game.SkyRaining, Pressure: 975, Change: -4}})
+	world.SetLedger(game.Ledger{Created: map[string]int{game.LedgerMobDrops: 40}, Days: []game.LedgerDay{{Year: 640, Created: 40}}})
+	world.SetPKills([]game.PKill{{Killer: "Alice", Victim: "Bob", Room: 3001}})
+
+	if err := SaveWorldState(path, WorldStateToRecord(world)); err != nil {
+		t.Fatalf("save world state: %v", err)
+	}
+
+	record, ok, err := LoadWorldState(path)
+	if err != nil || !ok {
+		t.Fatalf("load world state: ok=%v err=%v", ok, err)
+	}
+
+	restored := game.CreateDefaultWorld()
+	ApplyWorldState(restored, record)
+
+	if clock := restored.Clock(); clock != (game.GameTime{Hour: 21, Day: 3, Month: 9, Year: 640}) {
+		t.Fatalf("unexpected clock %+v", clock)
+	}
+	if !restored.IsNight() {
+		t.Fatalf("expected restored 9pm clock to be night")
+	}
+	if wx, _ := restored.AreaWeather(""); wx.Sky != game.SkyRaining || wx.Pressure != 975 {
+		t.Fatalf("unexpected weather %+v", wx)
+	}
+	if ledger := restored.Ledger(); ledger.Created[game.LedgerMobDrops] != 40 || len(ledger.Days) != 1 {
+		t.Fatalf("unexpected ledger %+v", ledger)
+	}
+	if kills := restored.PKills(); len(kills) != 1 || kills[0].Victim != "Bob" || kills[0].Room != 3001 {
+		t.Fatalf("unexpected player kills %+v", kills)
+	}
+}
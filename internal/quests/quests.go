@@ -0,0 +1,157 @@
+package quests
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Objective types
+const (
+	ObjectiveKill  = "kill"  // slay Count mobiles of Vnum
+	ObjectiveFetch = "fetch" // bring Count objects of Vnum back to the giver
+	ObjectiveVisit = "visit" // set foot in room Vnum
+	ObjectiveStudy = "study" // learn spell SpellID
+)
+
+// Objective is one goal a quest sets.
+type Objective struct {
+	Type        string `json:"type"`
+	Vnum        int    `json:"vnum"`     // mobile, object or room vnum
+	SpellID     int    `json:"spell_id"` // for study objectives
+	Count       int    `json:"count"`    // how many (kill and fetch; default 1)
+	Description string `json:"description"`
+}
+
+// Needed returns how many times the objective must be met.
+func (o Objective) Needed() int {
+	if o.Count < 1 || o.Type == ObjectiveVisit || o.Type == ObjectiveStudy {
+		return 1
+	}
+	return o.Count
+}
+
+// ProficiencyReward sharpens a spell the player already knows.
+type ProficiencyReward struct {
+	SpellID int `json:"spell_id"`
+	Amount  int `json:"amount"`
+}
+
+type Rewards struct {
+	Gold        int                `json:"gold"`
+	Items       []int              `json:"items"`       // object vnums
+	Proficiency *ProficiencyReward `json:"proficiency"` // optional
+	TeachSpell  int                `json:"teach_spell"` // spell ID learned on completion (0 = none)
+}
+
+type Quest struct {
+	ID            int         `json:"id"`
+	Name          string      `json:"name"`
+	Description   string      `json:"description"`
+	Giver         int         `json:"giver"`         // mobile vnum that offers and accepts the quest
+	Prerequisites []int       `json:"prerequisites"` // quest IDs that must be completed first
+	Objectives    []Objective `json:"objectives"`
+	Rewards       Rewards     `json:"rewards"`
+	AcceptText    string      `json:"accept_text"`   // what the giver says when the quest is taken
+	CompleteText  string      `json:"complete_text"` // what the giver says when it is handed in
+}
+
+var (
+	questRegistry map[int]*Quest
+	mu            sync.RWMutex
+)
+
+func init() {
+	questRegistry = make(map[int]*Quest)
+}
+
+// Load loads all quests from a JSON file
+func Load(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read quests file: %w", err)
+	}
+
+	var list []Quest
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("failed to parse quests JSON: %w", err)
+	}
+
+	for _, quest := range list {
+		for _, objective := range quest.Objectives {
+			switch objective.Type {
+			case ObjectiveKill, ObjectiveFetch, ObjectiveVisit, ObjectiveStudy:
+			default:
+				return fmt.Errorf("quest %d: unknown objective type %q", quest.ID, objective.Type)
+			}
+		}
+	}
+
+	Set(list)
+	return nil
+}
+
+// Set replaces the loaded quests.
+func Set(list []Quest) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	questRegistry = make(map[int]*Quest, len(list))
+	for i := range list {
+		quest := &list[i]
+		questRegistry[quest.ID] = quest
+	}
+}
+
+// GetQuest retrieves a quest by ID
+func GetQuest(id int) *Quest {
+	mu.RLock()
+	defer mu.RUnlock()
+	return questRegistry[id]
+}
+
+// AllQuests returns every loaded quest, ordered by ID
+func AllQuests() []*Quest {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	list := make([]*Quest, 0, len(questRegistry))
+	for _, quest := range questRegistry {
+		list = append(list, quest)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+// Match reports whether arg names the quest: its ID, or a prefix of its
+// name or of any word in it (case-insensitive).
+func (q *Quest) Match(arg string) bool {
+	arg = strings.ToLower(strings.TrimSpace(arg))
+	if arg == "" {
+		return false
+	}
+	if id, err := strconv.Atoi(arg); err == nil {
+		return id == q.ID
+	}
+	name := strings.ToLower(q.Name)
+	if strings.HasPrefix(name, arg) {
+		return true
+	}
+	for _, word := range strings.Fields(name) {
+		if strings.HasPrefix(word, arg) {
+			return true
+		}
+	}
+	return false
+}
+
+// PlayerQuestProgress tracks a player's state on one quest
+type PlayerQuestProgress struct {
+	QuestID   int   `json:"quest_id"`
+	Counts    []int `json:"counts"`    // per objective: kills made, rooms visited
+	Completed bool  `json:"completed"` // handed in and rewarded
+}
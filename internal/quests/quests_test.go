@@ -0,0 +1,79 @@
+package quests
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+var questsPath string
+
+func init() {
+	_, thisFile, _, _ := runtime.Caller(0)
+	projectRoot := filepath.Dir(filepath.Dir(filepath.Dir(thisFile)))
+	questsPath = filepath.Join(projectRoot, "quests", "quests.json")
+}
+
+func TestLoadQuests(t *testing.T) {
+	if err := Load(questsPath); err != nil {
+		t.Fatalf("Failed to load quests: %v", err)
+	}
+
+	all := AllQuests()
+	if len(all) == 0 {
+		t.Fatal("Expected quests to be loaded, got none")
+	}
+	for i, quest := range all {
+		if i > 0 && all[i-1].ID >= quest.ID {
+			t.Fatalf("expected quests ordered by ID, got %d before %d", all[i-1].ID, quest.ID)
+		}
+		if quest.Giver == 0 || len(quest.Objectives) == 0 {
+			t.Errorf("quest %d needs a giver and objectives", quest.ID)
+		}
+		for _, id := range quest.Prerequisites {
+			if GetQuest(id) == nil {
+				t.Errorf("quest %d depends on missing quest %d", quest.ID, id)
+			}
+		}
+	}
+}
+
+func TestLoadRejectsUnknownObjective(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "quests.json")
+	data := `[{"id": 1, "name": "Odd Jobs", "objectives": [{"type": "dance"}]}]`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatalf("write quests: %v", err)
+	}
+
+	if err := Load(path); err == nil {
+		t.Fatal("expected an unknown objective type to be rejected")
+	}
+}
+
+func TestMatch(t *testing.T) {
+	quest := &Quest{ID: 3, Name: "The Restless Colony"}
+
+	for _, arg := range []string{"3", "the", "restless", "COL"} {
+		if !quest.Match(arg) {
+			t.Errorf("expected %q to match", arg)
+		}
+	}
+	for _, arg := range []string{"4", "ants", ""} {
+		if quest.Match(arg) {
+			t.Errorf("expected %q not to match", arg)
+		}
+	}
+}
+
+func TestObjectiveNeeded(t *testing.T) {
+	if n := (Objective{Type: ObjectiveKill, Count: 5}).Needed(); n != 5 {
+		t.Fatalf("expected 5 kills, got %d", n)
+	}
+	if n := (Objective{Type: ObjectiveFetch}).Needed(); n != 1 {
+		t.Fatalf("expected a fetch to default to one, got %d", n)
+	}
+	if n := (Objective{Type: ObjectiveVisit, Count: 3}).Needed(); n != 1 {
+		t.Fatalf("expected a visit to need one, got %d", n)
+	}
+}
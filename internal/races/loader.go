@@ -10,20 +10,51 @@ import (
 
 // RaceJSON represents a race definition in JSON format
 type RaceJSON struct {
-	Name       string `json:"name"`
-	RaceID     int    `json:"race_id"`
-	FlavorText string `json:"flavor_text"`
-	StrPlus    int    `json:"str_plus"`
-	DexPlus    int    `json:"dex_plus"`
-	ConPlus    int    `json:"con_plus"`
-	IntPlus    int    `json:"int_plus"`
-	WisPlus    int    `json:"wis_plus"`
-	ChaPlus    int    `json:"cha_plus"`
-	LuckPlus   int    `json:"lck_plus"`
-	HPBonus    int    `json:"hp_bonus"`
-	ManaBonus  int    `json:"mana_bonus"`
-	HPRegen    int    `json:"hp_regen"`
-	ManaRegen  int    `json:"mana_regen"`
+	Name       string   `json:"name"`
+	RaceID     int      `json:"race_id"`
+	FlavorText string   `json:"flavor_text"`
+	StrPlus    int      `json:"str_plus"`
+	DexPlus    int      `json:"dex_plus"`
+	ConPlus    int      `json:"con_plus"`
+	IntPlus    int      `json:"int_plus"`
+	WisPlus    int      `json:"wis_plus"`
+	ChaPlus    int      `json:"cha_plus"`
+	LuckPlus   int      `json:"lck_plus"`
+	HPBonus    int      `json:"hp_bonus"`
+	ManaBonus  int      `json:"mana_bonus"`
+	HPRegen    int      `json:"hp_regen"`
+	ManaRegen  int      `json:"mana_regen"`
+	Vision     string   `json:"vision,omitempty"`    // "", "infravision" or "darkvision"
+	Abilities  []string `json:"abilities,omitempty"` // innate abilities, e.g. "flight"
+
+	// Damage defenses: damage types (fire, cold, magic, ...) the race takes
+	// half, no or half again as much damage from.
+	Resistances     []string `json:"resistances,omitempty"`
+	Immunities      []string `json:"immunities,omitempty"`
+	Vulnerabilities []string `json:"vulnerabilities,omitempty"`
+}
+
+// Racial vision kinds.
+const (
+	VisionInfravision = "infravision" // sees creatures, but not rooms or objects, in the dark
+	VisionDarkvision  = "darkvision"  // sees everything in the dark
+)
+
+// Innate racial abilities.
+const (
+	AbilityAquaBreath = "aqua_breath" // breathes underwater
+	AbilityFlight     = "flight"      // flies over air and water rooms
+	AbilitySwimming   = "swimming"    // swims deep water without a boat
+)
+
+// HasAbility reports whether the race has the named innate ability.
+func (r *RaceJSON) HasAbility(ability string) bool {
+	for _, a := range r.Abilities {
+		if a == ability {
+			return true
+		}
+	}
+	return false
 }
 
 var (
@@ -72,6 +103,16 @@ func Load(racesDir string) error {
 	return nil
 }
 
+// Preserve saves the loaded races and returns a function that puts them back.
+// Tests that load races of their own use it to leave the registry as they
+// found it: t.Cleanup(races.Preserve()).
+func Preserve() func() {
+	byID, byName, list := racesByID, racesByName, raceList
+	return func() {
+		racesByID, racesByName, raceList = byID, byName, list
+	}
+}
+
 // GetByID returns a race by its ID
 func GetByID(id int) *RaceJSON {
 	return racesByID[id]
@@ -0,0 +1,396 @@
+// Package script runs the small trigger scripts builders attach to rooms,
+// mobiles and objects in area files. The language is modelled on SMAUG
+// mudprogs: one command per line, with if/else/endif blocks, and $n, $i and
+// $t standing for the triggering character, the script's owner and the text
+// that set it off. Scripts reach the world only through a Host, so they can
+// do nothing the Host doesn't offer.
+package script
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Trigger types.
+const (
+	TriggerSpeech  = "speech"  // a player says something containing one of Arg's words
+	TriggerGreet   = "greet"   // a player enters the room; Arg is the percent chance
+	TriggerExit    = "exit"    // a player leaves the room; Arg limits it to one direction
+	TriggerFight   = "fight"   // the mobile takes a turn in combat; Arg is the percent chance
+	TriggerDeath   = "death"   // the mobile dies; Arg is the percent chance
+	TriggerGive    = "give"    // a player gives the mobile an item matching Arg ("all" for any)
+	TriggerRandom  = "random"  // each world update while players are nearby; Arg is the percent chance
+	TriggerTimer   = "timer"   // every Arg world updates (see Interval)
+	TriggerCommand = "command" // a player types the command word in Arg; the script replaces it
+)
+
+// triggerAliases maps SMAUG's names for triggers to ours.
+var triggerAliases = map[string]string{
+	"entry":     TriggerGreet,
+	"all_greet": TriggerGreet,
+	"rand":      TriggerRandom,
+	"time":      TriggerTimer,
+}
+
+// NormalizeTrigger returns the trigger type for name, accepting aliases and
+// a SMAUG-style "_prog" suffix, or "" if name is not a trigger.
+func NormalizeTrigger(name string) string {
+	name = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), "_prog")
+	if alias, ok := triggerAliases[name]; ok {
+		return alias
+	}
+	switch name {
+	case TriggerSpeech, TriggerGreet, TriggerExit, TriggerFight, TriggerDeath,
+		TriggerGive, TriggerRandom, TriggerTimer, TriggerCommand:
+		return name
+	}
+	return ""
+}
+
+// Percent reads a trigger's percent-chance argument. An empty argument
+// means always.
+func Percent(arg string) int {
+	if strings.TrimSpace(arg) == "" {
+		return 100
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(arg))
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// DefaultInterval is how many world updates apart a timer script runs when
+// its argument is empty: every update.
+const DefaultInterval = 1
+
+// Interval reads a timer trigger's argument: how many world updates apart
+// the script runs. An empty argument means DefaultInterval.
+func Interval(arg string) (int, error) {
+	arg = strings.TrimSpace(arg)
+	if arg == "" {
+		return DefaultInterval, nil
+	}
+	n, err := strconv.Atoi(arg)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("timer interval %q is not a positive number of updates", arg)
+	}
+	return n, nil
+}
+
+// SpeechMatches reports whether message sets off a speech trigger with arg.
+// As with SMAUG speech progs, an argument starting "p " must appear as a
+// phrase; otherwise any one of its words will do.
+func SpeechMatches(arg, message string) bool {
+	message = strings.ToLower(message)
+	arg = strings.ToLower(strings.TrimSpace(arg))
+	if phrase, ok := strings.CutPrefix(arg, "p "); ok {
+		return strings.Contains(message, strings.TrimSpace(phrase))
+	}
+	words := strings.FieldsFunc(message, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '\'' || r == '-')
+	})
+	for _, want := range strings.Fields(arg) {
+		for _, word := range words {
+			if word == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// MaxSteps caps how many lines one run of a script may execute.
+const MaxSteps = 200
+
+// Script is one trigger and the code it runs, as written in an area file.
+type Script struct {
+	Trigger string   `json:"trigger"`
+	Arg     string   `json:"arg,omitempty"`
+	Code    []string `json:"code"`
+
+	prog *Program // parsed by Prepare
+}
+
+// Prepare parses the script's code and keeps the result for Program.
+func (s *Script) Prepare() error {
+	prog, err := Parse(s.Code)
+	if err != nil {
+		return err
+	}
+	s.prog = prog
+	return nil
+}
+
+// Program returns the script's parsed code, parsing it now if Prepare never
+// ran.
+func (s Script) Program() (*Program, error) {
+	if s.prog != nil {
+		return s.prog, nil
+	}
+	return Parse(s.Code)
+}
+
+// Host is the world as a script sees it. Messages are already expanded.
+type Host interface {
+	Say(text string) error
+	Emote(text string) error
+	Echo(text string) error       // to everyone in the room
+	EchoAt(text string) error     // to the triggering player
+	EchoAround(text string) error // to the room except the triggering player
+	Transfer(vnum int) error      // moves the triggering player
+	Goto(vnum int) error          // moves the script's mobile
+	SpawnMobile(vnum int) error   // into the room
+	SpawnObject(vnum int) error   // onto the floor
+	GiveObject(vnum int) error    // into the triggering player's inventory
+	SetRoomFlag(flag string, on bool) error
+
+	HasActor() bool // a player set the script off
+	ActorIsKeeper() bool
+	ActorCarries(keyword string) bool
+	RoomFlag(flag string) bool
+	HPPercent() int // the script's mobile's health, 100 for rooms and objects
+	Roll(percent int) bool
+}
+
+// Vars are the $ substitutions for a run: "n", "i" and "t".
+type Vars map[string]string
+
+// line is one parsed line of a script.
+type line struct {
+	number int
+	verb   string
+	arg    string
+}
+
+// Program is a parsed script, ready to run.
+type Program struct {
+	lines []line
+}
+
+// commands maps each script command to what it does with its argument.
+var commands = map[string]func(h Host, arg string) error{
+	"say":        func(h Host, arg string) error { return h.Say(arg) },
+	"emote":      func(h Host, arg string) error { return h.Emote(arg) },
+	"echo":       func(h Host, arg string) error { return h.Echo(arg) },
+	"echoat":     func(h Host, arg string) error { return h.EchoAt(arg) },
+	"echoaround": func(h Host, arg string) error { return h.EchoAround(arg) },
+	"transfer":   vnumCommand(Host.Transfer),
+	"goto":       vnumCommand(Host.Goto),
+	"mload":      vnumCommand(Host.SpawnMobile),
+	"oload":      vnumCommand(Host.SpawnObject),
+	"give":       vnumCommand(Host.GiveObject),
+	"setflag":    func(h Host, arg string) error { return h.SetRoomFlag(arg, true) },
+	"clearflag":  func(h Host, arg string) error { return h.SetRoomFlag(arg, false) },
+}
+
+func vnumCommand(fn func(Host, int) error) func(Host, string) error {
+	return func(h Host, arg string) error {
+		vnum, err := strconv.Atoi(arg)
+		if err != nil {
+			return fmt.Errorf("expected a vnum, got %q", arg)
+		}
+		return fn(h, vnum)
+	}
+}
+
+// Parse checks a script's code and prepares it to run.
+func Parse(code []string) (*Program, error) {
+	prog := &Program{}
+	depth := 0
+	for i, raw := range code {
+		text := strings.TrimSpace(raw)
+		if text == "" || strings.HasPrefix(text, "*") {
+			continue
+		}
+		verb, arg, _ := strings.Cut(text, " ")
+		l := line{number: i + 1, verb: strings.ToLower(verb), arg: strings.TrimSpace(arg)}
+
+		switch l.verb {
+		case "if":
+			if l.arg == "" {
+				return nil, fmt.Errorf("line %d: if without a condition", l.number)
+			}
+			depth++
+		case "else":
+			if depth == 0 {
+				return nil, fmt.Errorf("line %d: else without if", l.number)
+			}
+		case "endif":
+			if depth == 0 {
+				return nil, fmt.Errorf("line %d: endif without if", l.number)
+			}
+			depth--
+		default:
+			if _, ok := commands[l.verb]; !ok {
+				return nil, fmt.Errorf("line %d: unknown command %q", l.number, verb)
+			}
+		}
+		prog.lines = append(prog.lines, l)
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("missing endif")
+	}
+	return prog, nil
+}
+
+// block tracks one if/else/endif while running.
+type block struct {
+	parentRunning bool
+	taken         bool // this if's branch ran
+}
+
+// Run executes the program against host.
+func (p *Program) Run(h Host, vars Vars) error {
+	running := true
+	var stack []block
+	steps := 0
+
+	for _, l := range p.lines {
+		steps++
+		if steps > MaxSteps {
+			return fmt.Errorf("line %d: script exceeded %d steps", l.number, MaxSteps)
+		}
+
+		switch l.verb {
+		case "if":
+			b := block{parentRunning: running}
+			if running {
+				ok, err := evaluate(h, expand(l.arg, vars))
+				if err != nil {
+					return fmt.Errorf("line %d: %w", l.number, err)
+				}
+				b.taken = ok
+				running = ok
+			}
+			stack = append(stack, b)
+			continue
+		case "else":
+			b := stack[len(stack)-1]
+			running = b.parentRunning && !b.taken
+			continue
+		case "endif":
+			running = stack[len(stack)-1].parentRunning
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		if !running {
+			continue
+		}
+		if err := commands[l.verb](h, expand(l.arg, vars)); err != nil {
+			return fmt.Errorf("line %d: %s: %w", l.number, l.verb, err)
+		}
+	}
+	return nil
+}
+
+// evaluate tests an if condition. Conditions may be negated with "not":
+//
+//	rand <percent>      succeeds percent% of the time
+//	ispc                a player set the script off
+//	iskeeper            that player is a keeper
+//	carries <keyword>   that player carries a matching item
+//	roomflag <flag>     the room has the flag
+//	hp <op> <percent>   the mobile's health compared with <, <=, >, >=, == or !=
+func evaluate(h Host, cond string) (bool, error) {
+	fields := strings.Fields(strings.ToLower(cond))
+	if len(fields) == 0 {
+		return false, fmt.Errorf("empty condition")
+	}
+	if fields[0] == "not" {
+		ok, err := evaluate(h, strings.Join(fields[1:], " "))
+		return !ok, err
+	}
+
+	name, args := fields[0], fields[1:]
+	need := func(n int) error {
+		if len(args) != n {
+			return fmt.Errorf("%s takes %d argument(s)", name, n)
+		}
+		return nil
+	}
+
+	switch name {
+	case "rand":
+		if err := need(1); err != nil {
+			return false, err
+		}
+		percent, err := strconv.Atoi(args[0])
+		if err != nil {
+			return false, fmt.Errorf("rand needs a percent, got %q", args[0])
+		}
+		return h.Roll(percent), nil
+	case "ispc":
+		return h.HasActor(), need(0)
+	case "iskeeper":
+		return h.HasActor() && h.ActorIsKeeper(), need(0)
+	case "carries":
+		if err := need(1); err != nil {
+			return false, err
+		}
+		return h.HasActor() && h.ActorCarries(args[0]), nil
+	case "roomflag":
+		if err := need(1); err != nil {
+			return false, err
+		}
+		return h.RoomFlag(args[0]), nil
+	case "hp":
+		if err := need(2); err != nil {
+			return false, err
+		}
+		value, err := strconv.Atoi(args[1])
+		if err != nil {
+			return false, fmt.Errorf("hp needs a number, got %q", args[1])
+		}
+		return compare(h.HPPercent(), args[0], value)
+	}
+	return false, fmt.Errorf("unknown condition %q", name)
+}
+
+func compare(a int, op string, b int) (bool, error) {
+	switch op {
+	case "<":
+		return a < b, nil
+	case "<=":
+		return a <= b, nil
+	case ">":
+		return a > b, nil
+	case ">=":
+		return a >= b, nil
+	case "==", "=":
+		return a == b, nil
+	case "!=":
+		return a != b, nil
+	}
+	return false, fmt.Errorf("unknown comparison %q", op)
+}
+
+// expand replaces $n, $i and $t with their values and $$ with a dollar sign.
+// Unknown codes are left alone.
+func expand(text string, vars Vars) string {
+	if !strings.Contains(text, "$") {
+		return text
+	}
+	var b strings.Builder
+	for i := 0; i < len(text); i++ {
+		if text[i] != '$' || i+1 >= len(text) {
+			b.WriteByte(text[i])
+			continue
+		}
+		code := text[i+1]
+		if code == '$' {
+			b.WriteByte('$')
+			i++
+			continue
+		}
+		if value, ok := vars[string(code)]; ok {
+			b.WriteString(value)
+			i++
+			continue
+		}
+		b.WriteByte('$')
+	}
+	return b.String()
+}
@@ -0,0 +1,198 @@
+package script
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// fakeHost records what a script asked for.
+type fakeHost struct {
+	log     []string
+	actor   bool
+	keeper  bool
+	carries map[string]bool
+	flags   map[string]bool
+	hp      int
+	fail    error
+}
+
+func (h *fakeHost) record(entry string) error {
+	h.log = append(h.log, entry)
+	return h.fail
+}
+
+func (h *fakeHost) Say(text string) error        { return h.record("say " + text) }
+func (h *fakeHost) Emote(text string) error      { return h.record("emote " + text) }
+func (h *fakeHost) Echo(text string) error       { return h.record("echo " + text) }
+func (h *fakeHost) EchoAt(text string) error     { return h.record("echoat " + text) }
+func (h *fakeHost) EchoAround(text string) error { return h.record("echoaround " + text) }
+func (h *fakeHost) Transfer(vnum int) error      { return h.record("transfer") }
+func (h *fakeHost) Goto(vnum int) error          { return h.record("goto") }
+func (h *fakeHost) SpawnMobile(vnum int) error   { return h.record("mload") }
+func (h *fakeHost) SpawnObject(vnum int) error   { return h.record("oload") }
+func (h *fakeHost) GiveObject(vnum int) error    { return h.record("give") }
+func (h *fakeHost) SetRoomFlag(flag string, on bool) error {
+	h.flags[flag] = on
+	return h.record("flag " + flag)
+}
+func (h *fakeHost) HasActor() bool                   { return h.actor }
+func (h *fakeHost) ActorIsKeeper() bool              { return h.keeper }
+func (h *fakeHost) ActorCarries(keyword string) bool { return h.carries[keyword] }
+func (h *fakeHost) RoomFlag(flag string) bool        { return h.flags[flag] }
+func (h *fakeHost) HPPercent() int                   { return h.hp }
+func (h *fakeHost) Roll(percent int) bool            { return percent >= 100 }
+
+func newFakeHost() *fakeHost {
+	return &fakeHost{actor: true, carries: map[string]bool{}, flags: map[string]bool{}, hp: 100}
+}
+
+func run(t *testing.T, h Host, code ...string) error {
+	t.Helper()
+	prog, err := Parse(code)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	return prog.Run(h, Vars{"n": "Alice", "i": "the guard", "t": "hello"})
+}
+
+func TestRunExpandsVariables(t *testing.T) {
+	h := newFakeHost()
+	if err := run(t, h, "say Welcome, $n!", "* a comment", "", "emote ($i) heard '$t' and costs $$5"); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	want := []string{"say Welcome, Alice!", "emote (the guard) heard 'hello' and costs $5"}
+	if strings.Join(h.log, "|") != strings.Join(want, "|") {
+		t.Fatalf("expected %v, got %v", want, h.log)
+	}
+}
+
+func TestRunFollowsIfElse(t *testing.T) {
+	h := newFakeHost()
+	h.carries["key"] = true
+	err := run(t, h,
+		"if carries key",
+		"  if hp < 50",
+		"    say hurt",
+		"  else",
+		"    say healthy",
+		"  endif",
+		"else",
+		"  say no key",
+		"endif",
+		"if not roomflag dark",
+		"  setflag dark",
+		"endif",
+	)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	if strings.Join(h.log, "|") != "say healthy|flag dark" || !h.flags["dark"] {
+		t.Fatalf("unexpected log %v", h.log)
+	}
+}
+
+func TestParseRejectsBadScripts(t *testing.T) {
+	cases := map[string][]string{
+		"unknown command": {"shutdown"},
+		"missing endif":   {"if ispc", "say hi"},
+		"stray else":      {"else"},
+		"empty if":        {"if"},
+	}
+	for name, code := range cases {
+		if _, err := Parse(code); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestPrepareKeepsTheParsedProgram(t *testing.T) {
+	s := Script{Trigger: TriggerSpeech, Code: []string{"say hi"}}
+	if err := s.Prepare(); err != nil {
+		t.Fatalf("prepare: %v", err)
+	}
+	s.Code = []string{"shutdown"}
+
+	prog, err := s.Program()
+	if err != nil {
+		t.Fatalf("expected the prepared program, got %v", err)
+	}
+	h := newFakeHost()
+	if err := prog.Run(h, Vars{}); err != nil || strings.Join(h.log, "|") != "say hi" {
+		t.Fatalf("expected the prepared code to run, got %v err %v", h.log, err)
+	}
+
+	if _, err := (Script{Code: []string{"shutdown"}}).Program(); err == nil {
+		t.Fatalf("expected an unprepared script to be parsed on demand")
+	}
+}
+
+func TestInterval(t *testing.T) {
+	if n, err := Interval(""); err != nil || n != DefaultInterval {
+		t.Fatalf("expected the default interval, got %d %v", n, err)
+	}
+	if n, err := Interval(" 4 "); err != nil || n != 4 {
+		t.Fatalf("expected 4, got %d %v", n, err)
+	}
+	for _, arg := range []string{"0", "-2", "often"} {
+		if _, err := Interval(arg); err == nil {
+			t.Errorf("%q: expected an error", arg)
+		}
+	}
+}
+
+func TestRunReportsErrors(t *testing.T) {
+	h := newFakeHost()
+	h.fail = errors.New("no such room")
+	err := run(t, h, "say first", "transfer 99")
+	if err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Fatalf("expected an error on line 1, got %v", err)
+	}
+
+	h = newFakeHost()
+	if err := run(t, h, "transfer north"); err == nil {
+		t.Fatalf("expected a bad vnum to fail")
+	}
+	if err := run(t, h, "if frobnicate", "endif"); err == nil {
+		t.Fatalf("expected an unknown condition to fail")
+	}
+}
+
+func TestRunCapsSteps(t *testing.T) {
+	code := make([]string, MaxSteps+1)
+	for i := range code {
+		code[i] = "echo spam"
+	}
+	if err := run(t, newFakeHost(), code...); err == nil {
+		t.Fatalf("expected a long script to be stopped")
+	}
+}
+
+func TestSpeechMatches(t *testing.T) {
+	if !SpeechMatches("hello hi", "Well, hello there!") {
+		t.Errorf("expected a keyword match")
+	}
+	if SpeechMatches("hi", "this is it") {
+		t.Errorf("expected whole words only")
+	}
+	if !SpeechMatches("p gear me", "Please gear me up") || SpeechMatches("p gear me", "gear for me") {
+		t.Errorf("expected phrase matching")
+	}
+}
+
+func TestNormalizeTrigger(t *testing.T) {
+	cases := map[string]string{
+		"speech":         TriggerSpeech,
+		"all_greet_prog": TriggerGreet,
+		"entry":          TriggerGreet,
+		"rand_prog":      TriggerRandom,
+		"bogus":          "",
+	}
+	for name, want := range cases {
+		if got := NormalizeTrigger(name); got != want {
+			t.Errorf("%s: expected %q, got %q", name, want, got)
+		}
+	}
+}
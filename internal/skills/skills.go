@@ -10,16 +10,19 @@ import (
 )
 
 type Targeting struct {
-	Mode   string `json:"mode"`   // hostile_single, hostile_area, ally_single, self, any_object
+	Mode   string `json:"mode"`   // hostile_single, hostile_area, ally_single, self, any_object, any_player
 	Range  int    `json:"range"`  // Maximum range in squares
 	Radius int    `json:"radius"` // Area effect radius (0 for single target)
 }
 
 type Effects struct {
+	Type       string  `json:"type"`        // Effect handler for non-damage spells: heal, teleport, blink, identify, detect, summon
+	Room       int     `json:"room"`        // Destination for teleport (0 = the recall room)
 	Damage     string  `json:"damage"`      // Damage formula: "4d8 + I"
 	DamageType string  `json:"damage_type"` // fire, cold, magic, none
 	SaveType   string  `json:"save_type"`   // reflex, will, fortitude, none
 	SaveDC     int     `json:"save_dc"`     // Difficulty class for save
+	SaveEffect string  `json:"save_effect"` // half (default) or negate
 	Healing    string  `json:"healing"`     // Healing formula (if applicable)
 	Affect     *Affect `json:"affect"`      // Optional: buff/debuff effect
 }
@@ -90,6 +93,21 @@ func Load(path string) error {
 	return nil
 }
 
+// Preserve saves the loaded spells and returns a function that puts them
+// back. Tests that load spells of their own use it to leave the registry as
+// they found it: t.Cleanup(skills.Preserve()).
+func Preserve() func() {
+	mu.RLock()
+	registry, byName := spellRegistry, spellsByName
+	mu.RUnlock()
+
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		spellRegistry, spellsByName = registry, byName
+	}
+}
+
 // GetSpell retrieves a spell by ID
 func GetSpell(id int) *Spell {
 	mu.RLock()
@@ -0,0 +1,174 @@
+package socials
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Social is one emote command with messages for each perspective, following
+// the SMAUG socials.dat fields. Templates use $n/$N for the actor/victim and
+// $e/$m/$s (and $E/$M/$S) for their pronouns.
+type Social struct {
+	Name        string `json:"name"`
+	CharNoArg   string `json:"char_no_arg"`
+	OthersNoArg string `json:"others_no_arg"`
+	CharFound   string `json:"char_found"`
+	OthersFound string `json:"others_found"`
+	VictFound   string `json:"vict_found"`
+	CharAuto    string `json:"char_auto"`
+	OthersAuto  string `json:"others_auto"`
+}
+
+var (
+	socialsByName map[string]*Social
+	names         []string // sorted, for prefix matching and listing
+	mu            sync.RWMutex
+)
+
+func init() {
+	socialsByName = make(map[string]*Social)
+}
+
+// Load reads socials from path. Files ending in .json hold a JSON array of
+// socials; anything else is parsed as a legacy socials.dat file.
+func Load(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to read socials file: %w", err)
+	}
+	defer file.Close()
+
+	var list []Social
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		if err := json.NewDecoder(file).Decode(&list); err != nil {
+			return fmt.Errorf("failed to parse socials JSON: %w", err)
+		}
+	} else {
+		list, err = ParseDat(file)
+		if err != nil {
+			return err
+		}
+	}
+
+	Set(list)
+	return nil
+}
+
+// Set replaces the loaded socials.
+func Set(list []Social) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	socialsByName = make(map[string]*Social, len(list))
+	names = make([]string, 0, len(list))
+	for i := range list {
+		social := &list[i]
+		key := strings.ToLower(social.Name)
+		if key == "" {
+			continue
+		}
+		if _, exists := socialsByName[key]; !exists {
+			names = append(names, key)
+		}
+		socialsByName[key] = social
+	}
+	sort.Strings(names)
+}
+
+// ParseDat converts the legacy SMAUG socials.dat format.
+func ParseDat(r io.Reader) ([]Social, error) {
+	var list []Social
+	var current *Social
+
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "" || strings.HasPrefix(line, "*"):
+			continue
+		case line == "#SOCIAL":
+			current = &Social{}
+			continue
+		case line == "#END":
+			return list, nil
+		case line == "End":
+			if current != nil && current.Name != "" {
+				list = append(list, *current)
+			}
+			current = nil
+			continue
+		}
+
+		if current == nil {
+			return nil, fmt.Errorf("socials line %d: field outside #SOCIAL block", lineNo)
+		}
+
+		key, value, _ := strings.Cut(line, " ")
+		value = strings.TrimSuffix(strings.TrimSpace(value), "~")
+
+		switch key {
+		case "Name":
+			current.Name = strings.ToLower(value)
+		case "CharNoArg":
+			current.CharNoArg = value
+		case "OthersNoArg":
+			current.OthersNoArg = value
+		case "CharFound":
+			current.CharFound = value
+		case "OthersFound":
+			current.OthersFound = value
+		case "VictFound":
+			current.VictFound = value
+		case "CharAuto":
+			current.CharAuto = value
+		case "OthersAuto":
+			current.OthersAuto = value
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read socials: %w", err)
+	}
+
+	return list, nil
+}
+
+// Find returns the social named command, preferring an exact match and then
+// the first social (alphabetically) that command is a prefix of.
+func Find(command string) *Social {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	command = strings.ToLower(command)
+	if command == "" {
+		return nil
+	}
+
+	if social, ok := socialsByName[command]; ok {
+		return social
+	}
+
+	index := sort.SearchStrings(names, command)
+	if index < len(names) && strings.HasPrefix(names[index], command) {
+		return socialsByName[names[index]]
+	}
+
+	return nil
+}
+
+// Names returns the loaded social names in alphabetical order.
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return append([]string(nil), names...)
+}
@@ -0,0 +1,57 @@
+package socials
+
+import (
+	"strings"
+	"testing"
+)
+
+const sampleDat = `#SOCIAL
+Name        bow~
+CharNoArg   You bow deeply.~
+OthersNoArg $n bows deeply.~
+CharFound   You bow before $M.~
+OthersFound $n bows before $N.~
+VictFound   $n bows before you.~
+End
+
+#SOCIAL
+Name        bounce~
+CharNoArg   BOIINNNNNNGG!~
+End
+
+#END
+`
+
+func TestParseDat(t *testing.T) {
+	list, err := ParseDat(strings.NewReader(sampleDat))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(list) != 2 {
+		t.Fatalf("expected 2 socials, got %d", len(list))
+	}
+	if list[0].Name != "bow" || list[0].CharFound != "You bow before $M." {
+		t.Fatalf("unexpected social: %+v", list[0])
+	}
+	if list[1].OthersNoArg != "" {
+		t.Fatalf("expected missing field to stay empty")
+	}
+}
+
+func TestFindExactAndPrefix(t *testing.T) {
+	list, _ := ParseDat(strings.NewReader(sampleDat))
+	Set(list)
+
+	if social := Find("bow"); social == nil || social.Name != "bow" {
+		t.Fatalf("expected exact match for bow")
+	}
+	if social := Find("bou"); social == nil || social.Name != "bounce" {
+		t.Fatalf("expected prefix match for bounce")
+	}
+	if social := Find("bo"); social == nil || social.Name != "bounce" {
+		t.Fatalf("expected first alphabetical match for bo")
+	}
+	if Find("zzz") != nil {
+		t.Fatalf("expected no match")
+	}
+}